@@ -2,3 +2,4 @@ package api
 
 //go:generate go tool oapi-codegen --config=server-cfg.yaml ../../api.yaml
 //go:generate go tool oapi-codegen --config=types-cfg.yaml ../../api.yaml
+//go:generate go run github.com/bufbuild/buf/cmd/buf@v1.47.2 generate
@@ -0,0 +1,476 @@
+// Protocol spoken between Compass and external mapper plugins. The plugin
+// runs as a subprocess of Compass and serves MapperService; the methods
+// mirror the mapper.Mapper interface. Breaking changes require a new
+// package version and a new handshake protocol version.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: compass/mapper/v1/mapper.proto
+
+package mapperv1
+
+import (
+	compassv1 "github.com/complytime/complybeacon/compass/api/compassv1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PluginNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginNameRequest) Reset() {
+	*x = PluginNameRequest{}
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginNameRequest) ProtoMessage() {}
+
+func (x *PluginNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginNameRequest.ProtoReflect.Descriptor instead.
+func (*PluginNameRequest) Descriptor() ([]byte, []int) {
+	return file_compass_mapper_v1_mapper_proto_rawDescGZIP(), []int{0}
+}
+
+type PluginNameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginNameResponse) Reset() {
+	*x = PluginNameResponse{}
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginNameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginNameResponse) ProtoMessage() {}
+
+func (x *PluginNameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginNameResponse.ProtoReflect.Descriptor instead.
+func (*PluginNameResponse) Descriptor() ([]byte, []int) {
+	return file_compass_mapper_v1_mapper_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PluginNameResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type MapRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Evidence *compassv1.Evidence    `protobuf:"bytes,1,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	// Return every matching control rather than only the first. Plugins that
+	// cannot map to several controls return at most one.
+	All           bool `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapRequest) Reset() {
+	*x = MapRequest{}
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapRequest) ProtoMessage() {}
+
+func (x *MapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapRequest.ProtoReflect.Descriptor instead.
+func (*MapRequest) Descriptor() ([]byte, []int) {
+	return file_compass_mapper_v1_mapper_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MapRequest) GetEvidence() *compassv1.Evidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *MapRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type MapResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Successful findings in a stable order. A single unmapped or unknown
+	// finding is returned when the evidence cannot be mapped and all is false.
+	Compliance    []*compassv1.Compliance `protobuf:"bytes,1,rep,name=compliance,proto3" json:"compliance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapResponse) Reset() {
+	*x = MapResponse{}
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapResponse) ProtoMessage() {}
+
+func (x *MapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapResponse.ProtoReflect.Descriptor instead.
+func (*MapResponse) Descriptor() ([]byte, []int) {
+	return file_compass_mapper_v1_mapper_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MapResponse) GetCompliance() []*compassv1.Compliance {
+	if x != nil {
+		return x.Compliance
+	}
+	return nil
+}
+
+type AddEvaluationPlanRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CatalogId string                 `protobuf:"bytes,1,opt,name=catalog_id,json=catalogId,proto3" json:"catalog_id,omitempty"`
+	// JSON encoded list of Gemara Layer 4 assessment plans.
+	Plans         []byte `protobuf:"bytes,2,opt,name=plans,proto3" json:"plans,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddEvaluationPlanRequest) Reset() {
+	*x = AddEvaluationPlanRequest{}
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddEvaluationPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddEvaluationPlanRequest) ProtoMessage() {}
+
+func (x *AddEvaluationPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddEvaluationPlanRequest.ProtoReflect.Descriptor instead.
+func (*AddEvaluationPlanRequest) Descriptor() ([]byte, []int) {
+	return file_compass_mapper_v1_mapper_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AddEvaluationPlanRequest) GetCatalogId() string {
+	if x != nil {
+		return x.CatalogId
+	}
+	return ""
+}
+
+func (x *AddEvaluationPlanRequest) GetPlans() []byte {
+	if x != nil {
+		return x.Plans
+	}
+	return nil
+}
+
+type AddEvaluationPlanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddEvaluationPlanResponse) Reset() {
+	*x = AddEvaluationPlanResponse{}
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddEvaluationPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddEvaluationPlanResponse) ProtoMessage() {}
+
+func (x *AddEvaluationPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddEvaluationPlanResponse.ProtoReflect.Descriptor instead.
+func (*AddEvaluationPlanResponse) Descriptor() ([]byte, []int) {
+	return file_compass_mapper_v1_mapper_proto_rawDescGZIP(), []int{5}
+}
+
+type SetScopeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JSON encoded object of Gemara Layer 2 catalogs by catalog ID.
+	Catalogs      []byte `protobuf:"bytes,1,opt,name=catalogs,proto3" json:"catalogs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetScopeRequest) Reset() {
+	*x = SetScopeRequest{}
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetScopeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetScopeRequest) ProtoMessage() {}
+
+func (x *SetScopeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetScopeRequest.ProtoReflect.Descriptor instead.
+func (*SetScopeRequest) Descriptor() ([]byte, []int) {
+	return file_compass_mapper_v1_mapper_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetScopeRequest) GetCatalogs() []byte {
+	if x != nil {
+		return x.Catalogs
+	}
+	return nil
+}
+
+type SetScopeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetScopeResponse) Reset() {
+	*x = SetScopeResponse{}
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetScopeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetScopeResponse) ProtoMessage() {}
+
+func (x *SetScopeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_mapper_v1_mapper_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetScopeResponse.ProtoReflect.Descriptor instead.
+func (*SetScopeResponse) Descriptor() ([]byte, []int) {
+	return file_compass_mapper_v1_mapper_proto_rawDescGZIP(), []int{7}
+}
+
+var File_compass_mapper_v1_mapper_proto protoreflect.FileDescriptor
+
+const file_compass_mapper_v1_mapper_proto_rawDesc = "" +
+	"\n" +
+	"\x1ecompass/mapper/v1/mapper.proto\x12\x11compass.mapper.v1\x1a\x18compass/v1/compass.proto\"\x13\n" +
+	"\x11PluginNameRequest\"(\n" +
+	"\x12PluginNameResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"P\n" +
+	"\n" +
+	"MapRequest\x120\n" +
+	"\bevidence\x18\x01 \x01(\v2\x14.compass.v1.EvidenceR\bevidence\x12\x10\n" +
+	"\x03all\x18\x02 \x01(\bR\x03all\"E\n" +
+	"\vMapResponse\x126\n" +
+	"\n" +
+	"compliance\x18\x01 \x03(\v2\x16.compass.v1.ComplianceR\n" +
+	"compliance\"O\n" +
+	"\x18AddEvaluationPlanRequest\x12\x1d\n" +
+	"\n" +
+	"catalog_id\x18\x01 \x01(\tR\tcatalogId\x12\x14\n" +
+	"\x05plans\x18\x02 \x01(\fR\x05plans\"\x1b\n" +
+	"\x19AddEvaluationPlanResponse\"-\n" +
+	"\x0fSetScopeRequest\x12\x1a\n" +
+	"\bcatalogs\x18\x01 \x01(\fR\bcatalogs\"\x12\n" +
+	"\x10SetScopeResponse2\xf5\x02\n" +
+	"\rMapperService\x12Y\n" +
+	"\n" +
+	"PluginName\x12$.compass.mapper.v1.PluginNameRequest\x1a%.compass.mapper.v1.PluginNameResponse\x12D\n" +
+	"\x03Map\x12\x1d.compass.mapper.v1.MapRequest\x1a\x1e.compass.mapper.v1.MapResponse\x12n\n" +
+	"\x11AddEvaluationPlan\x12+.compass.mapper.v1.AddEvaluationPlanRequest\x1a,.compass.mapper.v1.AddEvaluationPlanResponse\x12S\n" +
+	"\bSetScope\x12\".compass.mapper.v1.SetScopeRequest\x1a#.compass.mapper.v1.SetScopeResponseB9Z7github.com/complytime/complybeacon/compass/api/mapperv1b\x06proto3"
+
+var (
+	file_compass_mapper_v1_mapper_proto_rawDescOnce sync.Once
+	file_compass_mapper_v1_mapper_proto_rawDescData []byte
+)
+
+func file_compass_mapper_v1_mapper_proto_rawDescGZIP() []byte {
+	file_compass_mapper_v1_mapper_proto_rawDescOnce.Do(func() {
+		file_compass_mapper_v1_mapper_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_compass_mapper_v1_mapper_proto_rawDesc), len(file_compass_mapper_v1_mapper_proto_rawDesc)))
+	})
+	return file_compass_mapper_v1_mapper_proto_rawDescData
+}
+
+var file_compass_mapper_v1_mapper_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_compass_mapper_v1_mapper_proto_goTypes = []any{
+	(*PluginNameRequest)(nil),         // 0: compass.mapper.v1.PluginNameRequest
+	(*PluginNameResponse)(nil),        // 1: compass.mapper.v1.PluginNameResponse
+	(*MapRequest)(nil),                // 2: compass.mapper.v1.MapRequest
+	(*MapResponse)(nil),               // 3: compass.mapper.v1.MapResponse
+	(*AddEvaluationPlanRequest)(nil),  // 4: compass.mapper.v1.AddEvaluationPlanRequest
+	(*AddEvaluationPlanResponse)(nil), // 5: compass.mapper.v1.AddEvaluationPlanResponse
+	(*SetScopeRequest)(nil),           // 6: compass.mapper.v1.SetScopeRequest
+	(*SetScopeResponse)(nil),          // 7: compass.mapper.v1.SetScopeResponse
+	(*compassv1.Evidence)(nil),        // 8: compass.v1.Evidence
+	(*compassv1.Compliance)(nil),      // 9: compass.v1.Compliance
+}
+var file_compass_mapper_v1_mapper_proto_depIdxs = []int32{
+	8, // 0: compass.mapper.v1.MapRequest.evidence:type_name -> compass.v1.Evidence
+	9, // 1: compass.mapper.v1.MapResponse.compliance:type_name -> compass.v1.Compliance
+	0, // 2: compass.mapper.v1.MapperService.PluginName:input_type -> compass.mapper.v1.PluginNameRequest
+	2, // 3: compass.mapper.v1.MapperService.Map:input_type -> compass.mapper.v1.MapRequest
+	4, // 4: compass.mapper.v1.MapperService.AddEvaluationPlan:input_type -> compass.mapper.v1.AddEvaluationPlanRequest
+	6, // 5: compass.mapper.v1.MapperService.SetScope:input_type -> compass.mapper.v1.SetScopeRequest
+	1, // 6: compass.mapper.v1.MapperService.PluginName:output_type -> compass.mapper.v1.PluginNameResponse
+	3, // 7: compass.mapper.v1.MapperService.Map:output_type -> compass.mapper.v1.MapResponse
+	5, // 8: compass.mapper.v1.MapperService.AddEvaluationPlan:output_type -> compass.mapper.v1.AddEvaluationPlanResponse
+	7, // 9: compass.mapper.v1.MapperService.SetScope:output_type -> compass.mapper.v1.SetScopeResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_compass_mapper_v1_mapper_proto_init() }
+func file_compass_mapper_v1_mapper_proto_init() {
+	if File_compass_mapper_v1_mapper_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_compass_mapper_v1_mapper_proto_rawDesc), len(file_compass_mapper_v1_mapper_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_compass_mapper_v1_mapper_proto_goTypes,
+		DependencyIndexes: file_compass_mapper_v1_mapper_proto_depIdxs,
+		MessageInfos:      file_compass_mapper_v1_mapper_proto_msgTypes,
+	}.Build()
+	File_compass_mapper_v1_mapper_proto = out.File
+	file_compass_mapper_v1_mapper_proto_goTypes = nil
+	file_compass_mapper_v1_mapper_proto_depIdxs = nil
+}
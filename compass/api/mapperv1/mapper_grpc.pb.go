@@ -0,0 +1,260 @@
+// Protocol spoken between Compass and external mapper plugins. The plugin
+// runs as a subprocess of Compass and serves MapperService; the methods
+// mirror the mapper.Mapper interface. Breaking changes require a new
+// package version and a new handshake protocol version.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: compass/mapper/v1/mapper.proto
+
+package mapperv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MapperService_PluginName_FullMethodName        = "/compass.mapper.v1.MapperService/PluginName"
+	MapperService_Map_FullMethodName               = "/compass.mapper.v1.MapperService/Map"
+	MapperService_AddEvaluationPlan_FullMethodName = "/compass.mapper.v1.MapperService/AddEvaluationPlan"
+	MapperService_SetScope_FullMethodName          = "/compass.mapper.v1.MapperService/SetScope"
+)
+
+// MapperServiceClient is the client API for MapperService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MapperService maps evidence to compliance findings using the evaluation
+// plans and catalogs Compass sends to the plugin.
+type MapperServiceClient interface {
+	// PluginName returns the name the plugin reports for itself.
+	PluginName(ctx context.Context, in *PluginNameRequest, opts ...grpc.CallOption) (*PluginNameResponse, error)
+	// Map maps a single evidence record against the current scope.
+	Map(ctx context.Context, in *MapRequest, opts ...grpc.CallOption) (*MapResponse, error)
+	// AddEvaluationPlan adds assessment plans for a catalog. Compass replays
+	// every plan when it restarts a plugin.
+	AddEvaluationPlan(ctx context.Context, in *AddEvaluationPlanRequest, opts ...grpc.CallOption) (*AddEvaluationPlanResponse, error)
+	// SetScope replaces the catalogs that Map resolves controls against.
+	// Compass sends it before the first Map call and whenever the scope
+	// changes.
+	SetScope(ctx context.Context, in *SetScopeRequest, opts ...grpc.CallOption) (*SetScopeResponse, error)
+}
+
+type mapperServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMapperServiceClient(cc grpc.ClientConnInterface) MapperServiceClient {
+	return &mapperServiceClient{cc}
+}
+
+func (c *mapperServiceClient) PluginName(ctx context.Context, in *PluginNameRequest, opts ...grpc.CallOption) (*PluginNameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PluginNameResponse)
+	err := c.cc.Invoke(ctx, MapperService_PluginName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mapperServiceClient) Map(ctx context.Context, in *MapRequest, opts ...grpc.CallOption) (*MapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MapResponse)
+	err := c.cc.Invoke(ctx, MapperService_Map_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mapperServiceClient) AddEvaluationPlan(ctx context.Context, in *AddEvaluationPlanRequest, opts ...grpc.CallOption) (*AddEvaluationPlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddEvaluationPlanResponse)
+	err := c.cc.Invoke(ctx, MapperService_AddEvaluationPlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mapperServiceClient) SetScope(ctx context.Context, in *SetScopeRequest, opts ...grpc.CallOption) (*SetScopeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetScopeResponse)
+	err := c.cc.Invoke(ctx, MapperService_SetScope_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MapperServiceServer is the server API for MapperService service.
+// All implementations must embed UnimplementedMapperServiceServer
+// for forward compatibility.
+//
+// MapperService maps evidence to compliance findings using the evaluation
+// plans and catalogs Compass sends to the plugin.
+type MapperServiceServer interface {
+	// PluginName returns the name the plugin reports for itself.
+	PluginName(context.Context, *PluginNameRequest) (*PluginNameResponse, error)
+	// Map maps a single evidence record against the current scope.
+	Map(context.Context, *MapRequest) (*MapResponse, error)
+	// AddEvaluationPlan adds assessment plans for a catalog. Compass replays
+	// every plan when it restarts a plugin.
+	AddEvaluationPlan(context.Context, *AddEvaluationPlanRequest) (*AddEvaluationPlanResponse, error)
+	// SetScope replaces the catalogs that Map resolves controls against.
+	// Compass sends it before the first Map call and whenever the scope
+	// changes.
+	SetScope(context.Context, *SetScopeRequest) (*SetScopeResponse, error)
+	mustEmbedUnimplementedMapperServiceServer()
+}
+
+// UnimplementedMapperServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMapperServiceServer struct{}
+
+func (UnimplementedMapperServiceServer) PluginName(context.Context, *PluginNameRequest) (*PluginNameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PluginName not implemented")
+}
+func (UnimplementedMapperServiceServer) Map(context.Context, *MapRequest) (*MapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Map not implemented")
+}
+func (UnimplementedMapperServiceServer) AddEvaluationPlan(context.Context, *AddEvaluationPlanRequest) (*AddEvaluationPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddEvaluationPlan not implemented")
+}
+func (UnimplementedMapperServiceServer) SetScope(context.Context, *SetScopeRequest) (*SetScopeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetScope not implemented")
+}
+func (UnimplementedMapperServiceServer) mustEmbedUnimplementedMapperServiceServer() {}
+func (UnimplementedMapperServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeMapperServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MapperServiceServer will
+// result in compilation errors.
+type UnsafeMapperServiceServer interface {
+	mustEmbedUnimplementedMapperServiceServer()
+}
+
+func RegisterMapperServiceServer(s grpc.ServiceRegistrar, srv MapperServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMapperServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MapperService_ServiceDesc, srv)
+}
+
+func _MapperService_PluginName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PluginNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MapperServiceServer).PluginName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MapperService_PluginName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MapperServiceServer).PluginName(ctx, req.(*PluginNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MapperService_Map_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MapperServiceServer).Map(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MapperService_Map_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MapperServiceServer).Map(ctx, req.(*MapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MapperService_AddEvaluationPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddEvaluationPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MapperServiceServer).AddEvaluationPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MapperService_AddEvaluationPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MapperServiceServer).AddEvaluationPlan(ctx, req.(*AddEvaluationPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MapperService_SetScope_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetScopeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MapperServiceServer).SetScope(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MapperService_SetScope_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MapperServiceServer).SetScope(ctx, req.(*SetScopeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MapperService_ServiceDesc is the grpc.ServiceDesc for MapperService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MapperService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "compass.mapper.v1.MapperService",
+	HandlerType: (*MapperServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PluginName",
+			Handler:    _MapperService_PluginName_Handler,
+		},
+		{
+			MethodName: "Map",
+			Handler:    _MapperService_Map_Handler,
+		},
+		{
+			MethodName: "AddEvaluationPlan",
+			Handler:    _MapperService_AddEvaluationPlan_Handler,
+		},
+		{
+			MethodName: "SetScope",
+			Handler:    _MapperService_SetScope_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "compass/mapper/v1/mapper.proto",
+}
@@ -8,19 +8,39 @@ import (
 	"compress/gzip"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"net/url"
 	"path"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
 )
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List loaded catalogs
+	// (GET /v1/catalogs)
+	GetV1Catalogs(c *gin.Context)
+	// Get a control from a loaded catalog
+	// (GET /v1/catalogs/{catalogId}/controls/{controlId})
+	GetV1CatalogsCatalogIdControlsControlId(c *gin.Context, catalogId string, controlId string)
+	// Report how well the loaded catalogs are covered by assessment procedures
+	// (GET /v1/coverage)
+	GetV1Coverage(c *gin.Context, params GetV1CoverageParams)
 	// Enrich telemetry attributes with compliance control data
 	// (POST /v1/enrich)
 	PostV1Enrich(c *gin.Context)
+	// Enrich a batch of telemetry attributes with compliance control data
+	// (POST /v1/enrich/batch)
+	PostV1EnrichBatch(c *gin.Context)
+	// Look up the controls and policy rules covering a framework requirement
+	// (GET /v1/frameworks/{framework}/requirements/{requirementId})
+	GetV1FrameworksFrameworkRequirementsRequirementId(c *gin.Context, framework string, requirementId string)
+	// List the assessment procedures known to a mapper plugin
+	// (GET /v1/plugins/{pluginId}/procedures)
+	GetV1PluginsPluginIdProcedures(c *gin.Context, pluginId string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -32,6 +52,78 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// GetV1Catalogs operation middleware
+func (siw *ServerInterfaceWrapper) GetV1Catalogs(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetV1Catalogs(c)
+}
+
+// GetV1CatalogsCatalogIdControlsControlId operation middleware
+func (siw *ServerInterfaceWrapper) GetV1CatalogsCatalogIdControlsControlId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "catalogId" -------------
+	var catalogId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "catalogId", c.Param("catalogId"), &catalogId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter catalogId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "controlId" -------------
+	var controlId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "controlId", c.Param("controlId"), &controlId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter controlId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetV1CatalogsCatalogIdControlsControlId(c, catalogId, controlId)
+}
+
+// GetV1Coverage operation middleware
+func (siw *ServerInterfaceWrapper) GetV1Coverage(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetV1CoverageParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetV1Coverage(c, params)
+}
+
 // PostV1Enrich operation middleware
 func (siw *ServerInterfaceWrapper) PostV1Enrich(c *gin.Context) {
 
@@ -45,6 +137,76 @@ func (siw *ServerInterfaceWrapper) PostV1Enrich(c *gin.Context) {
 	siw.Handler.PostV1Enrich(c)
 }
 
+// PostV1EnrichBatch operation middleware
+func (siw *ServerInterfaceWrapper) PostV1EnrichBatch(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostV1EnrichBatch(c)
+}
+
+// GetV1FrameworksFrameworkRequirementsRequirementId operation middleware
+func (siw *ServerInterfaceWrapper) GetV1FrameworksFrameworkRequirementsRequirementId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "framework" -------------
+	var framework string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "framework", c.Param("framework"), &framework, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter framework: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "requirementId" -------------
+	var requirementId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "requirementId", c.Param("requirementId"), &requirementId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requirementId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetV1FrameworksFrameworkRequirementsRequirementId(c, framework, requirementId)
+}
+
+// GetV1PluginsPluginIdProcedures operation middleware
+func (siw *ServerInterfaceWrapper) GetV1PluginsPluginIdProcedures(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pluginId" -------------
+	var pluginId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pluginId", c.Param("pluginId"), &pluginId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pluginId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetV1PluginsPluginIdProcedures(c, pluginId)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -72,43 +234,100 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.GET(options.BaseURL+"/v1/catalogs", wrapper.GetV1Catalogs)
+	router.GET(options.BaseURL+"/v1/catalogs/:catalogId/controls/:controlId", wrapper.GetV1CatalogsCatalogIdControlsControlId)
+	router.GET(options.BaseURL+"/v1/coverage", wrapper.GetV1Coverage)
 	router.POST(options.BaseURL+"/v1/enrich", wrapper.PostV1Enrich)
+	router.POST(options.BaseURL+"/v1/enrich/batch", wrapper.PostV1EnrichBatch)
+	router.GET(options.BaseURL+"/v1/frameworks/:framework/requirements/:requirementId", wrapper.GetV1FrameworksFrameworkRequirementsRequirementId)
+	router.GET(options.BaseURL+"/v1/plugins/:pluginId/procedures", wrapper.GetV1PluginsPluginIdProcedures)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7RYW2/juBX+KwRboC0gO0pmty38ls1ksC66iRtn+9BNHhjx2OaGIjUkZY8xyH8vDklJ",
-	"1MVJBtO+2ebl3L7znY/+SgtdVlqBcpYuvlJb7KBk/uOVLispmCoAvzHOhRNaMbkyugLjBFi62DBpIaMc",
-	"bGFEhet0kRwkHBwT0pKN0SW5vVp/ImsoaiPckVxp5YyWZGX0RkiY04xWyc3omN+AH/9oYEMX9A9nnbNn",
-	"0dOzzlq8kb5kFJQRxa4E5daOudrf13cy/E70hrgdkKJzuTtKKqMLsHZB1nWBHzLyqypZVQHPyIoZJ5jE",
-	"n56VPqiMaEPWzwJXMRZQdUkXv9F4lGa0OUszGg/7H/1pmtF4lj5mFL6wspKAXran3bHCH6wzQm0xxI1h",
-	"JRy0ebbvz9Cn7sxLRo2wz+8/e4e7XzJqTyQ0qXrc0iWhWXM0ozdazdLv11+grMKCI5dVJUXBniQkuell",
-	"ZHh8kBcMCz7XwgBHww2GeulqY5jAyWNGnXDeUoL/1ox++h0Kh2kYw24MsQbq0Qsi1EabkuEy2WiToo5Z",
-	"C9aiI6M+YDEnQgp3HFu5VnthtMKjlvhLlYMvzpLDDgwQtxO2dcBfBT7wJqG/0ZXRvC78bRm2xRYT+ZhR",
-	"4aD0DoygF39gxrAjfi+YY1Jvl3zs3a9KfK6BCA7KiY0A4wPHlrPD7MRbMIa2WKmn9Ha9Ws9+muqFgjnY",
-	"ajORnau44m9lpZBH4nbMTXvwBFKrrSVO9+xe+h5sCGvKvvi+yJ9AqG2EgOeHQcz/upzlf5vn51OmDZTA",
-	"hcfUx9T+0J1ksSE9A4UuS1AcOEmuIdYZzNoxOtzhp+fZHZR6D8Ro7UhtwRAW0sQUJwL3NBRagSHLy19I",
-	"paUoAvpeb1qBKegwlZT38dVG/NRjxJPs1ILLuxoNe2eT/hx14eaVy+9gW0vmIsyE4rV15og0qDgz3MYC",
-	"w57Jmjngg+bvt+PNcn0/+3uez378gP14ezW7+LZuTCJ6PRG90FuYxmGNAOliHkbQd/nyaobYvLr66/z8",
-	"W3wd1L1H0b0oXq/7XZxjpwMV9jlh2FfrLGEPE1yONohfw4t0IXwdD8LtiNJq1i9mM/WMcKLwY/5nsd3R",
-	"jP4CXNQlzeg/9YFmdNn5wWR/zMUD40YZ5eG6HWF38LkG66bw6RdIxY5SswA/B9ihCFPmnBFPtUulT1rf",
-	"rxT2CI+gA30XH6/VVii4YaXnqNUlpjEsBIQIrRrhRT8xIT1iwo67WgJOCspBHWdIHzOkDyw5O3xkjqEV",
-	"A8wG14f0IixR2hEmpT74Ww3YWrp4n8eYKME6VlZ0QS/yix9m+fns/Mf783zxIV/k+X98GvtFTwN8TQ1d",
-	"N/uG0G0veHyjQrbSysLUFMc9wFNNsBGKY9d5lAUMN6UKNOt2BpgjKCuF2tp5v2pFT78nanogKE4rgGSu",
-	"d8O3m7XjwSj4xMg6NaG+Y4JMKvxELPfJOv12kl/7rDnktEQtR4IIHZro4YEyHYOsX4/3ie4JQdsuTULN",
-	"GG18mw5M8wnM/Xx/v4panfgdCXx+yPOMBnaiCyqU+3DR0ZFQDrZg0GAJ1rLtFKDRE9Isv63Tvflm+2Ro",
-	"SY9OMD0gf8UtBGWkn2KBcgh4ugrImhTdxGkt7WgYjMluaBt/bfRUz1iQmRUYTCJwvwFackSdAMj+RQA5",
-	"a7ovUX6eVUcT9BTNjjkfaXHoWnssGVP47rqrlX+WRv3ZUvYNALfkDvYCDu9+orWnTzjfTID3K+bovqkl",
-	"jLRUl8m+ch6Nl7F07ubNIHfsQP6xvr0hunZV7TpB1Ktwn21LcIzH296cQBndg7HB2Pk8D6rtOybesFcS",
-	"B4ax3e+A4DI+D1V4BrBD1zkHZskWFJihyjsVSMsRnDmY4c1vNnvnXTbusAFITgJ+TBFoBpXdOObL1bKF",
-	"EpIFs5ZYMHtRwJzc4wMnfsNhg5loXzyzJ2aBT0qkgYrC2mcPCqeD8QOUIEsaxSThumRCISmJIs7tzo/K",
-	"aHT/TzYlJmwqCXwL8we1xDUOVmwV0ogmT0AKJiVw8nQkTJHbCtR968eVlhIKpw3eWFuHJBj+xkJ3dQwA",
-	"nbEZwSOisFnwyrAC7PwBmSD9DwS9XMf8XK6WPfDm8whfXYFilaAL+mGez3GyVsztPC+d7c/PgtVAqVMK",
-	"FZVE5SxhxIInrWc4TklUS/4M8+0889TtyPJj1vSkYiVkoW+WH/+CAT0oA642ypLuv8skyTMD0hNIcnmo",
-	"tlbTtZs/KA8WULzSAt8R1m/0D+jvKkxIOk4eD3EvuFbaun+fB10Y30Ng3U+aHxs5hzq9k3N48Ox3G97+",
-	"QUm8KWdHT4eXfq86U4P/IchWX86LPP+/OBCVsfdg8D9a0HWbWspjxG+vbNSf2DBPiv8rz7yQmnCmVvCl",
-	"ggKBA3FPRm1dlsxL4hDRNHS9jk+6vPn7x4+Nl5eXl/8GAAD//7oavvaNFwAA",
+	"H4sIAAAAAAAC/+w8a2/buJZ/hdAuMLuA4jjua5r7ZdM0ncnFtM1NMg/cSXFBS8c2pxKpklQSI/B/X/Ah",
+	"ipIoWU6TbIC9n5qar8PzflF3UcLyglGgUkSHd5FIVpBj/ec7LJPVCeUkWeVA5Tl8K0FINZKCSDgpJGE0",
+	"OozsACrwOmM4RQvGEehlhC6RgGvgOENwTVKgCSAOCeOpQIQiRgElOMuiOIJbnBcZqN2rmdHhn3dRwTKS",
+	"rE/oklD4hHOIDqPPZ0dRXA1c46zECpALiWUposPoAyYZpG7GeZnBaRodRinQ9R5nTO6VAngUR5LkICTO",
+	"i+gwmk1nL/emB3sHry4PpocvpofT6T+jTRw8P2F0wXiOh4A4w0KEgFgSuSrn/5pzTJPVvwrOJCRq4VZw",
+	"Dv4Zbb5s4qjgrAAuCYgmqu4iIiHXP/4nh0V0GP3Hfk3ZfUvW/ZNqwSaOcnx7atYcTKfTaRzlhFY/xJFc",
+	"F+qymHO81rNZClt3d8zyUc3ebOKIw7eScEijwz9raL+47dn8L0ik2r/DbaJgVECX3eo5iIMoMyk0x2E0",
+	"VzvEiq/kCpDAOSDGU+AIC/0Lt3xagTGJ2ui0+43GZhfmMtOXaSKvhYbqlHFYUDt2cPC5lAnLwV5cELrM",
+	"oC1hChEWKRN0cosTma21xLEFUnfJCFaTlbByzjgiAgmQXaTUc7eh47ieuYkjvetWjtGTNDPKZAWie9WT",
+	"a+BrlDAqOcs0Hd09c1wIJBm6WQFtUFjxqrrPVYSz7CpSdxpFz+YFOkTsUOsYS5yx5bEBrgu7HUALznKE",
+	"kdKOkKLErOoi2vx+mnY3+pWSbyUgdW9JFgS4pry6sl1k/rbHzSFjdKlQ4yvW6PPF2cXeu8jdQ0hO6FLd",
+	"Y4Fzkq23Y0dv/8FM3sTRsiQpZITCR1wUhC4D1PvAcQ43jH9FVgIUV4sGtJaKY2n0U+vQLqXiiOyGQku+",
+	"Dq7+cbQ3fRPCl+EAch3QTp+rISVmfbufUFFyQMkK0yUIhLli3WsCN5CiOSwYB7V0rQdy4EtIQ1D4GO0C",
+	"ciQECGHVZD2xC9ZIydDTz+udQniXRGYBnFyqn4fwYbdFCUsrTHQv3NKiROGkFpnqcMfMLfyEuPXLkEgr",
+	"r2UJIZk2I+o+jyrSI2XX4vOYlTRgKD6V+Ry4Ufl6nqjsY+CUF2/d/oRKWAI3B1wDh/R413NuiFwhLFEG",
+	"WEhteArOEkhLDv6hB7PQoZqIBMQw/gEnq+pAZOiu7X+lFLX5H8viRq85wt+XvUPEK4CiC1byBNAZZ4rV",
+	"0AUkJSdyjd5hoblyK8OHeL1B/DCpPFwO8PsvJOTZ20FRsTmhkulbKkOJhUAiYQX0cf14P8qec1HmOebr",
+	"rQ6U23/gQtVenTvZgYb4/oLXwNGsX4wfW8bIIyqIJ+baOLoGLvQZ7SN/MwNDh86ms1eT6WwyezXOAAQF",
+	"IcgWDS8WpylRQOHszCP0AmcC4o6+qRaiFCQmmTDu3Ofjiw81PipP74yzBclg0sdC4z1Qu6OCHVw8UMWX",
+	"HabWv9cm1oFcLzXaV4hDdFEm6o8Y/UpzXBSQxugMc0lwpn76StkNjRHj6OIrUaPqLkDLXOHcLo3iqFob",
+	"xZFdrH/Uq6M4smujLz5x69UdnoHbBOxlxiLoxC1R9qLyM3fw8T/UaxRrEfF1/NpzNXsTR6KHIPVMZKfU",
+	"SKzGZBRHnxjda/wfIBXovHKBTm4hL8w8iY6KIiMJnmfgobqB4PZuXV2A+RJkyB251COafRaEpipzg9V5",
+	"IJBksQmxiEREIIo5ZzegVBGmzYDMJDsu7SETdAESzddIcwo3YbqbK1dYIm2whMsRGfBEjESZrKqwvaaD",
+	"0dnHNv2COBSMy8kVbWiQbyVeTwjbt7/s46L4H7HCs1evD18ks/nBYgpv0x/xm/nr5FX6El4sZvhgPk3e",
+	"pj/Cm8Vr/Gr+MnmRzuBgMcVv5z8mb9LX8GrxEr+Yz5LtJtr5th4/xpHHAS1B/uLUs6+gBtVXb7DpdJGF",
+	"AhGDJzWsUe+pBeyCg46iwpbLSEbkOpR9uSacURtLcH0Y3EqhOESHLUQ4ACwD+eT5MzrjLC2rnNeFxEuF",
+	"yC+ej9Zl2pYbdi+nWrSxU/uIyBFrrMuNJSxZyLk4tiN6V+1PGj4PQtATph9pJVlZlND55PtuPgct3ZoF",
+	"IG2cXYe9k+lBOObMISWap97757fB8QYrq8QhYXkOVDlc3jZISK6wtrYA1/zTChFzdg2IMyaRyt8ibNCE",
+	"aYqImlPZuAI4Oj36aHSR4b4dA0lH3mE/4sQ3Wa3Yuyg4u4YUObOmGOIGk2ujNiUy6a7U17ddSexJMfy+",
+	"ArkCQ9z6ACKQWVDj0W6ssn+Fum09u8o5KAUKqdK0hNrVCqEpQ5RJm58wTKT11cQnieQlOATNGcsA0925",
+	"E3dQ1cyU/LFnc+FvxhHSIm2YdB8a3kKv5XZ6QSPFSyf4qrVDtsXA5uewLDMsrYYgNC2F5GuFXZpiVRQx",
+	"sgmmoABpS283Nemn04vLvR+n071XL5Qq/Xy8N9tNkQ4nkDxENK7uaGgdYc3C7s7tGzRBPjreU2rl+Pj1",
+	"5GAXWFuUbljXxi2G6X5ufbz+ixLx1TOOg3TO4BoCZlidgfSY2oglRNNRJ0Moo3tNYlYeISeSJNqF/pks",
+	"V1EcfYSUlHkUR78w5Qee1nDgrOnz2QVd0QjgwcZI41JbtRYORjKnj5xjtbmMfvWH6brOKLlagHeYEjFb",
+	"7yBS+GRtZQa3qrS6iBeQk7MKBu2fgi5XFFm5JLRS9UULsLEZqTN37DksgEOwLvHIWdc4KqklxfkD5JsN",
+	"SijrUq6pKHxHZPYdmqJmVj9XYDir72pNen/pl6QPrmzSKnro3xEOe3pNabpXqQK5HHdNzX8c3Tv5M7Bp",
+	"iXUYsEMuZgBfNRcHDKMdUqKCvRDmOeXYH1vlPWX5pOHwdoRkkIhOVMYqgWF78r0BZ9M92RZz7uQg7ejK",
+	"Bi+/W3ylrhbgALiVFQOMOOX3FVCN8Dw3KZscp1DZoIITnf82LSgxIhLlpZBo7vnic6W8bEWST0ZKv4I8",
+	"blEzzEfGyzjX0cewF6IAboq/UMC18FA0LLCVkgVZlirmMaZYDBYptlSYmhDEpqxk0GR/Q6fvR1dSWwXG",
+	"ANvBbZKVKaRnFvSuy2EGbA4NU8okyojQeQbCfXyowEUwHe2pSQXmso7HNf4bgiLUZsB3k5KSchAsu4a0",
+	"doUG3SRulb1OQ9RotQpC4beHy82FidB3MVQZi/Zfu0COrzP13LFLqRC/t5qiNGIWWLf1RAvChSJBE1U/",
+	"s5s6T3qzYqLKrCJeZrWr61ebqgyqkxIibO4f0gn6XUUfV+a0qyhGjOrEFFxR/ZPJRihqOLuriCRLTr3V",
+	"upUmtg7u0AoFj23nsclZG+ZUt8VZK4bBWTDPdc/eQwkZ5KDiaiwlJ/NS+nWQvl7DR2019BPjnkGLDn3T",
+	"FEcc37zHEptWNCzMDdsZL8v+OMvYjT6c2yYxfWy02dZJqBobBxsJR/cPPnJH4PhmQKWcvbSNrV/omNtE",
+	"9BUfGIUoVxxMYKb7QCZNlmj2u3l1u5aj0p/K9jzS2qmsk8bdDC9JA75BX6r1O1KhwVqiV5Zrpq78//Vm",
+	"m5o5pHaGx6ur2XSJyVd4lbNW0arLmvftP/yOjsJuE0m8vckQXepcrlKxCiy+VoOuE9UV1YTHqhN0khdy",
+	"Xe/tAElYmaVazudg6mep0aUP0cfYDJDd1KAMVk2cbZqkAWH8+fLyzGaKdSziy9VL1WBsklim9eHFLAp1",
+	"QuQgRDA5pSFB1fD2Spw+vpoevJqn8gIJQZAeORQf6GSntcOgjYQRuWBZDUnGsq7T2TUxnWYSxSzWQ2sc",
+	"ZnyfArhCoq0cgDNJuo1XJQkTGwYlnVS6sWUdI9tn3LqWVlmZNmhumWfmVa36vKS6M8BWmJyhbNW3x5W1",
+	"3eoe4Cu7Oz5U892pdsq9xmSzNtZ9PxCGJmDlB2JaL4w11W/EQZimGw2IVJU7uCVCign6vdITRiM4HZYy",
+	"sE5BUWTrK9OlpT1Ad07c7gyxckqEDQRMGahJkHZtvWHwBq+/GzkCVx6gCsJLTKhoxrzmYcUkYblWiNla",
+	"khzsn3PASRhkz+FqcTu+QX+/+PwJsVIWpawrHQ2ZbDoOOUic2t22umBem1R0MJlqYL7H5WtrNw+ATjZB",
+	"8RrJwTNp2A84sEBLoMDb5Zu+izitnmIJe2rnreq5hi7u6sSWWPeqqJBSbzVxjitx1JnPh2n76yZS34Qb",
+	"a82y8T2u929ubdd+Qm0Vj9vnO3uAvsfnn/0e2ZLrSB/i4c4Lh7GvKnDgTUVPbXpoT8aR65Vv51eFrWW0",
+	"m1aa0cGOTxXOe8rLlTiF2nNspPEANeQxJeRQJa6b4fKcCq1eTN+bzUA+7HOfgWrA/3kZw9x3t1McjupD",
+	"vGeWO/p9PiG8s7QDXUdYmHMCou6WCWqtwQebA1x++sSVgxZzOxJ0bGlf3acJe1AGHH6Gyj6Num5hMry+",
+	"t+2X9/7/iETKklKhB/d0y/nDtUwEGfJ4BclXw8uGKVHNlBX3CFe++bfw3EN4Hlxiwq9rjoIVLB3/GjEZ",
+	"th9PoWYb9ZxxPSujqyu+jqrPCeHR8w+GPHvrj6qcjKdxhGkyN5poEXKc+vx+MXBIpdxsUdXbLVgeNHki",
+	"vXaXUmG7bSLgtX+3R9fXhrzFo3uIlijbnOWpVPHArVFblErY8Rz0O6sE97AK6XEufR0yps8oVLTsR7bB",
+	"bVVcBYEEy0HqKl1OhFD/ujRGq6jeVS7+efcgRJXL6Dbv2W54d4APcLC821BVWoK0Ov/jj723b6vJXljc",
+	"Z+vb2sczWBbYLgXUIkIXLKC7z06dUnWPIYFfE5XUv1wR9z91UWU1XVf73hwLSOuSlP9Eq1m9VLmk+Ipm",
+	"urSrakuIUAmcYpXwyzGhKi1NElvSquEozGO9H/xKgzYrGaRLldM7VWMpCLKkkCpBnJvPsNjOD4rU079L",
+	"B8cxyzJIJONqx1JIlldvyRS4zF5AASNipJaQRMQGKo6Tqgjsv3NRUF5Y/BydnTaSYdOJTYexAiguSHQY",
+	"vZhMJ0oJFViuNHfuXx/s+/0bSwiWhmXJqXKFRP3k0+ii1ntPXUBPWAE9rR2qQKiEQ7tnuqr3E8jfDo5r",
+	"4eG2QKmhmU2nlRWx2V9bOVTL9/+ycmEEaGS3iHYgNDs27/hLS4w3cd1c8EAQ2C90dM8uKdwWkEhIzQdE",
+	"tIiJ6tltpEDuqBk1xSfe/p3zrjb7lWLcv3Nu1mYEde1kk4ciUriH4D0Nv4Y1a3vol4EHqHxcAVr5AMee",
+	"L1hgpfAlcKE/WhSIPIgCWjFwFEfUfkfIcyxr1WR6kGvadNTYXV/UETqi66+OOOLL4zO0e93a5atLL8La",
+	"xNHL6cvH5+VLP47kflOLZ4qekXD9BNJPXwc/L+NkzXOZg6L0d2Z6ybqtdlqkBpvtur5dgqnrRctj+3Km",
+	"YFyKK1qBggrgnb6vWm5rZ3mCPFey6d70dI5d0dGtY7orToEKuvRVt3gZgxXSBRUqOxLfqZVq6UGmNPI3",
+	"lGP+NVVxHbdqq27AU0dj9LGaUGUKKnn+VgJf1wJtNox86XU8Gf0lGnVY+9/qbM+/eRpJb/Z5bmx3676D",
+	"p7FTG7IetWD5h9s9lXqYPo16MJivOEiUhSmaPietYDCNVuwG3UCWBUUac4tG6+yFBNupDuPZmcaFYAYj",
+	"SaDQhQcBujXgK6xD7XcC/RdMlhNjkiU6fR9XEbpi69jUOk/f/7cS/StayUj9kQbPkd3jkOmCsLe58agZ",
+	"DfvHkyt6aYrhacEI1SRUE2n6vc5vSE+cMSF/OzBtadbogpDvWLp+OPbotEVuNpu2fd88omQHGvMCzGrb",
+	"yhZllq1dL6pHtuckOeZGYdbVRtCLpFzTBZa4JSn7+mt7I+SFukBDm0ptSFufxzSG00gCo2C/dagtp5kS",
+	"+NLh5IqeqHKxnVtlaYHU74UH+iUZV4Bp9NjYFkt3lmDuQ4NXtKSlUP0hdrTuP1lgYtTOzYqp1hrz8cFd",
+	"xO+K7hB8MgW+9iWqWfbILYKpv7L4SNLZ89XUJxbRvq9pBkRDT62QCunfEKFCiQoCj5UUPxTA9yzBNZM8",
+	"Rwm2H7zUacV7C3Pd+Lp/5/7e7PtB5P5dI623PU71k5y2p78bgnrebSMB2cwvV/keP8l9RYffnjJRHz9B",
+	"75hcac1j/AFoNqE2XnsbubYeQ69jXD+od3/5TyrPWynQ3nC5mXQOBLSBxOquMbNN4AY2b2dqn0fEHKp+",
+	"BORjRP2jRdxnlS1i7Csqi6aY7F7JqSTYPvrav6uKTJv9Zi1rUFaNHIVcY6+Ipntsug1Zpg28XnD6vmoD",
+	"925yRU/ftyqq+g2eMthraxRti6d7tWc3/MElknuF0T5GOrNXbzxY6pU8rwwYkAyvVvc8hKJZWu0J2QzU",
+	"P4hGaPNEGaVPrOITIvzniDalQoSqA27i6NX04GniVwuN/1qQyDZqnlXyuD/x1Fsj32w2m/8dAFMe1UfS",
+	"XgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
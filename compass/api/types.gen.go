@@ -20,6 +20,7 @@ const (
 const (
 	ComplianceStatusCompliant     ComplianceStatus = "Compliant"
 	ComplianceStatusExempt        ComplianceStatus = "Exempt"
+	ComplianceStatusNeedsReview   ComplianceStatus = "Needs Review"
 	ComplianceStatusNonCompliant  ComplianceStatus = "Non-Compliant"
 	ComplianceStatusNotApplicable ComplianceStatus = "Not Applicable"
 	ComplianceStatusUnknown       ComplianceStatus = "Unknown"
@@ -34,6 +35,12 @@ const (
 	Medium        ComplianceRiskLevel = "Medium"
 )
 
+// Defines values for EnrichmentMode.
+const (
+	All   EnrichmentMode = "all"
+	First EnrichmentMode = "first"
+)
+
 // Defines values for EvidencePolicyEvaluationStatus.
 const (
 	Failed        EvidencePolicyEvaluationStatus = "Failed"
@@ -44,6 +51,98 @@ const (
 	Unknown       EvidencePolicyEvaluationStatus = "Unknown"
 )
 
+// Defines values for GetV1CoverageParamsFormat.
+const (
+	Json     GetV1CoverageParamsFormat = "json"
+	Markdown GetV1CoverageParamsFormat = "markdown"
+)
+
+// BatchEnrichmentRequest Request payload for enriching several evidence records in one call
+type BatchEnrichmentRequest struct {
+	Evidence []Evidence `json:"evidence"`
+
+	// Mode How evidence whose policy rule maps to controls in several catalogs is enriched. With "first", only the
+	// first matching control is returned. With "all", every matching control is returned in matches.
+	Mode *EnrichmentMode `json:"mode,omitempty"`
+}
+
+// BatchEnrichmentResponse Enrichment results for a batch, in the same order as the request evidence.
+type BatchEnrichmentResponse struct {
+	Results []BatchEnrichmentResult `json:"results"`
+}
+
+// BatchEnrichmentResult Outcome for a single evidence record in a batch. Exactly one of compliance or error is set.
+type BatchEnrichmentResult struct {
+	// Compliance Compliance details from OCSF Security Control Profile.
+	Compliance *Compliance `json:"compliance,omitempty"`
+	Error      *Error      `json:"error,omitempty"`
+
+	// Matches Every control the evidence maps to when the request mode is "all".
+	Matches *[]Compliance `json:"matches,omitempty"`
+}
+
+// CatalogControl Control from a loaded catalog
+type CatalogControl struct {
+	// CatalogId Unique identifier for the catalog the control belongs to
+	CatalogId string `json:"catalogId"`
+
+	// Family Family a control belongs to
+	Family ControlFamily `json:"family"`
+
+	// GuidelineMappings Framework requirements the control maps to
+	GuidelineMappings []GuidelineMapping `json:"guidelineMappings"`
+
+	// Id Unique identifier for the control
+	Id string `json:"id"`
+
+	// Objective Objective of the control
+	Objective *string `json:"objective,omitempty"`
+
+	// Requirements Assessment requirements of the control
+	Requirements []ControlRequirement `json:"requirements"`
+
+	// Title Title of the control
+	Title string `json:"title"`
+}
+
+// CatalogCoverage Coverage of a loaded catalog
+type CatalogCoverage struct {
+	// CatalogId Unique identifier for the catalog
+	CatalogId string `json:"catalogId"`
+
+	// ControlCount Number of controls in the catalog
+	ControlCount int `json:"controlCount"`
+
+	// CoveredControlCount Number of controls with at least one procedure
+	CoveredControlCount int `json:"coveredControlCount"`
+
+	// Families Coverage of each control family, in catalog order
+	Families []FamilyCoverage `json:"families"`
+
+	// Title Title of the catalog
+	Title string `json:"title"`
+}
+
+// CatalogList Catalogs loaded into the Compass scope
+type CatalogList struct {
+	Catalogs []CatalogSummary `json:"catalogs"`
+}
+
+// CatalogSummary Summary of a loaded Layer 2 catalog
+type CatalogSummary struct {
+	// ControlCount Number of controls in the catalog
+	ControlCount int `json:"controlCount"`
+
+	// Id Unique identifier for the catalog
+	Id string `json:"id"`
+
+	// Title Title of the catalog
+	Title string `json:"title"`
+
+	// Version Version of the catalog
+	Version *string `json:"version,omitempty"`
+}
+
 // Compliance Compliance details from OCSF Security Control Profile.
 type Compliance struct {
 	// Control Security control information for compliance assessment
@@ -52,6 +151,9 @@ type Compliance struct {
 	// EnrichmentStatus Status of the compliance enrichment process: Success, Unmapped, Partial, Unknown, or Skipped.
 	EnrichmentStatus ComplianceEnrichmentStatus `json:"enrichmentStatus"`
 
+	// Exception Approved exception or waiver that matched the finding
+	Exception *ComplianceException `json:"exception,omitempty"`
+
 	// Frameworks Compliance framework and requirement information
 	Frameworks ComplianceFrameworks `json:"frameworks"`
 
@@ -60,6 +162,9 @@ type Compliance struct {
 
 	// Status Compliance status
 	Status ComplianceStatus `json:"status"`
+
+	// TargetId Target the finding applies to, when it is narrower than the evidence policyTargetId. Set by mappers for evidence that covers several targets, such as the components of a Conforma report.
+	TargetId *string `json:"targetId,omitempty"`
 }
 
 // ComplianceEnrichmentStatus Status of the compliance enrichment process: Success, Unmapped, Partial, Unknown, or Skipped.
@@ -86,6 +191,15 @@ type ComplianceControl struct {
 	RemediationDescription *string `json:"remediationDescription,omitempty"`
 }
 
+// ComplianceException Approved exception or waiver that matched the finding
+type ComplianceException struct {
+	// Active Whether the exception is active for this finding. Expired exceptions are reported as inactive and do not change the status.
+	Active bool `json:"active"`
+
+	// Id Unique identifier for the approved exception
+	Id string `json:"id"`
+}
+
 // ComplianceFrameworks Compliance framework and requirement information
 type ComplianceFrameworks struct {
 	// Frameworks Regulatory or industry standards being evaluated for compliance
@@ -104,16 +218,91 @@ type ComplianceRisk struct {
 // ComplianceRiskLevel Risk level associated with non-compliance
 type ComplianceRiskLevel string
 
+// ControlCoverage Coverage of a control
+type ControlCoverage struct {
+	// ControlId Unique identifier for the control
+	ControlId string `json:"controlId"`
+
+	// Covered Whether any procedure maps to the control or one of its assessment requirements
+	Covered bool `json:"covered"`
+
+	// PolicyRules Procedures of every plugin that map to the control
+	PolicyRules []PolicyRuleReference `json:"policyRules"`
+
+	// Title Title of the control
+	Title string `json:"title"`
+
+	// UncoveredRequirements Assessment requirements of the control that no procedure maps to
+	UncoveredRequirements []string `json:"uncoveredRequirements"`
+}
+
+// ControlFamily Family a control belongs to
+type ControlFamily struct {
+	// Id Unique identifier for the control family
+	Id string `json:"id"`
+
+	// Title Title of the control family
+	Title string `json:"title"`
+}
+
+// ControlReference Reference to a control in a loaded catalog
+type ControlReference struct {
+	// CatalogId Unique identifier for the catalog
+	CatalogId string `json:"catalogId"`
+
+	// ControlId Unique identifier for the control
+	ControlId string `json:"controlId"`
+
+	// Title Title of the control
+	Title string `json:"title"`
+}
+
+// ControlRequirement Assessment requirement of a control
+type ControlRequirement struct {
+	// Applicability Environments or contexts where the requirement applies
+	Applicability []string `json:"applicability"`
+
+	// Id Unique identifier for the assessment requirement
+	Id string `json:"id"`
+
+	// Text Text of the assessment requirement
+	Text string `json:"text"`
+}
+
+// CoverageReport Coverage of the loaded catalogs by the assessment procedures of the configured plugins
+type CoverageReport struct {
+	// Catalogs Coverage of each loaded catalog, ordered by catalog ID
+	Catalogs []CatalogCoverage `json:"catalogs"`
+
+	// ExcludedPlugins Plugins that cannot list their procedures and so are not part of the report
+	ExcludedPlugins []string `json:"excludedPlugins"`
+
+	// UnresolvedProcedures Procedures referencing a catalog, control, or assessment requirement that is not loaded
+	UnresolvedProcedures []UnresolvedProcedure `json:"unresolvedProcedures"`
+}
+
+// EnrichmentMode How evidence whose policy rule maps to controls in several catalogs is enriched. With "first", only the
+// first matching control is returned. With "all", every matching control is returned in matches.
+type EnrichmentMode string
+
 // EnrichmentRequest Request payload for telemetry attribute enrichment
 type EnrichmentRequest struct {
 	// Evidence Complete evidence log from policy engines and compliance assessment tools
 	Evidence Evidence `json:"evidence"`
+
+	// Mode How evidence whose policy rule maps to controls in several catalogs is enriched. With "first", only the
+	// first matching control is returned. With "all", every matching control is returned in matches.
+	Mode *EnrichmentMode `json:"mode,omitempty"`
 }
 
 // EnrichmentResponse Enriched compliance finding with risk attributes and threat mappings.
 type EnrichmentResponse struct {
 	// Compliance Compliance details from OCSF Security Control Profile.
 	Compliance Compliance `json:"compliance"`
+
+	// Matches Every control the evidence maps to, in catalog order, when the request mode is "all". The first
+	// entry is the same finding as compliance. Empty when the evidence could not be mapped.
+	Matches *[]Compliance `json:"matches,omitempty"`
 }
 
 // Error defines model for Error.
@@ -136,6 +325,13 @@ type Evidence struct {
 	// PolicyRuleId Unique identifier for the policy rule being evaluated or enforced
 	PolicyRuleId string `json:"policyRuleId"`
 
+	// PolicyTargetEnvironment Environment where the target resource or entity exists. When the mapped control does not apply
+	// to this environment, the compliance status is reported as Not Applicable.
+	PolicyTargetEnvironment *string `json:"policyTargetEnvironment,omitempty"`
+
+	// PolicyTargetId Unique identifier for the resource or entity being evaluated or enforced against
+	PolicyTargetId *string `json:"policyTargetId,omitempty"`
+
 	// RawData Raw JSON output from the policy engine
 	RawData *map[string]interface{} `json:"rawData,omitempty"`
 
@@ -146,5 +342,111 @@ type Evidence struct {
 // EvidencePolicyEvaluationStatus Result of the policy evaluation
 type EvidencePolicyEvaluationStatus string
 
+// FamilyCoverage Coverage of a control family
+type FamilyCoverage struct {
+	// ControlCount Number of controls in the family
+	ControlCount int `json:"controlCount"`
+
+	// Controls Coverage of each control, in catalog order
+	Controls []ControlCoverage `json:"controls"`
+
+	// CoveredControlCount Number of controls with at least one procedure
+	CoveredControlCount int `json:"coveredControlCount"`
+
+	// Id Unique identifier for the control family
+	Id string `json:"id"`
+
+	// Title Title of the control family
+	Title string `json:"title"`
+}
+
+// GuidelineMapping Framework requirements a control maps to
+type GuidelineMapping struct {
+	// Framework Framework or guideline the requirements belong to
+	Framework string `json:"framework"`
+
+	// Requirements Requirement identifiers in the framework
+	Requirements []string `json:"requirements"`
+}
+
+// PolicyRuleReference Policy rule of a mapper plugin
+type PolicyRuleReference struct {
+	// CatalogId Unique identifier for the catalog of the control
+	CatalogId string `json:"catalogId"`
+
+	// ControlId Unique identifier for the control
+	ControlId string `json:"controlId"`
+
+	// PluginId Unique identifier for the plugin
+	PluginId string `json:"pluginId"`
+
+	// PolicyRuleId Policy rule identifier that evidence carries for this procedure
+	PolicyRuleId string `json:"policyRuleId"`
+
+	// RequirementId Unique identifier for the assessment requirement
+	RequirementId string `json:"requirementId"`
+}
+
+// Procedure Assessment procedure mapping a policy rule to a control
+type Procedure struct {
+	// CatalogId Unique identifier for the catalog of the control
+	CatalogId string `json:"catalogId"`
+
+	// ControlId Unique identifier for the control
+	ControlId string `json:"controlId"`
+
+	// Documentation Documentation for the procedure
+	Documentation *string `json:"documentation,omitempty"`
+
+	// PolicyRuleId Policy rule identifier that evidence carries for this procedure
+	PolicyRuleId string `json:"policyRuleId"`
+
+	// RequirementId Unique identifier for the assessment requirement
+	RequirementId string `json:"requirementId"`
+}
+
+// ProcedureList Assessment procedures known to a mapper plugin
+type ProcedureList struct {
+	// PluginId Unique identifier for the plugin
+	PluginId   string      `json:"pluginId"`
+	Procedures []Procedure `json:"procedures"`
+}
+
+// RequirementCoverage Controls and policy rules covering a framework requirement
+type RequirementCoverage struct {
+	// Controls Controls mapping to the requirement, ordered by catalog and control ID
+	Controls []ControlReference `json:"controls"`
+
+	// Framework Framework or guideline the requirement belongs to
+	Framework string `json:"framework"`
+
+	// PolicyRules Procedures of every plugin that map to one of the controls
+	PolicyRules []PolicyRuleReference `json:"policyRules"`
+
+	// RequirementId Requirement identifier in the framework
+	RequirementId string `json:"requirementId"`
+}
+
+// UnresolvedProcedure Procedure that references something missing from the loaded catalogs
+type UnresolvedProcedure struct {
+	// Procedure Policy rule of a mapper plugin
+	Procedure PolicyRuleReference `json:"procedure"`
+
+	// Reason What the procedure references that is not loaded
+	Reason string `json:"reason"`
+}
+
+// GetV1CoverageParams defines parameters for GetV1Coverage.
+type GetV1CoverageParams struct {
+	// Format Response format; markdown returns the report as a Markdown document
+	Format *GetV1CoverageParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetV1CoverageParamsFormat defines parameters for GetV1Coverage.
+type GetV1CoverageParamsFormat string
+
 // PostV1EnrichJSONRequestBody defines body for PostV1Enrich for application/json ContentType.
 type PostV1EnrichJSONRequestBody = EnrichmentRequest
+
+// PostV1EnrichBatchJSONRequestBody defines body for PostV1EnrichBatch for application/json ContentType.
+type PostV1EnrichBatchJSONRequestBody = BatchEnrichmentRequest
@@ -0,0 +1,173 @@
+// Package auth authenticates Compass clients with API keys and authorizes
+// them per route scope.
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Scope is a class of routes that can be granted to a client.
+type Scope string
+
+const (
+	// ScopeRead covers the read-only catalog and mapping queries.
+	ScopeRead Scope = "read"
+	// ScopeEnrich covers the enrichment endpoints.
+	ScopeEnrich Scope = "enrich"
+	// ScopeAdmin covers administrative endpoints and implies every other
+	// scope.
+	ScopeAdmin Scope = "admin"
+)
+
+var (
+	// ErrUnauthenticated is returned when no valid key is presented.
+	ErrUnauthenticated = errors.New("missing or invalid API key")
+	// ErrForbidden is returned when a valid key lacks the required scope.
+	ErrForbidden = errors.New("API key is not allowed to use this endpoint")
+)
+
+// Config configures client authentication. Authentication is disabled when
+// APIKeysFile is empty.
+type Config struct {
+	// APIKeysFile is the path to a YAML file of hashed API keys.
+	APIKeysFile string `json:"api-keys-file"`
+	// Anonymous lists scopes that clients may use without a key.
+	Anonymous []Scope `json:"anonymous"`
+}
+
+// Key is an API key as stored in the keys file. Only the SHA-256 hash of the
+// key is stored.
+type Key struct {
+	ID string `json:"id"`
+	// SHA256 is the hex-encoded SHA-256 hash of the key.
+	SHA256 string  `json:"sha256"`
+	Scopes []Scope `json:"scopes"`
+}
+
+// File is the on-disk format of an API keys file.
+type File struct {
+	Keys []Key `json:"keys"`
+}
+
+// KeyStore is an immutable set of validated API keys.
+type KeyStore struct {
+	keys      map[string]Key
+	anonymous []Scope
+}
+
+// Load reads and validates the API keys file named in cfg.
+func Load(cfg Config) (*KeyStore, error) {
+	content, err := os.ReadFile(filepath.Clean(cfg.APIKeysFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var file File
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("parsing API keys file %s: %w", cfg.APIKeysFile, err)
+	}
+
+	store, err := NewKeyStore(cfg.Anonymous, file.Keys...)
+	if err != nil {
+		return nil, fmt.Errorf("API keys file %s: %w", cfg.APIKeysFile, err)
+	}
+	return store, nil
+}
+
+// NewKeyStore validates keys and returns a KeyStore holding them. Requests
+// for the anonymous scopes are allowed without a key.
+func NewKeyStore(anonymous []Scope, keys ...Key) (*KeyStore, error) {
+	for _, scope := range anonymous {
+		if err := scope.validate(); err != nil {
+			return nil, fmt.Errorf("anonymous: %w", err)
+		}
+	}
+
+	store := &KeyStore{keys: make(map[string]Key), anonymous: anonymous}
+	ids := make(map[string]bool)
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("key has no id")
+		}
+		if ids[key.ID] {
+			return nil, fmt.Errorf("duplicate key id %s", key.ID)
+		}
+		ids[key.ID] = true
+
+		hash := strings.ToLower(key.SHA256)
+		if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("key %s: sha256 must be a hex-encoded SHA-256 hash", key.ID)
+		}
+		if _, ok := store.keys[hash]; ok {
+			return nil, fmt.Errorf("key %s: hash is already used by another key", key.ID)
+		}
+		if len(key.Scopes) == 0 {
+			return nil, fmt.Errorf("key %s has no scopes", key.ID)
+		}
+		for _, scope := range key.Scopes {
+			if err := scope.validate(); err != nil {
+				return nil, fmt.Errorf("key %s: %w", key.ID, err)
+			}
+		}
+		store.keys[hash] = key
+	}
+	return store, nil
+}
+
+// Len returns the number of keys in the store.
+func (s *KeyStore) Len() int {
+	return len(s.keys)
+}
+
+// Authorize checks that token grants scope. It returns the matching key, or
+// a zero Key when scope is allowed anonymously and no token is given.
+func (s *KeyStore) Authorize(token string, scope Scope) (Key, error) {
+	if token == "" {
+		if grants(s.anonymous, scope) {
+			return Key{}, nil
+		}
+		return Key{}, ErrUnauthenticated
+	}
+
+	key, ok := s.keys[HashKey(token)]
+	if !ok {
+		return Key{}, ErrUnauthenticated
+	}
+	if !grants(key.Scopes, scope) && !grants(s.anonymous, scope) {
+		return key, ErrForbidden
+	}
+	return key, nil
+}
+
+// HashKey returns the value to store in a keys file for key.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// grants reports whether scopes allow scope.
+func grants(scopes []Scope, scope Scope) bool {
+	for _, granted := range scopes {
+		if granted == scope || granted == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Scope) validate() error {
+	switch s {
+	case ScopeRead, ScopeEnrich, ScopeAdmin:
+		return nil
+	default:
+		return fmt.Errorf("unknown scope %q: must be one of read, enrich, admin", s)
+	}
+}
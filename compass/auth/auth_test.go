@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeyStore(t *testing.T) {
+	validHash := HashKey("secret")
+
+	tests := []struct {
+		name      string
+		anonymous []Scope
+		keys      []Key
+		wantErr   string
+	}{
+		{name: "valid", anonymous: []Scope{ScopeRead}, keys: []Key{{ID: "truthbeam", SHA256: validHash, Scopes: []Scope{ScopeEnrich}}}},
+		{name: "uppercase hash", keys: []Key{{ID: "truthbeam", SHA256: "A" + validHash[1:], Scopes: []Scope{ScopeEnrich}}}},
+		{name: "missing id", keys: []Key{{SHA256: validHash, Scopes: []Scope{ScopeEnrich}}}, wantErr: "no id"},
+		{name: "duplicate id", keys: []Key{
+			{ID: "a", SHA256: validHash, Scopes: []Scope{ScopeEnrich}},
+			{ID: "a", SHA256: HashKey("other"), Scopes: []Scope{ScopeEnrich}},
+		}, wantErr: "duplicate key id a"},
+		{name: "duplicate hash", keys: []Key{
+			{ID: "a", SHA256: validHash, Scopes: []Scope{ScopeEnrich}},
+			{ID: "b", SHA256: validHash, Scopes: []Scope{ScopeRead}},
+		}, wantErr: "already used"},
+		{name: "plaintext key", keys: []Key{{ID: "a", SHA256: "secret", Scopes: []Scope{ScopeEnrich}}}, wantErr: "hex-encoded SHA-256"},
+		{name: "no scopes", keys: []Key{{ID: "a", SHA256: validHash}}, wantErr: "no scopes"},
+		{name: "unknown scope", keys: []Key{{ID: "a", SHA256: validHash, Scopes: []Scope{"write"}}}, wantErr: "unknown scope"},
+		{name: "unknown anonymous scope", anonymous: []Scope{"write"}, wantErr: "anonymous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyStore(tt.anonymous, tt.keys...)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestKeyStoreAuthorize(t *testing.T) {
+	store, err := NewKeyStore([]Scope{ScopeRead},
+		Key{ID: "truthbeam", SHA256: HashKey("enrich-key"), Scopes: []Scope{ScopeEnrich}},
+		Key{ID: "ops", SHA256: HashKey("admin-key"), Scopes: []Scope{ScopeAdmin}},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		scope   Scope
+		wantID  string
+		wantErr error
+	}{
+		{name: "anonymous read", scope: ScopeRead},
+		{name: "anonymous enrich", scope: ScopeEnrich, wantErr: ErrUnauthenticated},
+		{name: "unknown key", token: "guess", scope: ScopeRead, wantErr: ErrUnauthenticated},
+		{name: "enrich key enriches", token: "enrich-key", scope: ScopeEnrich, wantID: "truthbeam"},
+		{name: "enrich key reads anonymous scope", token: "enrich-key", scope: ScopeRead, wantID: "truthbeam"},
+		{name: "enrich key is not admin", token: "enrich-key", scope: ScopeAdmin, wantID: "truthbeam", wantErr: ErrForbidden},
+		{name: "admin key enriches", token: "admin-key", scope: ScopeEnrich, wantID: "ops"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := store.Authorize(tt.token, tt.scope)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantID, key.ID)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api-keys.yaml")
+	content := "keys:\n  - id: truthbeam\n    sha256: " + HashKey("enrich-key") + "\n    scopes: [enrich]\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	store, err := Load(Config{APIKeysFile: path})
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.Len())
+
+	key, err := store.Authorize("enrich-key", ScopeEnrich)
+	require.NoError(t, err)
+	assert.Equal(t, "truthbeam", key.ID)
+
+	_, err = Load(Config{APIKeysFile: filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/complytime/complybeacon/compass/api"
+)
+
+// APIKeyHeader is an alternative to the Authorization header for clients
+// that cannot send bearer tokens.
+const APIKeyHeader = "X-API-Key"
+
+// Middleware rejects requests whose key does not grant the scope returned by
+// scopeFor for the matched route.
+func Middleware(store *KeyStore, scopeFor func(c *gin.Context) Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope := scopeFor(c)
+		token := tokenFromHeaders(c.GetHeader("Authorization"), c.GetHeader(APIKeyHeader))
+		key, err := store.Authorize(token, scope)
+		if err != nil {
+			slog.Warn("request rejected",
+				slog.String("request_id", requestid.Get(c)),
+				slog.String("path", c.Request.URL.Path),
+				slog.String("scope", string(scope)),
+				slog.String("key_id", key.ID),
+				slog.String("error", err.Error()),
+			)
+			code := http.StatusForbidden
+			if errors.Is(err, ErrUnauthenticated) {
+				code = http.StatusUnauthorized
+				c.Header("WWW-Authenticate", "Bearer")
+			}
+			c.AbortWithStatusJSON(code, api.Error{Code: int32(code), Message: err.Error()})
+			return
+		}
+		c.Next()
+	}
+}
+
+// UnaryServerInterceptor rejects unary calls whose key does not grant scope.
+func UnaryServerInterceptor(store *KeyStore, scope Scope) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authorizeRPC(ctx, store, scope); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects streams whose key does not grant scope.
+func StreamServerInterceptor(store *KeyStore, scope Scope) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorizeRPC(stream.Context(), store, scope); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func authorizeRPC(ctx context.Context, store *KeyStore, scope Scope) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	token := tokenFromHeaders(first(md.Get("authorization")), first(md.Get(strings.ToLower(APIKeyHeader))))
+	if _, err := store.Authorize(token, scope); err != nil {
+		if errors.Is(err, ErrUnauthenticated) {
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+// tokenFromHeaders returns the bearer token from authorization, or apiKey
+// when no bearer token is given.
+func tokenFromHeaders(authorization, apiKey string) string {
+	if scheme, token, ok := strings.Cut(authorization, " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return strings.TrimSpace(apiKey)
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestStore(t *testing.T) *KeyStore {
+	t.Helper()
+	store, err := NewKeyStore(nil,
+		Key{ID: "reader", SHA256: HashKey("read-key"), Scopes: []Scope{ScopeRead}},
+		Key{ID: "truthbeam", SHA256: HashKey("enrich-key"), Scopes: []Scope{ScopeEnrich}},
+	)
+	require.NoError(t, err)
+	return store
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Middleware(newTestStore(t), func(*gin.Context) Scope { return ScopeEnrich }))
+	r.POST("/v1/enrich", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name     string
+		header   string
+		value    string
+		wantCode int
+	}{
+		{name: "no key", wantCode: http.StatusUnauthorized},
+		{name: "bearer token", header: "Authorization", value: "Bearer enrich-key", wantCode: http.StatusOK},
+		{name: "lowercase scheme", header: "Authorization", value: "bearer enrich-key", wantCode: http.StatusOK},
+		{name: "api key header", header: APIKeyHeader, value: "enrich-key", wantCode: http.StatusOK},
+		{name: "wrong key", header: "Authorization", value: "Bearer nope", wantCode: http.StatusUnauthorized},
+		{name: "basic auth", header: "Authorization", value: "Basic ZW5yaWNoLWtleQ==", wantCode: http.StatusUnauthorized},
+		{name: "missing scope", header: "Authorization", value: "Bearer read-key", wantCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/enrich", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor(newTestStore(t), ScopeEnrich)
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		wantCode codes.Code
+	}{
+		{name: "no key", wantCode: codes.Unauthenticated},
+		{name: "bearer token", md: metadata.Pairs("authorization", "Bearer enrich-key"), wantCode: codes.OK},
+		{name: "api key", md: metadata.Pairs("x-api-key", "enrich-key"), wantCode: codes.OK},
+		{name: "missing scope", md: metadata.Pairs("authorization", "Bearer read-key"), wantCode: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/complytime/complybeacon/compass/coverage"
+	"github.com/complytime/complybeacon/compass/internal/logging"
+)
+
+// runCoverage implements "compass coverage". It loads the catalogs and
+// plugins as the server would and writes the report served by
+// GET /v1/coverage.
+func runCoverage(args []string, stdout, stderr io.Writer) int {
+	var (
+		configPath, outputPath string
+		format, logLevel       string
+		catalogPaths           stringSliceFlag
+	)
+	flags := flag.NewFlagSet("compass coverage", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Var(&catalogPaths, "catalog", "Path to a Layer 2 catalog file, directory, or glob pattern; may be repeated (default \""+defaultCatalogPath+"\")")
+	flags.StringVar(&configPath, "config", "./docs/config.yaml", "Path to compass config file")
+	flags.StringVar(&outputPath, "output", "", "File to write the report to instead of stdout")
+	flags.StringVar(&format, "format", "json", "Output format: json|markdown")
+	flags.StringVar(&logLevel, "log-level", "warn", "Log level: debug|info|warn|error")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if format != "json" && format != "markdown" {
+		_, _ = fmt.Fprintf(stderr, "invalid format %q: must be json or markdown\n", format)
+		return 2
+	}
+
+	// The report may be written to stdout, so logs go to stderr.
+	if _, err := logging.InitWriter(stderr, logLevel); err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	configPath = filepath.Clean(configPath)
+	cfg, err := readConfig(configPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "config %s: %v\n", configPath, err)
+		return 1
+	}
+	set, scope, err := loadPlugins(&cfg, configCatalogPaths(catalogPaths, cfg))
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return 1
+	}
+	defer func() {
+		if err := set.Close(); err != nil {
+			slog.Warn("failed to stop plugins", "err", err)
+		}
+	}()
+	report := coverage.Report(set, scope)
+
+	output := stdout
+	var outputFile *os.File
+	if outputPath != "" {
+		outputFile, err = os.Create(filepath.Clean(outputPath))
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return 1
+		}
+		defer func() { _ = outputFile.Close() }()
+		output = outputFile
+	}
+
+	if format == "markdown" {
+		err = coverage.WriteMarkdown(output, report)
+	} else {
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	}
+	if err == nil && outputFile != nil {
+		err = outputFile.Close()
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/exception"
+	"github.com/complytime/complybeacon/compass/internal/evidencefile"
+	"github.com/complytime/complybeacon/compass/internal/logging"
+	compass "github.com/complytime/complybeacon/compass/service"
+)
+
+// enrichResult is written for each piece of evidence read by "compass
+// enrich". It holds the evidence followed by the result of the batch
+// enrichment API for it.
+type enrichResult struct {
+	Evidence *api.Evidence `json:"evidence,omitempty"`
+	api.BatchEnrichmentResult
+}
+
+// enrichSummary counts the evidence enriched by "compass enrich".
+type enrichSummary struct {
+	total, mapped, unmapped, failed int
+}
+
+// runEnrich implements "compass enrich". It enriches the evidence in each
+// input file, or stdin, with the configured plugins and catalogs, and writes
+// one result per piece of evidence, in order.
+func runEnrich(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var (
+		configPath, outputPath string
+		modeName, format       string
+		logLevel               string
+		catalogPaths           stringSliceFlag
+		failUnmapped           bool
+	)
+	flags := flag.NewFlagSet("compass enrich", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "Usage: compass enrich [flags] [file ...]")
+		_, _ = fmt.Fprintln(stderr, "Reads evidence from each file, or from stdin when none is given or a file is \"-\".")
+		flags.PrintDefaults()
+	}
+	flags.Var(&catalogPaths, "catalog", "Path to a Layer 2 catalog file, directory, or glob pattern; may be repeated (default \""+defaultCatalogPath+"\")")
+	flags.StringVar(&configPath, "config", "./docs/config.yaml", "Path to compass config file")
+	flags.StringVar(&outputPath, "output", "", "File to write results to instead of stdout")
+	flags.StringVar(&format, "format", "ndjson", "Output format: ndjson|json")
+	flags.StringVar(&modeName, "mode", string(api.First), "Enrichment mode for evidence that does not request one: first|all")
+	flags.StringVar(&logLevel, "log-level", "warn", "Log level: debug|info|warn|error")
+	flags.BoolVar(&failUnmapped, "fail-unmapped", false, "Exit non-zero if any evidence is not mapped to a control")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	mode := api.EnrichmentMode(modeName)
+	if mode != api.First && mode != api.All {
+		_, _ = fmt.Fprintf(stderr, "invalid mode %q: must be first or all\n", modeName)
+		return 2
+	}
+	if format != "ndjson" && format != "json" {
+		_, _ = fmt.Fprintf(stderr, "invalid format %q: must be ndjson or json\n", format)
+		return 2
+	}
+
+	// Results are written to stdout, so logs go to stderr.
+	if _, err := logging.InitWriter(stderr, logLevel); err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	service, err := newOfflineService(filepath.Clean(configPath), catalogPaths)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return 1
+	}
+	defer func() {
+		if err := service.Close(); err != nil {
+			slog.Warn("failed to stop plugins", "err", err)
+		}
+	}()
+
+	output := stdout
+	var outputFile *os.File
+	if outputPath != "" {
+		outputFile, err = os.Create(filepath.Clean(outputPath))
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return 1
+		}
+		defer func() { _ = outputFile.Close() }()
+		output = outputFile
+	}
+
+	inputs := flags.Args()
+	if len(inputs) == 0 {
+		inputs = []string{"-"}
+	}
+
+	var (
+		summary enrichSummary
+		results []enrichResult
+	)
+	encoder := json.NewEncoder(output)
+	write := func(result enrichResult) error {
+		if format == "json" {
+			results = append(results, result)
+			return nil
+		}
+		return encoder.Encode(result)
+	}
+
+	ctx := context.Background()
+	for _, input := range inputs {
+		if err := enrichFile(ctx, service, input, stdin, &mode, &summary, write); err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+
+	if format == "json" {
+		if results == nil {
+			results = []enrichResult{}
+		}
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(map[string][]enrichResult{"results": results}); err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+
+	if outputFile != nil {
+		if err := outputFile.Close(); err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+
+	_, _ = fmt.Fprintf(stderr, "enriched %d evidence records: %d mapped, %d unmapped, %d failed\n",
+		summary.total, summary.mapped, summary.unmapped, summary.failed)
+	if failUnmapped && summary.mapped < summary.total {
+		return 1
+	}
+	return 0
+}
+
+// newOfflineService loads the config, catalogs, plugins, and exceptions as
+// the server does, into a service that is used without serving the API.
+func newOfflineService(configPath string, catalogPaths []string) (*compass.Service, error) {
+	cfg, err := readConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("config %s: %w", configPath, err)
+	}
+	if err := cfg.Risk.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid risk configuration in %s: %w", configPath, err)
+	}
+
+	opts := []compass.Option{
+		compass.WithRiskConfig(cfg.Risk),
+		compass.WithEnvironmentCategories(cfg.EnvironmentCategories...),
+	}
+	if cfg.ExceptionsFile != "" {
+		exceptions, err := exception.Load(cfg.ExceptionsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load exceptions: %w", err)
+		}
+		opts = append(opts, compass.WithExceptions(exceptions))
+	}
+
+	set, scope, err := loadPlugins(&cfg, configCatalogPaths(catalogPaths, cfg))
+	if err != nil {
+		return nil, err
+	}
+	return compass.NewService(set, scope, opts...), nil
+}
+
+// enrichFile enriches the evidence read from input, or from stdin when input
+// is "-", passing each result to write. Evidence that does not request a mode
+// is enriched with mode.
+func enrichFile(ctx context.Context, service *compass.Service, input string, stdin io.Reader, mode *api.EnrichmentMode, summary *enrichSummary, write func(enrichResult) error) error {
+	r := stdin
+	if input != "-" {
+		file, err := os.Open(filepath.Clean(input))
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		r = file
+	}
+
+	reader := evidencefile.NewReader(r)
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", input, err)
+		}
+
+		summary.total++
+		result := enrichResult{Evidence: &record.Evidence}
+		if record.Err == nil {
+			requestMode := record.Mode
+			if requestMode == nil {
+				requestMode = mode
+			}
+			var response api.EnrichmentResponse
+			response, record.Err = service.Enrich(ctx, record.Evidence, requestMode)
+			if record.Err == nil {
+				result.Compliance = &response.Compliance
+				result.Matches = response.Matches
+			}
+		}
+
+		switch {
+		case record.Err != nil:
+			summary.failed++
+			result.Error = &api.Error{Code: http.StatusBadRequest, Message: record.Err.Error()}
+		case result.Compliance.EnrichmentStatus == api.ComplianceEnrichmentStatusSuccess:
+			summary.mapped++
+		default:
+			summary.unmapped++
+		}
+
+		if err := write(result); err != nil {
+			return err
+		}
+	}
+}
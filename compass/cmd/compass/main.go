@@ -1,32 +1,116 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"flag"
+	"fmt"
 	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/goccy/go-yaml"
+	"google.golang.org/grpc"
 
+	"github.com/complytime/complybeacon/compass/auth"
 	"github.com/complytime/complybeacon/compass/cmd/compass/server"
+	"github.com/complytime/complybeacon/compass/exception"
 	"github.com/complytime/complybeacon/compass/internal/logging"
+	"github.com/complytime/complybeacon/compass/internal/telemetry"
+	"github.com/complytime/complybeacon/compass/mapper"
 	compass "github.com/complytime/complybeacon/compass/service"
 )
 
+const defaultCatalogPath = "./hack/sampledata/osps.yaml"
+
+// stringSliceFlag collects the values of a flag that may be repeated.
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// readConfig reads and parses the compass config file at configPath.
+func readConfig(configPath string) (server.Config, error) {
+	var cfg server.Config
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return cfg, err
+	}
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return cfg, fmt.Errorf("cannot parse config: %w", err)
+	}
+	return cfg, nil
+}
+
+// configCatalogPaths returns the catalog paths given on the command line
+// followed by those in cfg, or the default catalog when there are none.
+func configCatalogPaths(flagPaths []string, cfg server.Config) []string {
+	catalogPaths := append(flagPaths, cfg.Catalogs...)
+	if len(catalogPaths) == 0 {
+		catalogPaths = append(catalogPaths, defaultCatalogPath)
+	}
+	return catalogPaths
+}
+
+// loadPlugins loads the catalogs at catalogPaths and creates the plugins
+// configured in cfg.
+func loadPlugins(cfg *server.Config, catalogPaths []string) (mapper.Set, mapper.Scope, error) {
+	scope, err := server.NewScopeFromCatalogPaths(catalogPaths...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load catalogs: %w", err)
+	}
+	transformers, err := server.NewMapperSet(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize plugin mappers: %w", err)
+	}
+	return transformers, scope, nil
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "validate":
+			os.Exit(runValidate(os.Args[2:], os.Stdout, os.Stderr))
+		case "enrich":
+			os.Exit(runEnrich(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+		case "coverage":
+			os.Exit(runCoverage(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
 
 	var (
-		port, catalogPath, configPath string
-		logLevel                      string
-		skipTLS                       bool
+		port, configPath string
+		grpcPort         string
+		logLevel         string
+		skipTLS, watch   bool
+		catalogPaths     stringSliceFlag
+		shutdownGrace    time.Duration
+		drainDelay       time.Duration
 	)
 
 	flag.StringVar(&port, "port", "8080", "Port for HTTP server")
+	flag.StringVar(&grpcPort, "grpc-port", "", "Port for gRPC server; disabled when empty")
 	flag.BoolVar(&skipTLS, "skip-tls", false, "Run without TLS")
 	flag.StringVar(&logLevel, "log-level", "info", "Log level: debug|info|warn|error")
+	flag.BoolVar(&watch, "watch", false, "Reload catalogs and plugin evaluations when they change on disk")
+	flag.DurationVar(&drainDelay, "shutdown-drain-delay", 5*time.Second, "Time to keep serving after SIGTERM while /readyz fails, so load balancers stop routing traffic before the listeners close")
+	flag.DurationVar(&shutdownGrace, "shutdown-grace-period", 20*time.Second, "Time to let in-flight requests finish once the listeners close, after the drain delay")
 
 	// TODO: This needs to become Layer 3 policy and complete resolution on startup
-	flag.StringVar(&catalogPath, "catalog", "./hack/sampledata/osps.yaml", "Path to Layer 2 catalog")
+	flag.Var(&catalogPaths, "catalog", "Path to a Layer 2 catalog file, directory, or glob pattern; may be repeated (default \""+defaultCatalogPath+"\")")
 	flag.StringVar(&configPath, "config", "./docs/config.yaml", "Path to compass config file")
 	flag.Parse()
 
@@ -38,53 +122,183 @@ func main() {
 
 	slog.Info("starting compass service",
 		slog.String("port", port),
-		slog.String("catalog", catalogPath),
+		slog.String("grpc_port", grpcPort),
+		slog.Any("catalog", []string(catalogPaths)),
 		slog.String("config", configPath),
 		slog.Bool("skip_tls", skipTLS),
+		slog.Bool("watch", watch),
+		slog.Duration("shutdown_drain_delay", drainDelay),
+		slog.Duration("shutdown_grace_period", shutdownGrace),
 	)
 
-	catalogPath = filepath.Clean(catalogPath)
-	scope, err := server.NewScopeFromCatalogPath(catalogPath)
-	if err != nil {
-		slog.Error("failed to load catalog", "path", catalogPath, "err", err)
-		os.Exit(1)
-	}
-
-	var cfg server.Config
 	configPath = filepath.Clean(configPath)
-	content, err := os.ReadFile(configPath)
+	cfg, err := readConfig(configPath)
 	if err != nil {
-		slog.Error("failed to read config file", "path", configPath, "err", err)
+		slog.Error("failed to load config file", "path", configPath, "err", err)
 		os.Exit(1)
 	}
 
-	err = yaml.Unmarshal(content, &cfg)
-	if err != nil {
-		slog.Error("failed to parse config file", "path", configPath, "err", err)
+	if err := cfg.Risk.Validate(); err != nil {
+		slog.Error("invalid risk configuration", "path", configPath, "err", err)
 		os.Exit(1)
 	}
 
-	transformers, err := server.NewMapperSet(&cfg)
+	tel, err := telemetry.Setup(context.Background(), cfg.Telemetry)
 	if err != nil {
-		slog.Error("failed to initialize plugin mappers", "err", err)
+		slog.Error("failed to set up telemetry", "err", err)
 		os.Exit(1)
 	}
 
-	service := compass.NewService(transformers, scope)
+	catalogPaths = configCatalogPaths(catalogPaths, cfg)
+	load := func() (mapper.Set, mapper.Scope, error) {
+		return loadPlugins(&cfg, catalogPaths)
+	}
 
-	s := server.NewGinServer(service, port)
+	opts := []compass.Option{
+		compass.WithRiskConfig(cfg.Risk),
+		compass.WithEnvironmentCategories(cfg.EnvironmentCategories...),
+	}
+	if cfg.ExceptionsFile != "" {
+		exceptions, err := exception.Load(cfg.ExceptionsFile)
+		if err != nil {
+			slog.Error("failed to load exceptions", "path", cfg.ExceptionsFile, "err", err)
+			os.Exit(1)
+		}
+		slog.Info("exceptions loaded",
+			slog.String("path", cfg.ExceptionsFile),
+			slog.Int("count", exceptions.Len()),
+		)
+		opts = append(opts, compass.WithExceptions(exceptions))
+	}
 
-	if skipTLS {
-		slog.Warn("Insecure connections permitted. TLS is highly recommended for production")
-		if err := s.ListenAndServe(); err != nil {
-			slog.Error("server error", "err", err)
+	var keys *auth.KeyStore
+	if cfg.Auth.APIKeysFile != "" {
+		keys, err = auth.Load(cfg.Auth)
+		if err != nil {
+			slog.Error("failed to load API keys", "path", cfg.Auth.APIKeysFile, "err", err)
 			os.Exit(1)
 		}
+		slog.Info("API key authentication enabled",
+			slog.String("path", cfg.Auth.APIKeysFile),
+			slog.Int("keys", keys.Len()),
+			slog.Any("anonymous", cfg.Auth.Anonymous),
+		)
 	} else {
-		cert, key := server.SetupTLS(s, cfg)
-		if err := s.ListenAndServeTLS(cert, key); err != nil {
-			slog.Error("server error", "err", err)
+		slog.Warn("API key authentication disabled; any client that can reach compass can use it")
+	}
+
+	// The servers start before catalogs and plugins are loaded; /readyz
+	// reports not ready until they are.
+	service := compass.NewService(nil, nil, opts...)
+	health := server.NewHealth(service)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	serverErrs := make(chan error, 2)
+
+	ginOpts := []server.GinOption{server.WithHealth(health), server.WithMetrics(tel.MetricsHandler())}
+	if keys != nil {
+		ginOpts = append(ginOpts, server.WithAuth(keys))
+	}
+	if !skipTLS && cfg.Certificate.ClientCA != "" {
+		ginOpts = append(ginOpts, server.WithClientCertificates())
+	}
+	s := server.NewGinServer(service, port, ginOpts...)
+	if !skipTLS {
+		if err := server.SetupTLS(s, cfg); err != nil {
+			slog.Error("failed to configure tls", "err", err)
 			os.Exit(1)
 		}
 	}
+
+	var grpcServer *grpc.Server
+	if grpcPort != "" {
+		var tlsConfig *tls.Config
+		if !skipTLS {
+			tlsConfig, err = server.NewTLSConfig(cfg)
+			if err != nil {
+				slog.Error("failed to configure grpc tls", "err", err)
+				os.Exit(1)
+			}
+		}
+		listener, err := net.Listen("tcp", net.JoinHostPort("0.0.0.0", grpcPort))
+		if err != nil {
+			slog.Error("failed to listen for grpc", "port", grpcPort, "err", err)
+			os.Exit(1)
+		}
+		grpcServer = server.NewGRPCServer(service, tlsConfig, keys)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				serverErrs <- fmt.Errorf("grpc server: %w", err)
+			}
+		}()
+	}
+
+	go func() {
+		var err error
+		if skipTLS {
+			slog.Warn("Insecure connections permitted. TLS is highly recommended for production")
+			err = s.ListenAndServe()
+		} else {
+			err = s.ListenAndServeTLS("", "")
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErrs <- fmt.Errorf("http server: %w", err)
+		}
+	}()
+
+	transformers, scope, err := load()
+	if err != nil {
+		slog.Error("failed to load catalogs and plugins", "err", err)
+		os.Exit(1)
+	}
+	service.Update(transformers, scope)
+	slog.Info("catalogs and plugins loaded",
+		slog.Int("catalogs", len(scope)),
+		slog.Int("plugins", len(transformers)),
+	)
+
+	if watch {
+		watchPaths := server.CatalogWatchPaths(catalogPaths...)
+		for _, pluginConf := range cfg.Plugins {
+			if pluginConf.EvaluationsDir != "" {
+				watchPaths = append(watchPaths, pluginConf.EvaluationsDir)
+			}
+		}
+		reloader := server.NewReloader(service, load, watchPaths...)
+		go func() {
+			if err := reloader.Watch(ctx); err != nil {
+				slog.Error("failed to watch catalogs and plugins", "err", err)
+			}
+		}()
+	}
+
+	select {
+	case err := <-serverErrs:
+		slog.Error("server error", "err", err)
+		os.Exit(1)
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down",
+		slog.Duration("drain_delay", drainDelay),
+		slog.Duration("grace_period", shutdownGrace),
+	)
+	// Keep serving while /readyz fails so that load balancers and
+	// Kubernetes endpoints stop routing new traffic here first.
+	health.Drain()
+	time.Sleep(drainDelay)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx, s, grpcServer); err != nil {
+		slog.Error("shutdown did not complete within the grace period", "err", err)
+		os.Exit(1)
+	}
+	if err := service.Close(); err != nil {
+		slog.Warn("failed to stop plugins", "err", err)
+	}
+	if err := tel.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("failed to flush telemetry", "err", err)
+	}
+	slog.Info("shutdown complete")
 }
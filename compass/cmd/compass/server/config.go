@@ -6,62 +6,241 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/goccy/go-yaml"
 	"github.com/ossf/gemara/layer2"
 	"github.com/ossf/gemara/layer4"
 
+	"github.com/complytime/complybeacon/compass/auth"
+	"github.com/complytime/complybeacon/compass/internal/telemetry"
 	"github.com/complytime/complybeacon/compass/mapper"
 	"github.com/complytime/complybeacon/compass/mapper/factory"
+	"github.com/complytime/complybeacon/compass/risk"
 )
 
+// NewScopeFromCatalogPath loads the Layer 2 catalogs found at catalogPath,
+// which may be a file, a directory, or a glob pattern.
 func NewScopeFromCatalogPath(catalogPath string) (mapper.Scope, error) {
+	return NewScopeFromCatalogPaths(catalogPath)
+}
+
+// NewScopeFromCatalogPaths loads the Layer 2 catalogs found at each of the
+// given paths into a single scope. Each path may be a file, a directory, or a
+// glob pattern. Two catalogs with the same ID are rejected.
+func NewScopeFromCatalogPaths(catalogPaths ...string) (mapper.Scope, error) {
+	if len(catalogPaths) == 0 {
+		return nil, errors.New("no catalog paths provided")
+	}
+
+	scope := make(mapper.Scope)
+	loadedFrom := make(map[string]string)
+	for _, catalogPath := range catalogPaths {
+		files, err := resolveCatalogFiles(catalogPath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			layer2Catalog, err := loadCatalog(file)
+			if err != nil {
+				return nil, fmt.Errorf("catalog %s: %w", file, err)
+			}
+
+			catalogID := layer2Catalog.Metadata.Id
+			if catalogID == "" {
+				return nil, fmt.Errorf("catalog %s has no metadata.id", file)
+			}
+			if previous, ok := loadedFrom[catalogID]; ok {
+				if previous == file {
+					continue
+				}
+				return nil, fmt.Errorf("duplicate catalog id %s in %s and %s", catalogID, previous, file)
+			}
+			loadedFrom[catalogID] = file
+			scope[catalogID] = layer2Catalog
+		}
+	}
+
+	slog.Debug("catalogs loaded", slog.Int("count", len(scope)))
+	return scope, nil
+}
+
+// CatalogWatchPaths returns the files and directories to watch for changes to
+// the catalogs at the given paths. For glob patterns, the directory holding
+// the pattern is watched so that newly matching files are noticed.
+func CatalogWatchPaths(catalogPaths ...string) []string {
+	var watchPaths []string
+	for _, catalogPath := range catalogPaths {
+		cleanedPath := filepath.Clean(catalogPath)
+		if isGlob(cleanedPath) {
+			cleanedPath = filepath.Dir(cleanedPath)
+			if isGlob(cleanedPath) {
+				slog.Warn("cannot watch catalog pattern with wildcard directories",
+					slog.String("path", catalogPath),
+				)
+				continue
+			}
+		}
+		watchPaths = append(watchPaths, cleanedPath)
+	}
+	return watchPaths
+}
+
+// resolveCatalogFiles expands a catalog path into the catalog files it refers
+// to, sorted for deterministic loading.
+func resolveCatalogFiles(catalogPath string) ([]string, error) {
 	cleanedPath := filepath.Clean(catalogPath)
-	slog.Debug("loading catalog", slog.String("path", cleanedPath))
 
-	catalogData, err := os.ReadFile(cleanedPath)
+	if isGlob(cleanedPath) {
+		matches, err := filepath.Glob(cleanedPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid catalog pattern %s: %w", catalogPath, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("catalog pattern %s matched no files", catalogPath)
+		}
+		sort.Strings(matches)
+		return matches, nil
+	}
+
+	info, err := os.Stat(cleanedPath)
 	if err != nil {
 		return nil, err
 	}
+	if !info.IsDir() {
+		return []string{cleanedPath}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(cleanedPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml":
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("catalog directory %s contains no YAML files", catalogPath)
+	}
+	return files, nil
+}
+
+// loadCatalog reads a single Layer 2 catalog file.
+func loadCatalog(catalogPath string) (layer2.Catalog, error) {
+	slog.Debug("loading catalog", slog.String("path", catalogPath))
 
 	var layer2Catalog layer2.Catalog
+	catalogData, err := os.ReadFile(catalogPath)
+	if err != nil {
+		return layer2Catalog, err
+	}
+
 	err = yaml.Unmarshal(catalogData, &layer2Catalog)
 	if err != nil {
-		return nil, err
+		return layer2Catalog, err
 	}
 
 	slog.Debug("catalog loaded",
 		slog.String("catalog_id", layer2Catalog.Metadata.Id),
+		slog.String("path", catalogPath),
 	)
+	return layer2Catalog, nil
+}
 
-	return mapper.Scope{
-		layer2Catalog.Metadata.Id: layer2Catalog,
-	}, nil
+func isGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[")
 }
 
 type Config struct {
+	// Catalogs lists Layer 2 catalog files, directories, or glob patterns to
+	// load in addition to any given on the command line.
+	Catalogs    []string       `json:"catalogs"`
 	Plugins     []PluginConfig `json:"plugins"`
 	Certificate CertConfig     `json:"certConfig"`
+	// Risk defines how risk levels are assigned to enriched findings.
+	Risk risk.Config `json:"risk"`
+	// ExceptionsFile is the path to a YAML file of approved exceptions.
+	ExceptionsFile string `json:"exceptions-file"`
+	// EnvironmentCategories lists, by ID or title, the catalog applicability
+	// categories that name target environments. Only these are compared with
+	// the evidence target environment.
+	EnvironmentCategories []string `json:"environment-categories"`
+	// Telemetry selects the exporters for Compass's own metrics and traces.
+	Telemetry telemetry.Config `json:"telemetry"`
+	// Auth configures API key authentication of clients.
+	Auth auth.Config `json:"auth"`
 }
 
 type CertConfig struct {
 	PublicKey  string `json:"cert"`
 	PrivateKey string `json:"key"`
+	// ClientCA is a PEM file of CAs for verifying client certificates. When
+	// set, clients must present a certificate signed by one of them.
+	ClientCA string `json:"client-ca"`
 }
 
 type PluginConfig struct {
-	Id             string `json:"id"`
+	Id string `json:"id"`
+	// Type selects the mapper implementation for the plugin. When empty,
+	// basic is used.
+	Type           string `json:"type"`
 	EvaluationsDir string `json:"evaluations-dir"`
+	// CatalogPrecedence orders catalogs when a policy rule is mapped in more
+	// than one. Listed catalogs win over unlisted ones, which are ordered by
+	// ID.
+	CatalogPrecedence []string `json:"catalog-precedence"`
+	// Config holds settings specific to the plugin's type.
+	Config mapper.Config `json:"config"`
+	// StatusMapping overrides how policy evaluation results translate to
+	// compliance statuses.
+	StatusMapping *mapper.StatusMapping `json:"status-mapping"`
 }
 
-func NewMapperSet(config *Config) (mapper.Set, error) {
+// NewMapperSet creates the mapper for each configured plugin and loads its
+// evaluation plans. On error, the mappers created so far are closed.
+func NewMapperSet(config *Config) (set mapper.Set, err error) {
 	pluginSet := make(mapper.Set)
 	slog.Debug("loading plugins", slog.Int("count", len(config.Plugins)))
+	defer func() {
+		if err != nil {
+			if closeErr := pluginSet.Close(); closeErr != nil {
+				slog.Warn("failed to close plugins", slog.String("err", closeErr.Error()))
+			}
+		}
+	}()
 
 	for _, pluginConf := range config.Plugins {
 		transformerId := mapper.ID(pluginConf.Id)
+		tfmr, err := factory.NewMapper(transformerId, mapper.Type(pluginConf.Type), pluginConf.Config)
+		if err != nil {
+			return pluginSet, fmt.Errorf("plugin %s: %w", pluginConf.Id, err)
+		}
+		pluginSet[transformerId] = tfmr
+
+		if pluginConf.StatusMapping != nil {
+			if err := pluginConf.StatusMapping.Validate(); err != nil {
+				return pluginSet, fmt.Errorf("status-mapping for plugin %s: %w", pluginConf.Id, err)
+			}
+			statusMapper, ok := tfmr.(mapper.StatusMapper)
+			if !ok {
+				return pluginSet, fmt.Errorf("plugin %s does not support status-mapping", pluginConf.Id)
+			}
+			statusMapper.SetStatusMapping(*pluginConf.StatusMapping)
+		}
+
 		if pluginConf.EvaluationsDir == "" {
-			slog.Info("plugin has no evaluations; skipping",
+			slog.Info("plugin has no evaluations",
 				slog.String("plugin_id", string(transformerId)),
 			)
 			continue
@@ -79,18 +258,39 @@ func NewMapperSet(config *Config) (mapper.Set, error) {
 			return pluginSet, fmt.Errorf("evaluations directory %s for plugin %s is not a directory", pluginConf.EvaluationsDir, pluginConf.Id)
 		}
 
-		tfmr, err := NewMapperFromDir(transformerId, pluginConf.EvaluationsDir)
+		seen := make(map[string]bool)
+		for _, catalogID := range pluginConf.CatalogPrecedence {
+			if seen[catalogID] {
+				return pluginSet, fmt.Errorf("catalog-precedence for plugin %s lists %s more than once", pluginConf.Id, catalogID)
+			}
+			seen[catalogID] = true
+		}
+
+		err = LoadEvaluations(tfmr, pluginConf.EvaluationsDir, pluginConf.CatalogPrecedence...)
 		if err != nil {
 			return pluginSet, fmt.Errorf("unable to load configuration for %s: %w", pluginConf.Id, err)
 		}
-		pluginSet[transformerId] = tfmr
 	}
 	slog.Debug("plugins loaded", slog.Int("count", len(pluginSet)))
 	return pluginSet, nil
 }
 
-func NewMapperFromDir(pluginID mapper.ID, evaluationsPath string) (mapper.Mapper, error) {
-	mpr := factory.MapperByID(pluginID)
+// NewMapperFromDir creates a mapper of the default type for pluginID and
+// loads every evaluation plan under evaluationsPath into it. See
+// LoadEvaluations.
+func NewMapperFromDir(pluginID mapper.ID, evaluationsPath string, precedence ...string) (mapper.Mapper, error) {
+	mpr, err := factory.NewMapper(pluginID, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return mpr, LoadEvaluations(mpr, evaluationsPath, precedence...)
+}
+
+// LoadEvaluations loads every evaluation plan under evaluationsPath into mpr.
+// Catalogs listed in precedence are preferred, in order, when a policy rule is
+// mapped in several catalogs; each such policy rule is logged as a conflict.
+func LoadEvaluations(mpr mapper.Mapper, evaluationsPath string, precedence ...string) error {
+	pluginID := mpr.PluginName()
 	err := filepath.Walk(evaluationsPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -122,11 +322,29 @@ func NewMapperFromDir(pluginID mapper.ID, evaluationsPath string) (mapper.Mapper
 		return nil
 	})
 	if err != nil {
-		return mpr, err
+		return err
 	}
+
+	precedenceMapper, ok := mpr.(mapper.PrecedenceMapper)
+	if !ok {
+		if len(precedence) > 0 {
+			return fmt.Errorf("plugin %s does not support catalog-precedence", pluginID)
+		}
+	} else {
+		precedenceMapper.SetCatalogPrecedence(precedence...)
+		for _, conflict := range precedenceMapper.Conflicts() {
+			slog.Warn("policy rule mapped in several catalogs",
+				slog.String("plugin_id", string(pluginID)),
+				slog.String("policy_rule_id", conflict.PolicyRuleID),
+				slog.String("catalogs", strings.Join(conflict.CatalogIDs, ",")),
+				slog.String("selected_catalog", conflict.CatalogIDs[0]),
+			)
+		}
+	}
+
 	slog.Info("plugin evaluations loaded",
 		slog.String("plugin_id", string(pluginID)),
 		slog.String("dir", evaluationsPath),
 	)
-	return mpr, nil
+	return nil
 }
@@ -0,0 +1,358 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/ossf/gemara/layer2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// writeCatalog writes a minimal Layer 2 catalog with the given ID to path.
+func writeCatalog(t *testing.T, path, id string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	content := fmt.Sprintf("metadata:\n  id: %s\n  title: %s\ncontrol-families: []\n", id, id)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+// writePlan writes an evaluation plan mapping procedureID to requirement
+// controlID.01 of controlID in catalogID.
+func writePlan(t *testing.T, path, catalogID, controlID, procedureID string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	content := fmt.Sprintf(`plans:
+  - control:
+      reference-id: %[1]s
+      entry-id: %[2]s
+    assessments:
+      - requirement:
+          reference-id: %[1]s
+          entry-id: %[2]s.01
+        procedures:
+          - id: %[3]s
+            name: %[3]s
+            description: %[3]s
+`, catalogID, controlID, procedureID)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestNewScopeFromCatalogPaths(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, filepath.Join(dir, "osps.yaml"), "OSPS-B")
+	writeCatalog(t, filepath.Join(dir, "cis", "cis.yaml"), "CIS")
+	writeCatalog(t, filepath.Join(dir, "cis", "internal.yml"), "INTERNAL")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cis", "README.md"), []byte("not a catalog"), 0o600))
+
+	tests := []struct {
+		name     string
+		paths    []string
+		expected []string
+	}{
+		{
+			name:     "single file",
+			paths:    []string{filepath.Join(dir, "osps.yaml")},
+			expected: []string{"OSPS-B"},
+		},
+		{
+			name:     "directory",
+			paths:    []string{filepath.Join(dir, "cis")},
+			expected: []string{"CIS", "INTERNAL"},
+		},
+		{
+			name:     "glob",
+			paths:    []string{filepath.Join(dir, "cis", "*.yaml")},
+			expected: []string{"CIS"},
+		},
+		{
+			name:     "repeated paths",
+			paths:    []string{filepath.Join(dir, "osps.yaml"), filepath.Join(dir, "cis")},
+			expected: []string{"OSPS-B", "CIS", "INTERNAL"},
+		},
+		{
+			name:     "same file through two paths",
+			paths:    []string{filepath.Join(dir, "cis"), filepath.Join(dir, "cis", "cis.yaml")},
+			expected: []string{"CIS", "INTERNAL"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope, err := NewScopeFromCatalogPaths(tt.paths...)
+			require.NoError(t, err)
+			assert.Len(t, scope, len(tt.expected))
+			for _, id := range tt.expected {
+				assert.Contains(t, scope, id)
+			}
+		})
+	}
+}
+
+func TestNewScopeFromCatalogPathsErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, filepath.Join(dir, "a.yaml"), "OSPS-B")
+	writeCatalog(t, filepath.Join(dir, "b.yaml"), "OSPS-B")
+	noIDDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(noIDDir, "catalog.yaml"), []byte("control-families: []\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		paths    []string
+		errorMsg string
+	}{
+		{
+			name:     "no paths",
+			errorMsg: "no catalog paths",
+		},
+		{
+			name:     "duplicate catalog id",
+			paths:    []string{dir},
+			errorMsg: "duplicate catalog id OSPS-B",
+		},
+		{
+			name:     "catalog without id",
+			paths:    []string{noIDDir},
+			errorMsg: "has no metadata.id",
+		},
+		{
+			name:     "glob without matches",
+			paths:    []string{filepath.Join(dir, "*.json")},
+			errorMsg: "matched no files",
+		},
+		{
+			name:     "missing file",
+			paths:    []string{filepath.Join(dir, "missing.yaml")},
+			errorMsg: "no such file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewScopeFromCatalogPaths(tt.paths...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestCatalogWatchPaths(t *testing.T) {
+	paths := CatalogWatchPaths("catalogs/osps.yaml", "catalogs/cis/*.yaml", "catalogs/*/baseline.yaml")
+	assert.Equal(t, []string{"catalogs/osps.yaml", "catalogs/cis"}, paths)
+}
+
+func TestNewMapperFromDirPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	writePlan(t, filepath.Join(dir, "a.yaml"), "CIS", "CIS-1", "branch_protection")
+	writePlan(t, filepath.Join(dir, "b.yaml"), "OSPS-B", "OSPS-AC-03", "branch_protection")
+	writePlan(t, filepath.Join(dir, "c.yaml"), "OSPS-B", "OSPS-QA-07", "signed_commits")
+
+	tests := []struct {
+		name       string
+		precedence []string
+		expected   []string
+	}{
+		{
+			name:     "catalog id order without precedence",
+			expected: []string{"CIS", "OSPS-B"},
+		},
+		{
+			name:       "precedence wins",
+			precedence: []string{"OSPS-B"},
+			expected:   []string{"OSPS-B", "CIS"},
+		},
+		{
+			name:       "precedence for catalogs without plans is ignored",
+			precedence: []string{"CCC", "CIS"},
+			expected:   []string{"CIS", "OSPS-B"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mpr, err := NewMapperFromDir("basic", dir, tt.precedence...)
+			require.NoError(t, err)
+
+			precedenceMapper, ok := mpr.(mapper.PrecedenceMapper)
+			require.True(t, ok)
+			assert.Equal(t, []mapper.Conflict{
+				{PolicyRuleID: "branch_protection", CatalogIDs: tt.expected},
+			}, precedenceMapper.Conflicts())
+		})
+	}
+}
+
+func TestNewMapperSetDuplicatePrecedence(t *testing.T) {
+	dir := t.TempDir()
+	writePlan(t, filepath.Join(dir, "plan.yaml"), "CIS", "CIS-1", "branch_protection")
+
+	_, err := NewMapperSet(&Config{
+		Plugins: []PluginConfig{
+			{Id: "basic", EvaluationsDir: dir, CatalogPrecedence: []string{"CIS", "OSPS-B", "CIS"}},
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lists CIS more than once")
+}
+
+func TestNewMapperSetTypes(t *testing.T) {
+	dir := t.TempDir()
+	writePlan(t, filepath.Join(dir, "plan.yaml"), "CIS", "CIS-1", "branch_protection")
+
+	tests := []struct {
+		name     string
+		plugin   PluginConfig
+		wantType string
+		wantErr  string
+	}{
+		{
+			name:     "explicit type",
+			plugin:   PluginConfig{Id: "conforma", Type: "basic", EvaluationsDir: dir},
+			wantType: "*basic.Mapper",
+		},
+		{
+			name:     "id naming a type defaults to basic",
+			plugin:   PluginConfig{Id: "conforma", EvaluationsDir: dir},
+			wantType: "*basic.Mapper",
+		},
+		{
+			name:     "type matching id",
+			plugin:   PluginConfig{Id: "conforma", Type: "conforma", EvaluationsDir: dir},
+			wantType: "*conforma.Mapper",
+		},
+		{
+			name:     "unregistered id defaults to basic",
+			plugin:   PluginConfig{Id: "scanner", EvaluationsDir: dir},
+			wantType: "*basic.Mapper",
+		},
+		{
+			name:    "unknown type",
+			plugin:  PluginConfig{Id: "conforma", Type: "missing", EvaluationsDir: dir},
+			wantErr: `plugin conforma: unknown plugin type "missing"`,
+		},
+		{
+			name:    "unknown type without evaluations",
+			plugin:  PluginConfig{Id: "conforma", Type: "missing"},
+			wantErr: `unknown plugin type "missing"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := NewMapperSet(&Config{Plugins: []PluginConfig{tt.plugin}})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			id := mapper.ID(tt.plugin.Id)
+			require.Contains(t, set, id)
+			assert.Equal(t, id, set[id].PluginName())
+			assert.Equal(t, tt.wantType, fmt.Sprintf("%T", set[id]))
+		})
+	}
+}
+
+func TestNewMapperSetStatusMapping(t *testing.T) {
+	dir := t.TempDir()
+	writePlan(t, filepath.Join(dir, "plan.yaml"), "CIS", "CIS-1", "branch_protection")
+	scope := mapper.Scope{
+		"CIS": layer2.Catalog{
+			Metadata: layer2.Metadata{Id: "CIS"},
+			ControlFamilies: []layer2.ControlFamily{{
+				Title: "Source Code",
+				Controls: []layer2.Control{{
+					Id:                     "CIS-1",
+					AssessmentRequirements: []layer2.AssessmentRequirement{{Id: "CIS-1.01"}},
+				}},
+			}},
+		},
+	}
+
+	content := fmt.Sprintf(`plugins:
+  - id: github
+    evaluations-dir: %s
+    status-mapping:
+      results:
+        Needs Review: Needs Review
+        Not Run: Not Applicable
+      overrides:
+        - catalog: CIS
+          control: CIS-1
+          results:
+            Not Run: Non-Compliant
+`, dir)
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte(content), &cfg))
+	set, err := NewMapperSet(&cfg)
+	require.NoError(t, err)
+
+	evidence := api.Evidence{
+		PolicyEngineName:       "github",
+		PolicyRuleId:           "branch_protection",
+		PolicyEvaluationStatus: api.NotRun,
+		Timestamp:              time.Now(),
+	}
+	assert.Equal(t, api.ComplianceStatusNonCompliant, set["github"].Map(evidence, scope).Status)
+	evidence.PolicyEvaluationStatus = api.NeedsReview
+	assert.Equal(t, api.ComplianceStatusNeedsReview, set["github"].Map(evidence, scope).Status)
+
+	t.Run("applied to results read from rawData", func(t *testing.T) {
+		tests := []struct {
+			pluginType string
+			rawData    map[string]interface{}
+		}{
+			{
+				pluginType: "kyverno",
+				rawData:    map[string]interface{}{"policy": "branch_protection", "result": "warn"},
+			},
+			{
+				pluginType: "conforma",
+				rawData: map[string]interface{}{"warnings": []interface{}{
+					map[string]interface{}{"metadata": map[string]interface{}{"code": "branch_protection"}},
+				}},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.pluginType, func(t *testing.T) {
+				set, err := NewMapperSet(&Config{Plugins: []PluginConfig{{
+					Id:             "scanner",
+					Type:           tt.pluginType,
+					EvaluationsDir: dir,
+					StatusMapping: &mapper.StatusMapping{
+						Results: mapper.StatusTable{api.NeedsReview: api.ComplianceStatusNonCompliant},
+					},
+				}}})
+				require.NoError(t, err)
+				compliance := set["scanner"].Map(api.Evidence{
+					PolicyEngineName:       "scanner",
+					PolicyRuleId:           "scan",
+					PolicyEvaluationStatus: api.Unknown,
+					RawData:                &tt.rawData,
+					Timestamp:              time.Now(),
+				}, scope)
+				assert.Equal(t, "CIS-1.01", compliance.Control.Id)
+				assert.Equal(t, api.ComplianceStatusNonCompliant, compliance.Status)
+			})
+		}
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		_, err := NewMapperSet(&Config{Plugins: []PluginConfig{{
+			Id: "github",
+			StatusMapping: &mapper.StatusMapping{
+				Results: mapper.StatusTable{api.NotRun: "Failing"},
+			},
+		}}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `status-mapping for plugin github: results: Not Run: unknown compliance status "Failing"`)
+	})
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"crypto/tls"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/complytime/complybeacon/compass/api/compassv1"
+	"github.com/complytime/complybeacon/compass/auth"
+	compass "github.com/complytime/complybeacon/compass/service"
+)
+
+// NewGRPCServer returns a gRPC server exposing the enrichment API of service.
+// When tlsConfig is nil, the server accepts plaintext connections. When keys
+// is set, calls need an API key granting the enrich scope. Incoming trace
+// context is extracted so enrichment spans join the caller's trace.
+func NewGRPCServer(service *compass.Service, tlsConfig *tls.Config, keys *auth.KeyStore) *grpc.Server {
+	opts := []grpc.ServerOption{grpc.StatsHandler(otelgrpc.NewServerHandler())}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	if keys != nil {
+		opts = append(opts,
+			grpc.UnaryInterceptor(auth.UnaryServerInterceptor(keys, auth.ScopeEnrich)),
+			grpc.StreamInterceptor(auth.StreamServerInterceptor(keys, auth.ScopeEnrich)),
+		)
+	}
+
+	s := grpc.NewServer(opts...)
+	compassv1.RegisterEnrichmentServiceServer(s, compass.NewGRPCServer(service))
+	return s
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadinessChecker reports whether enrichment requests can be served.
+type ReadinessChecker interface {
+	Ready() error
+}
+
+// Health serves the liveness and readiness endpoints.
+type Health struct {
+	checker  ReadinessChecker
+	draining atomic.Bool
+}
+
+// NewHealth returns a Health whose readiness is reported by checker.
+func NewHealth(checker ReadinessChecker) *Health {
+	return &Health{checker: checker}
+}
+
+// Drain marks the server as shutting down. Readiness fails from then on so
+// that no new traffic is routed to it while in-flight requests finish.
+func (h *Health) Drain() {
+	h.draining.Store(true)
+}
+
+// Register adds GET /healthz and GET /readyz to r.
+func (h *Health) Register(r gin.IRoutes) {
+	r.GET("/healthz", h.live)
+	r.GET("/readyz", h.ready)
+}
+
+func (h *Health) live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *Health) ready(c *gin.Context) {
+	if h.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "draining"})
+		return
+	}
+	if err := h.checker.Ready(); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not ready", "reason": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// defaultReloadDebounce is how long the Reloader waits after the last file
+// event before reloading, so editors and config-map updates that touch several
+// files trigger a single reload.
+const defaultReloadDebounce = 500 * time.Millisecond
+
+const instrumentationName = "github.com/complytime/complybeacon/compass/cmd/compass/server"
+
+// attrReloadResult records whether a reload was swapped in or rejected.
+const attrReloadResult = attribute.Key("compass.reload.result")
+
+// Loader rebuilds the mapper set and scope from their sources on disk.
+type Loader func() (mapper.Set, mapper.Scope, error)
+
+// Updater receives a freshly loaded mapper set and scope.
+type Updater interface {
+	Update(transformers mapper.Set, scope mapper.Scope)
+}
+
+// Reloader watches catalog and evaluation paths and swaps newly loaded
+// mapper sets and scopes into an Updater when they change. A load that fails
+// leaves the current values in place.
+type Reloader struct {
+	updater  Updater
+	load     Loader
+	paths    []string
+	debounce time.Duration
+
+	// succeeded and failed count reloads for the totals logged with each.
+	succeeded atomic.Uint64
+	failed    atomic.Uint64
+	// lastSuccess is the time of the last successful reload in Unix
+	// nanoseconds, or zero before the first.
+	lastSuccess atomic.Int64
+
+	reloads metric.Int64Counter
+}
+
+// NewReloader returns a Reloader that watches the given files and directories.
+// Reloads are recorded with the global meter provider.
+func NewReloader(updater Updater, load Loader, paths ...string) *Reloader {
+	r := &Reloader{
+		updater:  updater,
+		load:     load,
+		paths:    paths,
+		debounce: defaultReloadDebounce,
+	}
+	r.instrument(otel.GetMeterProvider())
+	return r
+}
+
+// instrument registers the reload metrics with provider.
+func (r *Reloader) instrument(provider metric.MeterProvider) {
+	meter := provider.Meter(instrumentationName)
+
+	// Instrument creation only fails on invalid names; the no-op instruments
+	// returned alongside the error are safe to use.
+	r.reloads, _ = meter.Int64Counter("compass.reload.count",
+		metric.WithDescription("Number of catalog and plugin reloads by result."),
+		metric.WithUnit("{reload}"),
+	)
+	_, _ = meter.Float64ObservableGauge("compass.reload.last_success",
+		metric.WithDescription("Unix time of the last successful catalog and plugin reload."),
+		metric.WithUnit("s"),
+		metric.WithFloat64Callback(func(_ context.Context, observer metric.Float64Observer) error {
+			if nanos := r.lastSuccess.Load(); nanos != 0 {
+				observer.Observe(float64(nanos) / float64(time.Second))
+			}
+			return nil
+		}),
+	)
+}
+
+// Reload loads the mapper set and scope and swaps them into the Updater.
+func (r *Reloader) Reload() error {
+	start := time.Now()
+	set, scope, err := r.load()
+	if err != nil {
+		failed := r.failed.Add(1)
+		r.reloads.Add(context.Background(), 1, metric.WithAttributes(attrReloadResult.String("failure")))
+		slog.Error("reload rejected; keeping current catalogs and plugins",
+			slog.String("err", err.Error()),
+			slog.Uint64("reload_failures_total", failed),
+		)
+		return err
+	}
+
+	r.updater.Update(set, scope)
+	succeeded := r.succeeded.Add(1)
+	r.lastSuccess.Store(time.Now().UnixNano())
+	r.reloads.Add(context.Background(), 1, metric.WithAttributes(attrReloadResult.String("success")))
+	slog.Info("catalogs and plugins reloaded",
+		slog.Int("catalogs", len(scope)),
+		slog.Int("plugins", len(set)),
+		slog.Duration("duration", time.Since(start)),
+		slog.Uint64("reloads_total", succeeded),
+	)
+	return nil
+}
+
+// Watch blocks until ctx is done, reloading whenever a watched path changes.
+// Files are watched through their parent directory so that replacements by
+// rename, as done by editors and Kubernetes config maps, are observed.
+func (r *Reloader) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	for _, path := range r.paths {
+		if err := addWatch(watcher, path); err != nil {
+			return fmt.Errorf("watching %s: %w", path, err)
+		}
+	}
+
+	timer := time.NewTimer(r.debounce)
+	if !timer.Stop() {
+		<-timer.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			slog.Debug("watched path changed",
+				slog.String("path", event.Name),
+				slog.String("op", event.Op.String()),
+			)
+			// New directories under an evaluations directory are watched as well.
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := addWatch(watcher, event.Name); err != nil {
+						slog.Warn("failed to watch new directory",
+							slog.String("path", event.Name),
+							slog.String("err", err.Error()),
+						)
+					}
+				}
+			}
+			timer.Reset(r.debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Warn("file watcher error", slog.String("err", err.Error()))
+		case <-timer.C:
+			// Errors are logged and counted by Reload.
+			_ = r.Reload()
+		}
+	}
+}
+
+// addWatch registers path with the watcher. Directories are watched
+// recursively; files are watched through their parent directory.
+func addWatch(watcher *fsnotify.Watcher, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return watcher.Add(filepath.Dir(path))
+	}
+
+	return filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		return watcher.Add(p)
+	})
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ossf/gemara/layer2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// fakeUpdater records the values passed to Update.
+type fakeUpdater struct {
+	mu      sync.Mutex
+	updates int
+	scope   mapper.Scope
+}
+
+func (f *fakeUpdater) Update(_ mapper.Set, scope mapper.Scope) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.updates++
+	f.scope = scope
+}
+
+func (f *fakeUpdater) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.updates
+}
+
+func TestReloader_Reload(t *testing.T) {
+	t.Run("successful load is swapped in", func(t *testing.T) {
+		updater := &fakeUpdater{}
+		scope := mapper.Scope{"catalog-1": layer2.Catalog{}}
+		reloader := NewReloader(updater, func() (mapper.Set, mapper.Scope, error) {
+			return mapper.Set{}, scope, nil
+		})
+
+		require.NoError(t, reloader.Reload())
+
+		assert.Equal(t, 1, updater.count())
+		assert.Equal(t, scope, updater.scope)
+	})
+
+	t.Run("failed load keeps current values", func(t *testing.T) {
+		updater := &fakeUpdater{}
+		reloader := NewReloader(updater, func() (mapper.Set, mapper.Scope, error) {
+			return nil, nil, errors.New("bad catalog")
+		})
+
+		assert.Error(t, reloader.Reload())
+
+		assert.Equal(t, 0, updater.count())
+	})
+}
+
+func TestReloader_Metrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	fail := true
+	reloader := NewReloader(&fakeUpdater{}, func() (mapper.Set, mapper.Scope, error) {
+		if fail {
+			return nil, nil, errors.New("bad catalog")
+		}
+		return mapper.Set{}, mapper.Scope{}, nil
+	})
+	reloader.instrument(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	collect := func() (map[string]int64, float64) {
+		var data metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &data))
+		counts := map[string]int64{}
+		var lastSuccess float64
+		for _, scopeMetrics := range data.ScopeMetrics {
+			for _, m := range scopeMetrics.Metrics {
+				switch m.Name {
+				case "compass.reload.count":
+					for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+						result, _ := point.Attributes.Value(attribute.Key("compass.reload.result"))
+						counts[result.AsString()] = point.Value
+					}
+				case "compass.reload.last_success":
+					for _, point := range m.Data.(metricdata.Gauge[float64]).DataPoints {
+						lastSuccess = point.Value
+					}
+				}
+			}
+		}
+		return counts, lastSuccess
+	}
+
+	assert.Error(t, reloader.Reload())
+	counts, lastSuccess := collect()
+	assert.Equal(t, map[string]int64{"failure": 1}, counts)
+	assert.Zero(t, lastSuccess)
+
+	fail = false
+	before := time.Now()
+	require.NoError(t, reloader.Reload())
+	counts, lastSuccess = collect()
+	assert.Equal(t, map[string]int64{"failure": 1, "success": 1}, counts)
+	assert.GreaterOrEqual(t, lastSuccess, float64(before.Unix()))
+}
+
+func TestReloader_Watch(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath := filepath.Join(dir, "catalog.yaml")
+	require.NoError(t, os.WriteFile(catalogPath, []byte("metadata: {id: one}\n"), 0o600))
+
+	evaluationsDir := filepath.Join(dir, "evaluations")
+	require.NoError(t, os.Mkdir(evaluationsDir, 0o750))
+
+	updater := &fakeUpdater{}
+	reloader := NewReloader(updater, func() (mapper.Set, mapper.Scope, error) {
+		return mapper.Set{}, mapper.Scope{}, nil
+	}, catalogPath, evaluationsDir)
+	reloader.debounce = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- reloader.Watch(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	// The watcher is registered asynchronously, so keep touching the file
+	// until a reload is observed.
+	require.Eventually(t, func() bool {
+		_ = os.WriteFile(catalogPath, []byte("metadata: {id: two}\n"), 0o600)
+		return updater.count() > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	before := updater.count()
+	require.Eventually(t, func() bool {
+		_ = os.WriteFile(filepath.Join(evaluationsDir, "plan.yaml"), []byte("plans: []\n"), 0o600)
+		return updater.count() > before
+	}, 5*time.Second, 50*time.Millisecond)
+}
@@ -1,22 +1,84 @@
 package server
 
 import (
+	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
 	middleware "github.com/oapi-codegen/gin-middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
 
 	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/auth"
 	httpmw "github.com/complytime/complybeacon/compass/internal/middleware"
 	compass "github.com/complytime/complybeacon/compass/service"
 )
 
-func NewGinServer(service *compass.Service, port string) *http.Server {
+// GinOption configures optional routes and middleware of the HTTP server.
+type GinOption func(*ginOptions)
+
+type ginOptions struct {
+	health         *Health
+	metricsHandler http.Handler
+	keys           *auth.KeyStore
+	clientCerts    bool
+}
+
+// WithHealth serves the liveness and readiness endpoints of health.
+func WithHealth(health *Health) GinOption {
+	return func(o *ginOptions) {
+		o.health = health
+	}
+}
+
+// WithMetrics serves handler at /metrics.
+func WithMetrics(handler http.Handler) GinOption {
+	return func(o *ginOptions) {
+		o.metricsHandler = handler
+	}
+}
+
+// WithAuth requires clients to present an API key from keys granting the
+// scope of each route.
+func WithAuth(keys *auth.KeyStore) GinOption {
+	return func(o *ginOptions) {
+		o.keys = keys
+	}
+}
+
+// WithClientCertificates requires clients to present a verified certificate
+// on every route except the health endpoints. It is used with a TLS config
+// from SetupTLS, which verifies certificates that clients present but lets
+// probes connect without one.
+func WithClientCertificates() GinOption {
+	return func(o *ginOptions) {
+		o.clientCerts = true
+	}
+}
+
+// NewGinServer returns the HTTP server for the Compass API. The health and
+// metrics endpoints are served outside of the API, without request
+// validation, tracing, or access logging. Health endpoints never require a
+// key or client certificate; metrics require the admin scope.
+func NewGinServer(service *compass.Service, port string, opts ...GinOption) *http.Server {
+	var options ginOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	swagger, err := api.GetSwagger()
 	if err != nil {
 		log.Fatalf("Error loading swagger spec\n: %s", err)
@@ -26,9 +88,27 @@ func NewGinServer(service *compass.Service, port string) *http.Server {
 	// that server names match. We don't know how this thing will be run.
 	swagger.Servers = nil
 
+	var authorize []gin.HandlerFunc
+	if options.keys != nil {
+		authorize = append(authorize, auth.Middleware(options.keys, RouteScope))
+	}
+
 	r := gin.New()
 	r.Use(gin.Recovery())
+	if options.health != nil {
+		options.health.Register(r)
+	}
+	// Routes registered before this point are not affected.
+	if options.clientCerts {
+		r.Use(requireClientCert)
+	}
+	if options.metricsHandler != nil {
+		r.GET("/metrics", append(authorize, gin.WrapH(options.metricsHandler))...)
+	}
+
+	r.Use(otelgin.Middleware("compass"))
 	r.Use(requestid.New(), httpmw.AccessLogger())
+	r.Use(authorize...)
 
 	r.Use(middleware.OapiRequestValidator(swagger))
 
@@ -43,18 +123,129 @@ func NewGinServer(service *compass.Service, port string) *http.Server {
 	return s
 }
 
-func SetupTLS(server *http.Server, config Config) (string, string) {
+// RouteScope returns the scope a client needs for the route matched by c:
+// enrich for the enrichment endpoints, read for other GET endpoints of the
+// API, and admin for everything else.
+func RouteScope(c *gin.Context) auth.Scope {
+	route := c.FullPath()
+	switch {
+	case c.Request.Method == http.MethodPost && (route == "/v1/enrich" || route == "/v1/enrich/batch"):
+		return auth.ScopeEnrich
+	case c.Request.Method == http.MethodGet && strings.HasPrefix(route, "/v1/"):
+		return auth.ScopeRead
+	default:
+		return auth.ScopeAdmin
+	}
+}
+
+// SetupTLS configures server for TLS with the configured certificate, so it
+// is served with ListenAndServeTLS("", ""). When a client CA is configured,
+// client certificates are verified if presented, so that health probes can
+// connect without one; WithClientCertificates then requires them on the
+// other routes. It is called before serving, so that invalid TLS settings
+// stop startup instead of the running server.
+func SetupTLS(server *http.Server, config Config) error {
+	tlsConfig, err := loadTLSConfig(config, tls.VerifyClientCertIfGiven)
+	if err != nil {
+		return err
+	}
+	server.TLSConfig = tlsConfig
+	return nil
+}
+
+// NewTLSConfig returns the server TLS configuration with the configured
+// certificate loaded, for use by listeners other than the HTTP server. When a
+// client CA is configured, clients must present a certificate signed by it.
+func NewTLSConfig(config Config) (*tls.Config, error) {
+	return loadTLSConfig(config, tls.RequireAndVerifyClientCert)
+}
+
+// loadTLSConfig returns the server TLS configuration with the configured
+// certificate loaded, checking client certificates according to clientAuth.
+func loadTLSConfig(config Config, clientAuth tls.ClientAuthType) (*tls.Config, error) {
+	if config.Certificate.PublicKey == "" || config.Certificate.PrivateKey == "" {
+		return nil, errors.New("invalid certification configuration: certConfig.cert and certConfig.key are required")
+	}
+	cert, err := tls.LoadX509KeyPair(config.Certificate.PublicKey, config.Certificate.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("loading certificate: %w", err)
+	}
+	tlsConfig, err := newTLSConfig(config.Certificate, clientAuth)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	return tlsConfig, nil
+}
+
+// newTLSConfig returns the server TLS settings. When a client CA is
+// configured, client certificates are checked against it according to
+// clientAuth.
+func newTLSConfig(certConfig CertConfig, clientAuth tls.ClientAuthType) (*tls.Config, error) {
 	// TODO: Allow loosening here through configuration
 	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS13}
-	server.TLSConfig = tlsConfig
+	if certConfig.ClientCA == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(filepath.Clean(certConfig.ClientCA))
+	if err != nil {
+		return nil, fmt.Errorf("reading client CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("client CA %s contains no PEM certificates", certConfig.ClientCA)
+	}
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = clientAuth
+	return tlsConfig, nil
+}
 
-	if config.Certificate.PublicKey == "" {
-		log.Fatal("Invalid certification configuration. Please add certConfig.cert to the configuration.")
+// requireClientCert rejects requests over connections that did not present a
+// client certificate. Certificates that are presented have already been
+// verified during the TLS handshake.
+func requireClientCert(c *gin.Context) {
+	if c.Request.TLS == nil || len(c.Request.TLS.VerifiedChains) == 0 {
+		slog.Warn("request rejected",
+			slog.String("path", c.Request.URL.Path),
+			slog.String("error", "no client certificate"),
+		)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, api.Error{
+			Code:    http.StatusUnauthorized,
+			Message: "client certificate required",
+		})
+		return
 	}
+	c.Next()
+}
 
-	if config.Certificate.PrivateKey == "" {
-		log.Fatal("Invalid certification configuration. Please add certConfig.key to the configuration.")
+// Shutdown stops the HTTP server and, when set, the gRPC server, letting
+// in-flight requests finish until ctx is done. Connections still open at that
+// point are closed and ctx's error is returned.
+func Shutdown(ctx context.Context, httpServer *http.Server, grpcServer *grpc.Server) error {
+	grpcDone := make(chan struct{})
+	go func() {
+		defer close(grpcDone)
+		if grpcServer != nil {
+			grpcServer.GracefulStop()
+		}
+	}()
+
+	err := httpServer.Shutdown(ctx)
+	if err != nil {
+		_ = httpServer.Close()
 	}
 
-	return config.Certificate.PublicKey, config.Certificate.PrivateKey
+	select {
+	case <-grpcDone:
+	case <-ctx.Done():
+		if grpcServer != nil {
+			grpcServer.Stop()
+		}
+		<-grpcDone
+		if err == nil {
+			err = ctx.Err()
+		}
+	}
+	return err
 }
@@ -0,0 +1,293 @@
+package server
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/auth"
+	"github.com/complytime/complybeacon/compass/mapper"
+	compass "github.com/complytime/complybeacon/compass/service"
+)
+
+func TestNewGinServerAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	keys, err := auth.NewKeyStore(nil,
+		auth.Key{ID: "dashboard", SHA256: auth.HashKey("read-key"), Scopes: []auth.Scope{auth.ScopeRead}},
+		auth.Key{ID: "truthbeam", SHA256: auth.HashKey("enrich-key"), Scopes: []auth.Scope{auth.ScopeEnrich}},
+		auth.Key{ID: "ops", SHA256: auth.HashKey("admin-key"), Scopes: []auth.Scope{auth.ScopeAdmin}},
+	)
+	require.NoError(t, err)
+
+	service := compass.NewService(mapper.Set{}, mapper.Scope{})
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	handler := NewGinServer(service, "0",
+		WithHealth(NewHealth(service)),
+		WithMetrics(metrics),
+		WithAuth(keys),
+	).Handler
+
+	enrichBody := `{"evidence": {"timestamp": "2024-01-15T10:30:00Z", "policyEngineName": "OPA", "policyRuleId": "deny-root-user", "policyEvaluationStatus": "Failed"}}`
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		key      string
+		wantCode int
+	}{
+		{name: "health needs no key", method: http.MethodGet, path: "/healthz", wantCode: http.StatusOK},
+		{name: "enrich without key", method: http.MethodPost, path: "/v1/enrich", wantCode: http.StatusUnauthorized},
+		{name: "enrich with read key", method: http.MethodPost, path: "/v1/enrich", key: "read-key", wantCode: http.StatusForbidden},
+		{name: "enrich with enrich key", method: http.MethodPost, path: "/v1/enrich", key: "enrich-key", wantCode: http.StatusOK},
+		{name: "query with enrich key", method: http.MethodGet, path: "/v1/catalogs", key: "enrich-key", wantCode: http.StatusForbidden},
+		{name: "query with read key", method: http.MethodGet, path: "/v1/catalogs", key: "read-key", wantCode: http.StatusOK},
+		{name: "metrics with read key", method: http.MethodGet, path: "/metrics", key: "read-key", wantCode: http.StatusForbidden},
+		{name: "metrics with admin key", method: http.MethodGet, path: "/metrics", key: "admin-key", wantCode: http.StatusOK},
+		{name: "unknown route without key", method: http.MethodGet, path: "/v2/unknown", wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body *bytes.Reader
+			if tt.method == http.MethodPost {
+				body = bytes.NewReader([]byte(enrichBody))
+			} else {
+				body = bytes.NewReader(nil)
+			}
+			req := httptest.NewRequest(tt.method, tt.path, body)
+			req.Header.Set("Content-Type", "application/json")
+			if tt.key != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.key)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestNewTLSConfigClientCA(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := newTestCA(t)
+	writePEM(t, filepath.Join(dir, "ca.crt"), "CERTIFICATE", ca.Raw)
+	serverCert := newTestCert(t, ca, caKey, "localhost", x509.ExtKeyUsageServerAuth)
+	writeKeyPair(t, dir, "server", serverCert)
+
+	config := Config{Certificate: CertConfig{
+		PublicKey:  filepath.Join(dir, "server.crt"),
+		PrivateKey: filepath.Join(dir, "server.key"),
+		ClientCA:   filepath.Join(dir, "ca.crt"),
+	}}
+	tlsConfig, err := NewTLSConfig(config)
+	require.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	require.NoError(t, err)
+	httpServer := &http.Server{
+		ReadHeaderTimeout: time.Second,
+		Handler:           http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }),
+	}
+	go func() { _ = httpServer.Serve(listener) }()
+	t.Cleanup(func() { _ = httpServer.Close() })
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	get := func(certificates ...tls.Certificate) error {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			ServerName:   "localhost",
+			Certificates: certificates,
+			MinVersion:   tls.VersionTLS13,
+		}}}
+		resp, err := client.Get("https://" + listener.Addr().String())
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	assert.NoError(t, get(newTestCert(t, ca, caKey, "truthbeam", x509.ExtKeyUsageClientAuth)))
+	assert.Error(t, get(), "clients without a certificate are rejected")
+
+	otherCA, otherKey := newTestCA(t)
+	assert.Error(t, get(newTestCert(t, otherCA, otherKey, "truthbeam", x509.ExtKeyUsageClientAuth)), "clients signed by another CA are rejected")
+
+	t.Run("invalid client CA", func(t *testing.T) {
+		invalid := filepath.Join(dir, "invalid.crt")
+		require.NoError(t, os.WriteFile(invalid, []byte("not a certificate"), 0600))
+		config.Certificate.ClientCA = invalid
+		_, err := NewTLSConfig(config)
+		assert.ErrorContains(t, err, "no PEM certificates")
+	})
+}
+
+func TestSetupTLSInvalid(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := newTestCA(t)
+	writeKeyPair(t, dir, "server", newTestCert(t, ca, caKey, "localhost", x509.ExtKeyUsageServerAuth))
+
+	tests := []struct {
+		name    string
+		config  CertConfig
+		wantErr string
+	}{
+		{
+			name:    "missing key",
+			config:  CertConfig{PublicKey: filepath.Join(dir, "server.crt")},
+			wantErr: "certConfig.cert and certConfig.key are required",
+		},
+		{
+			name:    "certificate not found",
+			config:  CertConfig{PublicKey: filepath.Join(dir, "missing.crt"), PrivateKey: filepath.Join(dir, "server.key")},
+			wantErr: "loading certificate",
+		},
+		{
+			name: "client CA not found",
+			config: CertConfig{
+				PublicKey:  filepath.Join(dir, "server.crt"),
+				PrivateKey: filepath.Join(dir, "server.key"),
+				ClientCA:   filepath.Join(dir, "missing-ca.crt"),
+			},
+			wantErr: "reading client CA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpServer := &http.Server{ReadHeaderTimeout: time.Second}
+			assert.ErrorContains(t, SetupTLS(httpServer, Config{Certificate: tt.config}), tt.wantErr)
+			assert.Nil(t, httpServer.TLSConfig)
+		})
+	}
+}
+
+func TestNewGinServerClientCertificates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dir := t.TempDir()
+	ca, caKey := newTestCA(t)
+	writePEM(t, filepath.Join(dir, "ca.crt"), "CERTIFICATE", ca.Raw)
+	writeKeyPair(t, dir, "server", newTestCert(t, ca, caKey, "localhost", x509.ExtKeyUsageServerAuth))
+	config := Config{Certificate: CertConfig{
+		PublicKey:  filepath.Join(dir, "server.crt"),
+		PrivateKey: filepath.Join(dir, "server.key"),
+		ClientCA:   filepath.Join(dir, "ca.crt"),
+	}}
+
+	service := compass.NewService(mapper.Set{}, mapper.Scope{"catalog": {}})
+	httpServer := NewGinServer(service, "0", WithHealth(NewHealth(service)), WithClientCertificates())
+	require.NoError(t, SetupTLS(httpServer, config))
+	assert.Equal(t, tls.VerifyClientCertIfGiven, httpServer.TLSConfig.ClientAuth)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = httpServer.ServeTLS(listener, "", "") }()
+	t.Cleanup(func() { _ = httpServer.Close() })
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	get := func(path string, certificates ...tls.Certificate) (int, error) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			ServerName:   "localhost",
+			Certificates: certificates,
+			MinVersion:   tls.VersionTLS13,
+		}}}
+		resp, err := client.Get("https://" + listener.Addr().String() + path)
+		if err != nil {
+			return 0, err
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	clientCert := newTestCert(t, ca, caKey, "truthbeam", x509.ExtKeyUsageClientAuth)
+	for _, path := range []string{"/healthz", "/readyz"} {
+		code, err := get(path)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code, "%s is served without a client certificate", path)
+	}
+
+	code, err := get("/v1/catalogs")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, code, "API routes require a client certificate")
+
+	code, err = get("/v1/catalogs", clientCert)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	otherCA, otherKey := newTestCA(t)
+	_, err = get("/healthz", newTestCert(t, otherCA, otherKey, "truthbeam", x509.ExtKeyUsageClientAuth))
+	assert.Error(t, err, "certificates signed by another CA are rejected")
+}
+
+func newTestCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return ca, key
+}
+
+func newTestCert(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, name string, usage x509.ExtKeyUsage) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func writeKeyPair(t *testing.T, dir, name string, cert tls.Certificate) {
+	t.Helper()
+	keyDER, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, name+".crt"), "CERTIFICATE", cert.Certificate[0])
+	writePEM(t, filepath.Join(dir, name+".key"), "EC PRIVATE KEY", keyDER)
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+}
@@ -0,0 +1,310 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-yaml"
+	"github.com/ossf/gemara/layer2"
+	"github.com/ossf/gemara/layer4"
+
+	"github.com/complytime/complybeacon/compass/exception"
+	"github.com/complytime/complybeacon/compass/mapper"
+	"github.com/complytime/complybeacon/compass/mapper/factory"
+)
+
+// Problem is a mistake found by Validate in a config, catalog, or evaluation
+// plan file.
+type Problem struct {
+	// Path is the file or directory the problem was found in.
+	Path    string
+	Message string
+	// Warning marks problems that the server tolerates but that are likely
+	// to leave evidence unmapped.
+	Warning bool
+}
+
+func (p Problem) String() string {
+	if p.Warning {
+		return fmt.Sprintf("%s: warning: %s", p.Path, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", p.Path, p.Message)
+}
+
+// Validate loads the catalogs at catalogPaths and the plugins and evaluation
+// plans configured in cfg, which was read from configPath, as the server
+// would. Instead of stopping at the first error, it reports every problem
+// found, including those the server silently ignores, such as evaluation
+// plans that reference controls missing from the catalogs.
+func Validate(configPath string, cfg *Config, catalogPaths ...string) []Problem {
+	v := &validator{configPath: configPath}
+	v.validateConfig(cfg)
+	index := v.validateCatalogs(catalogPaths)
+	seen := make(map[string]bool)
+	for i, pluginConf := range cfg.Plugins {
+		if seen[pluginConf.Id] {
+			v.errorf(configPath, "plugin %s is configured more than once", pluginConf.Id)
+			continue
+		}
+		seen[pluginConf.Id] = true
+		v.validatePlugin(i, pluginConf, index)
+	}
+	return v.problems
+}
+
+// ValidateFields reports a key of the config at configPath that Config does
+// not define as a warning. The server ignores such keys, so they are usually
+// misspelled settings. Only the first unknown key is reported.
+func ValidateFields(configPath string) []Problem {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return []Problem{{Path: configPath, Message: err.Error()}}
+	}
+	var cfg Config
+	if err := yaml.UnmarshalWithOptions(content, &cfg, yaml.Strict()); err != nil {
+		return []Problem{{Path: configPath, Message: yaml.FormatError(err, false, false), Warning: true}}
+	}
+	return nil
+}
+
+// HasErrors reports whether problems holds anything other than warnings.
+func HasErrors(problems []Problem) bool {
+	for _, problem := range problems {
+		if !problem.Warning {
+			return true
+		}
+	}
+	return false
+}
+
+type validator struct {
+	configPath string
+	problems   []Problem
+}
+
+func (v *validator) errorf(path, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) warnf(path, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Path: path, Message: fmt.Sprintf(format, args...), Warning: true})
+}
+
+func (v *validator) validateConfig(cfg *Config) {
+	if err := cfg.Risk.Validate(); err != nil {
+		v.errorf(v.configPath, "risk: %v", err)
+	}
+	if cfg.ExceptionsFile != "" {
+		if _, err := exception.Load(cfg.ExceptionsFile); err != nil {
+			v.errorf(cfg.ExceptionsFile, "%v", err)
+		}
+	}
+}
+
+// validateCatalogs loads every catalog file and returns an index of the
+// catalogs that loaded.
+func (v *validator) validateCatalogs(catalogPaths []string) mapper.ScopeIndex {
+	scope := make(mapper.Scope)
+	if len(catalogPaths) == 0 {
+		v.errorf(v.configPath, "no catalogs configured")
+	}
+
+	loadedFrom := make(map[string]string)
+	loadedFiles := make(map[string]bool)
+	for _, catalogPath := range catalogPaths {
+		files, err := resolveCatalogFiles(catalogPath)
+		if err != nil {
+			v.errorf(catalogPath, "%v", err)
+			continue
+		}
+
+		for _, file := range files {
+			if loadedFiles[file] {
+				continue
+			}
+			loadedFiles[file] = true
+			content, ok := v.readFile(file)
+			if !ok {
+				continue
+			}
+			var catalog layer2.Catalog
+			if err := yaml.Unmarshal(content, &catalog); err != nil {
+				v.errorf(file, "cannot parse catalog: %v", err)
+				continue
+			}
+
+			catalogID := catalog.Metadata.Id
+			if catalogID == "" {
+				v.errorf(file, "catalog has no metadata.id")
+				continue
+			}
+			if previous, ok := loadedFrom[catalogID]; ok {
+				v.errorf(file, "duplicate catalog id %s, also in %s", catalogID, previous)
+				continue
+			}
+			loadedFrom[catalogID] = file
+			scope[catalogID] = catalog
+		}
+	}
+	return mapper.NewScopeIndex(scope)
+}
+
+// procedureRef records where a procedure ID was first mapped.
+type procedureRef struct {
+	path        string
+	requirement string
+}
+
+func (v *validator) validatePlugin(i int, pluginConf PluginConfig, index mapper.ScopeIndex) {
+	if pluginConf.Id == "" {
+		v.errorf(v.configPath, "plugins[%d]: id is required", i)
+		return
+	}
+
+	mpr, err := factory.NewMapper(mapper.ID(pluginConf.Id), mapper.Type(pluginConf.Type), pluginConf.Config)
+	if err != nil {
+		v.errorf(v.configPath, "plugin %s: %v", pluginConf.Id, err)
+	} else {
+		if closer, ok := mpr.(io.Closer); ok {
+			defer func() { _ = closer.Close() }()
+		}
+		if _, ok := mpr.(mapper.StatusMapper); pluginConf.StatusMapping != nil && !ok {
+			v.errorf(v.configPath, "plugin %s does not support status-mapping", pluginConf.Id)
+		}
+		if _, ok := mpr.(mapper.PrecedenceMapper); len(pluginConf.CatalogPrecedence) > 0 && !ok {
+			v.errorf(v.configPath, "plugin %s does not support catalog-precedence", pluginConf.Id)
+		}
+	}
+
+	if pluginConf.StatusMapping != nil {
+		if err := pluginConf.StatusMapping.Validate(); err != nil {
+			v.errorf(v.configPath, "status-mapping for plugin %s: %v", pluginConf.Id, err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, catalogID := range pluginConf.CatalogPrecedence {
+		if seen[catalogID] {
+			v.errorf(v.configPath, "catalog-precedence for plugin %s lists %s more than once", pluginConf.Id, catalogID)
+		} else if _, ok := index[catalogID]; !ok {
+			v.warnf(v.configPath, "catalog-precedence for plugin %s lists %s, which is not loaded", pluginConf.Id, catalogID)
+		}
+		seen[catalogID] = true
+	}
+
+	if pluginConf.EvaluationsDir == "" {
+		v.warnf(v.configPath, "plugin %s has no evaluations-dir", pluginConf.Id)
+		return
+	}
+	v.validateEvaluations(pluginConf.Id, pluginConf.EvaluationsDir, index)
+}
+
+// validateEvaluations checks each evaluation plan under evaluationsDir
+// against the loaded catalogs.
+func (v *validator) validateEvaluations(pluginID, evaluationsDir string, index mapper.ScopeIndex) {
+	info, err := os.Stat(evaluationsDir)
+	if err != nil {
+		v.errorf(evaluationsDir, "evaluations directory for plugin %s: %v", pluginID, err)
+		return
+	}
+	if !info.IsDir() {
+		v.errorf(evaluationsDir, "evaluations directory for plugin %s is not a directory", pluginID)
+		return
+	}
+
+	// procedures holds the first mapping of each procedure ID, by catalog.
+	procedures := make(map[string]map[string]procedureRef)
+	var planCount int
+	err = filepath.Walk(evaluationsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			v.errorf(path, "%v", err)
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		content, ok := v.readFile(path)
+		if !ok {
+			return nil
+		}
+		var evaluation layer4.EvaluationPlan
+		if err := yaml.Unmarshal(content, &evaluation); err != nil {
+			v.errorf(path, "cannot parse evaluation plan: %v", err)
+			return nil
+		}
+		if len(evaluation.Plans) == 0 {
+			v.errorf(path, "file has no plans")
+			return nil
+		}
+
+		for j, plan := range evaluation.Plans {
+			catalogID := plan.Control.ReferenceId
+			if catalogID == "" {
+				v.errorf(path, "plans[%d]: control.reference-id is empty, so the plan is skipped", j)
+				continue
+			}
+			planCount++
+			catalogIndex, ok := index[catalogID]
+			if !ok {
+				v.errorf(path, "plans[%d]: catalog %s is not loaded", j, catalogID)
+				continue
+			}
+			if _, ok := catalogIndex.Control(plan.Control.EntryId); !ok {
+				v.errorf(path, "plans[%d]: control %s is not in catalog %s", j, plan.Control.EntryId, catalogID)
+			}
+
+			if procedures[catalogID] == nil {
+				procedures[catalogID] = make(map[string]procedureRef)
+			}
+			for k, assessment := range plan.Assessments {
+				requirementID := assessment.Requirement.EntryId
+				requirement, ok := catalogIndex.Requirement(requirementID)
+				switch {
+				case !ok:
+					v.errorf(path, "plans[%d].assessments[%d]: requirement %s is not in catalog %s", j, k, requirementID, catalogID)
+				case requirement.Control.Id != plan.Control.EntryId:
+					v.errorf(path, "plans[%d].assessments[%d]: requirement %s belongs to control %s, not %s", j, k, requirementID, requirement.Control.Id, plan.Control.EntryId)
+				}
+
+				for _, procedure := range assessment.Procedures {
+					if procedure.Id == "" {
+						v.errorf(path, "plans[%d].assessments[%d]: procedure has no id", j, k)
+						continue
+					}
+					if first, ok := procedures[catalogID][procedure.Id]; ok {
+						v.errorf(path, "duplicate procedure %s in catalog %s for plugin %s, already mapped to %s in %s",
+							procedure.Id, catalogID, pluginID, first.requirement, first.path)
+						continue
+					}
+					procedures[catalogID][procedure.Id] = procedureRef{path: path, requirement: requirementID}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		v.errorf(evaluationsDir, "%v", err)
+		return
+	}
+	if planCount == 0 {
+		v.errorf(evaluationsDir, "plugin %s has no evaluation plans", pluginID)
+	}
+}
+
+// readFile reads path, reporting it if it cannot be read or is empty.
+func (v *validator) readFile(path string) ([]byte, bool) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		v.errorf(path, "%v", err)
+		return nil, false
+	}
+	if len(bytes.TrimSpace(content)) == 0 {
+		v.errorf(path, "file is empty")
+		return nil, false
+	}
+	return content, true
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+const validateCatalog = `metadata:
+  id: CIS
+  title: CIS
+control-families:
+  - id: IAM
+    title: Identity
+    controls:
+      - id: CIS-1
+        assessment-requirements:
+          - id: CIS-1.01
+      - id: CIS-2
+        assessment-requirements:
+          - id: CIS-2.01
+`
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath := filepath.Join(dir, "catalogs", "cis.yaml")
+	writeFile(t, catalogPath, validateCatalog)
+	writePlan(t, filepath.Join(dir, "valid", "plan.yaml"), "CIS", "CIS-1", "mfa")
+
+	t.Run("valid", func(t *testing.T) {
+		cfg := &Config{Plugins: []PluginConfig{{Id: "github", EvaluationsDir: filepath.Join(dir, "valid")}}}
+		assert.Empty(t, Validate("config.yaml", cfg, catalogPath))
+	})
+
+	t.Run("no evaluations", func(t *testing.T) {
+		cfg := &Config{Plugins: []PluginConfig{{Id: "github"}}}
+		problems := Validate("config.yaml", cfg, catalogPath)
+		assert.Equal(t, []Problem{{Path: "config.yaml", Message: "plugin github has no evaluations-dir", Warning: true}}, problems)
+		assert.False(t, HasErrors(problems))
+	})
+
+	evaluationsDir := filepath.Join(dir, "invalid")
+	writeFile(t, filepath.Join(evaluationsDir, "empty.yaml"), "\n")
+	writeFile(t, filepath.Join(evaluationsDir, "garbage.yaml"), "plans: [")
+	writeFile(t, filepath.Join(evaluationsDir, "plans.yaml"), `plans:
+  - control:
+      entry-id: CIS-1
+  - control:
+      reference-id: OSPS-B
+      entry-id: OSPS-AC-01
+  - control:
+      reference-id: CIS
+      entry-id: CIS-9
+    assessments:
+      - requirement:
+          reference-id: CIS
+          entry-id: CIS-9.01
+  - control:
+      reference-id: CIS
+      entry-id: CIS-2
+    assessments:
+      - requirement:
+          reference-id: CIS
+          entry-id: CIS-1.01
+        procedures:
+          - id: mfa
+      - requirement:
+          reference-id: CIS
+          entry-id: CIS-2.01
+        procedures:
+          - id: mfa
+`)
+	emptyCatalog := filepath.Join(dir, "catalogs", "empty.yaml")
+	writeFile(t, emptyCatalog, "")
+	duplicateCatalog := filepath.Join(dir, "duplicate.yaml")
+	writeFile(t, duplicateCatalog, validateCatalog)
+
+	cfg := &Config{Plugins: []PluginConfig{
+		{Id: "github", EvaluationsDir: evaluationsDir, CatalogPrecedence: []string{"CIS", "CIS"}},
+		{Id: "github"},
+		{Id: "scanner", Type: "unknown"},
+		{Id: "empty", EvaluationsDir: filepath.Join(dir, "catalogs", "none")},
+		{Id: "status", StatusMapping: &mapper.StatusMapping{Overrides: []mapper.StatusOverride{{Catalog: "CIS"}}}},
+	}}
+	problems := Validate("config.yaml", cfg, filepath.Join(dir, "catalogs"), duplicateCatalog)
+	assert.True(t, HasErrors(problems))
+
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.String())
+	}
+	plans := filepath.Join(evaluationsDir, "plans.yaml")
+	for _, want := range []string{
+		emptyCatalog + ": file is empty",
+		duplicateCatalog + ": duplicate catalog id CIS, also in " + catalogPath,
+		"config.yaml: catalog-precedence for plugin github lists CIS more than once",
+		filepath.Join(evaluationsDir, "empty.yaml") + ": file is empty",
+		filepath.Join(evaluationsDir, "garbage.yaml") + ": cannot parse evaluation plan",
+		plans + ": plans[0]: control.reference-id is empty, so the plan is skipped",
+		plans + ": plans[1]: catalog OSPS-B is not loaded",
+		plans + ": plans[2]: control CIS-9 is not in catalog CIS",
+		plans + ": plans[2].assessments[0]: requirement CIS-9.01 is not in catalog CIS",
+		plans + ": plans[3].assessments[0]: requirement CIS-1.01 belongs to control CIS-1, not CIS-2",
+		plans + ": duplicate procedure mfa in catalog CIS for plugin github, already mapped to CIS-1.01 in " + plans,
+		"config.yaml: plugin github is configured more than once",
+		"config.yaml: plugin scanner: unknown plugin type \"unknown\"",
+		filepath.Join(dir, "catalogs", "none") + ": evaluations directory for plugin empty",
+		"config.yaml: status-mapping for plugin status: overrides[0]: results are required",
+		"config.yaml: warning: plugin status has no evaluations-dir",
+	} {
+		assert.True(t, containsPrefix(messages, want), "missing problem %q in\n%v", want, messages)
+	}
+}
+
+func containsPrefix(messages []string, prefix string) bool {
+	for _, message := range messages {
+		if strings.HasPrefix(message, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateFields(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	writeFile(t, valid, "plugins:\n  - id: github\n    evaluations-dir: ./evaluations\n")
+	assert.Empty(t, ValidateFields(valid))
+
+	misspelled := filepath.Join(dir, "misspelled.yaml")
+	writeFile(t, misspelled, "plugins:\n  - id: github\n    evaluation-dir: ./evaluations\n")
+	problems := ValidateFields(misspelled)
+	assert.Equal(t, []Problem{{Path: misspelled, Message: `[3:5] unknown field "evaluation-dir"`, Warning: true}}, problems)
+	assert.False(t, HasErrors(problems))
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/complytime/complybeacon/compass/cmd/compass/server"
+)
+
+// runValidate implements "compass validate". It loads the config, catalogs,
+// and evaluation plans as the server would, prints every problem found, and
+// returns a non-zero exit code if any of them is an error, or a warning when
+// -strict is set.
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	var (
+		configPath   string
+		catalogPaths stringSliceFlag
+		strict       bool
+	)
+	flags := flag.NewFlagSet("compass validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Var(&catalogPaths, "catalog", "Path to a Layer 2 catalog file, directory, or glob pattern; may be repeated (default \""+defaultCatalogPath+"\")")
+	flags.StringVar(&configPath, "config", "./docs/config.yaml", "Path to compass config file")
+	flags.BoolVar(&strict, "strict", false, "Fail on warnings as well as errors")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	// Plugins and catalogs log as they load; only the problems are of
+	// interest here.
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	configPath = filepath.Clean(configPath)
+	cfg, err := readConfig(configPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stdout, "%s: %v\n", configPath, err)
+		return 1
+	}
+
+	problems := server.ValidateFields(configPath)
+	problems = append(problems, server.Validate(configPath, &cfg, configCatalogPaths(catalogPaths, cfg)...)...)
+	for _, problem := range problems {
+		_, _ = fmt.Fprintln(stdout, problem)
+	}
+	if server.HasErrors(problems) || (strict && len(problems) > 0) {
+		_, _ = fmt.Fprintf(stderr, "%s is invalid: %d problem(s) found\n", configPath, len(problems))
+		return 1
+	}
+	_, _ = fmt.Fprintf(stderr, "%s is valid\n", configPath)
+	return 0
+}
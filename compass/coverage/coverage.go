@@ -0,0 +1,145 @@
+// Package coverage reports which controls of the loaded catalogs are assessed
+// by the procedures in the evaluation plans of the configured plugins.
+package coverage
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// controlKey identifies a control within the loaded catalogs.
+type controlKey struct{ catalogID, controlID string }
+
+// Report computes the coverage of the catalogs in scope by the procedures of
+// every plugin in set. A control is covered when a procedure maps to it or to
+// one of its assessment requirements. Plugins that do not implement
+// mapper.ProcedureLister are listed as excluded.
+func Report(set mapper.Set, scope mapper.Scope) api.CoverageReport {
+	report := api.CoverageReport{
+		Catalogs:             []api.CatalogCoverage{},
+		UnresolvedProcedures: []api.UnresolvedProcedure{},
+		ExcludedPlugins:      []string{},
+	}
+	index := mapper.NewScopeIndex(scope)
+
+	policyRules := make(map[controlKey][]api.PolicyRuleReference)
+	coveredRequirements := make(map[controlKey]bool)
+	for _, pluginID := range sortedPluginIDs(set) {
+		lister, ok := set[mapper.ID(pluginID)].(mapper.ProcedureLister)
+		if !ok {
+			report.ExcludedPlugins = append(report.ExcludedPlugins, pluginID)
+			continue
+		}
+
+		for _, procedure := range lister.Procedures() {
+			ref := api.PolicyRuleReference{
+				PluginId:      pluginID,
+				PolicyRuleId:  procedure.PolicyRuleID,
+				CatalogId:     procedure.CatalogID,
+				ControlId:     procedure.ControlID,
+				RequirementId: procedure.RequirementID,
+			}
+			controlID, reason := resolve(index, procedure)
+			if reason != "" {
+				report.UnresolvedProcedures = append(report.UnresolvedProcedures, api.UnresolvedProcedure{
+					Procedure: ref,
+					Reason:    reason,
+				})
+				continue
+			}
+			key := controlKey{procedure.CatalogID, controlID}
+			policyRules[key] = append(policyRules[key], ref)
+			if procedure.RequirementID != "" {
+				coveredRequirements[controlKey{procedure.CatalogID, procedure.RequirementID}] = true
+			}
+		}
+	}
+
+	catalogIDs := make([]string, 0, len(scope))
+	for catalogID := range scope {
+		catalogIDs = append(catalogIDs, catalogID)
+	}
+	sort.Strings(catalogIDs)
+
+	for _, catalogID := range catalogIDs {
+		catalog := scope[catalogID]
+		catalogCoverage := api.CatalogCoverage{
+			CatalogId: catalogID,
+			Title:     catalog.Metadata.Title,
+			Families:  []api.FamilyCoverage{},
+		}
+		for _, family := range catalog.ControlFamilies {
+			familyCoverage := api.FamilyCoverage{
+				Id:       family.Id,
+				Title:    family.Title,
+				Controls: []api.ControlCoverage{},
+			}
+			for _, control := range family.Controls {
+				rules := policyRules[controlKey{catalogID, control.Id}]
+				if rules == nil {
+					rules = []api.PolicyRuleReference{}
+				}
+				controlCoverage := api.ControlCoverage{
+					ControlId:             control.Id,
+					Title:                 control.Title,
+					Covered:               len(rules) > 0,
+					UncoveredRequirements: []string{},
+					PolicyRules:           rules,
+				}
+				for _, requirement := range control.AssessmentRequirements {
+					if !coveredRequirements[controlKey{catalogID, requirement.Id}] {
+						controlCoverage.UncoveredRequirements = append(controlCoverage.UncoveredRequirements, requirement.Id)
+					}
+				}
+
+				familyCoverage.ControlCount++
+				if controlCoverage.Covered {
+					familyCoverage.CoveredControlCount++
+				}
+				familyCoverage.Controls = append(familyCoverage.Controls, controlCoverage)
+			}
+			catalogCoverage.ControlCount += familyCoverage.ControlCount
+			catalogCoverage.CoveredControlCount += familyCoverage.CoveredControlCount
+			catalogCoverage.Families = append(catalogCoverage.Families, familyCoverage)
+		}
+		report.Catalogs = append(report.Catalogs, catalogCoverage)
+	}
+	return report
+}
+
+// resolve returns the ID of the control that procedure covers, or the reason
+// it cannot be resolved against the loaded catalogs.
+func resolve(index mapper.ScopeIndex, procedure mapper.Procedure) (controlID, reason string) {
+	catalogIndex, ok := index[procedure.CatalogID]
+	if !ok {
+		return "", fmt.Sprintf("catalog %s is not loaded", procedure.CatalogID)
+	}
+
+	if procedure.RequirementID == "" {
+		if _, ok := catalogIndex.Control(procedure.ControlID); !ok {
+			return "", fmt.Sprintf("control %s is not in catalog %s", procedure.ControlID, procedure.CatalogID)
+		}
+		return procedure.ControlID, ""
+	}
+
+	requirement, ok := catalogIndex.Requirement(procedure.RequirementID)
+	if !ok {
+		return "", fmt.Sprintf("requirement %s is not in catalog %s", procedure.RequirementID, procedure.CatalogID)
+	}
+	if procedure.ControlID != "" && requirement.Control.Id != procedure.ControlID {
+		return "", fmt.Sprintf("requirement %s belongs to control %s, not %s", procedure.RequirementID, requirement.Control.Id, procedure.ControlID)
+	}
+	return requirement.Control.Id, ""
+}
+
+func sortedPluginIDs(set mapper.Set) []string {
+	pluginIDs := make([]string, 0, len(set))
+	for pluginID := range set {
+		pluginIDs = append(pluginIDs, string(pluginID))
+	}
+	sort.Strings(pluginIDs)
+	return pluginIDs
+}
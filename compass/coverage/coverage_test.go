@@ -0,0 +1,160 @@
+package coverage
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ossf/gemara/layer2"
+	"github.com/ossf/gemara/layer4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/internal/mappertest"
+	"github.com/complytime/complybeacon/compass/mapper"
+	"github.com/complytime/complybeacon/compass/mapper/plugins/basic"
+)
+
+// opaqueMapper is a mapper that cannot list its procedures.
+type opaqueMapper struct{}
+
+func (opaqueMapper) PluginName() mapper.ID { return "opaque" }
+
+func (opaqueMapper) Map(api.Evidence, mapper.Scope) api.Compliance { return api.Compliance{} }
+
+func (opaqueMapper) AddEvaluationPlan(string, ...layer4.AssessmentPlan) {}
+
+// testScope returns the CIS catalog with an identity and a logging family.
+// Beyond the mappertest catalog, its controls have titles, CIS-1 has a second
+// requirement, and CIS-3 has none.
+func testScope() mapper.Scope {
+	scope := mappertest.Scope("CIS", "CIS-1", "CIS-2", "CIS-3")
+	catalog := scope["CIS"]
+	catalog.Metadata.Title = "CIS Benchmark"
+	controls := catalog.ControlFamilies[0].Controls
+	controls[0].Title = "MFA"
+	controls[0].AssessmentRequirements = append(controls[0].AssessmentRequirements, layer2.AssessmentRequirement{Id: "CIS-1.02"})
+	controls[1].Title = "Key | rotation"
+	controls[2].Title = "Audit logs"
+	controls[2].AssessmentRequirements = nil
+	catalog.ControlFamilies = []layer2.ControlFamily{
+		{Id: "IAM", Title: "Identity", Controls: controls[:2]},
+		{Id: "LOG", Title: "Logging", Controls: controls[2:]},
+	}
+	scope["CIS"] = catalog
+	return scope
+}
+
+func TestReport(t *testing.T) {
+	github := basic.NewBasicMapper()
+	github.AddEvaluationPlan("CIS",
+		mappertest.Plan("CIS", "CIS-1", "mfa"),
+		mappertest.RequirementPlan("CIS", "CIS-3", "", "audit"),
+		mappertest.RequirementPlan("CIS", "CIS-9", "", "missing_control"),
+		mappertest.RequirementPlan("CIS", "CIS-2", "CIS-1.02", "wrong_control"),
+		mappertest.RequirementPlan("CIS", "CIS-2", "CIS-2.09", "missing_requirement"),
+	)
+	github.AddEvaluationPlan("OSPS-B", mappertest.Plan("OSPS-B", "OSPS-AC-01", "mfa"))
+	scanner := basic.NewBasicMapper()
+	scanner.AddEvaluationPlan("CIS", mappertest.Plan("CIS", "CIS-1", "sso"))
+
+	report := Report(mapper.Set{"github": github, "scanner": scanner, "opaque": opaqueMapper{}}, testScope())
+
+	require.Len(t, report.Catalogs, 1)
+	catalog := report.Catalogs[0]
+	assert.Equal(t, "CIS Benchmark", catalog.Title)
+	assert.Equal(t, 3, catalog.ControlCount)
+	assert.Equal(t, 2, catalog.CoveredControlCount)
+
+	require.Len(t, catalog.Families, 2)
+	identity := catalog.Families[0]
+	assert.Equal(t, 2, identity.ControlCount)
+	assert.Equal(t, 1, identity.CoveredControlCount)
+	assert.Equal(t, api.ControlCoverage{
+		ControlId:             "CIS-1",
+		Title:                 "MFA",
+		Covered:               true,
+		UncoveredRequirements: []string{"CIS-1.02"},
+		PolicyRules: []api.PolicyRuleReference{
+			{PluginId: "github", PolicyRuleId: "mfa", CatalogId: "CIS", ControlId: "CIS-1", RequirementId: "CIS-1.01"},
+			{PluginId: "scanner", PolicyRuleId: "sso", CatalogId: "CIS", ControlId: "CIS-1", RequirementId: "CIS-1.01"},
+		},
+	}, identity.Controls[0])
+	assert.False(t, identity.Controls[1].Covered)
+	assert.Equal(t, []string{"CIS-2.01"}, identity.Controls[1].UncoveredRequirements)
+	assert.Empty(t, identity.Controls[1].PolicyRules)
+
+	logging := catalog.Families[1]
+	assert.Equal(t, 1, logging.CoveredControlCount)
+	assert.True(t, logging.Controls[0].Covered)
+
+	reasons := make(map[string]string)
+	for _, unresolved := range report.UnresolvedProcedures {
+		reasons[unresolved.Procedure.PolicyRuleId] = unresolved.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"missing_control":     "control CIS-9 is not in catalog CIS",
+		"wrong_control":       "requirement CIS-1.02 belongs to control CIS-1, not CIS-2",
+		"missing_requirement": "requirement CIS-2.09 is not in catalog CIS",
+		"mfa":                 "catalog OSPS-B is not loaded",
+	}, reasons)
+	assert.Equal(t, []string{"opaque"}, report.ExcludedPlugins)
+}
+
+func TestReportEmpty(t *testing.T) {
+	report := Report(mapper.Set{}, mapper.Scope{})
+	assert.Empty(t, report.Catalogs)
+	assert.NotNil(t, report.Catalogs)
+	assert.NotNil(t, report.UnresolvedProcedures)
+	assert.NotNil(t, report.ExcludedPlugins)
+
+	var markdown bytes.Buffer
+	require.NoError(t, WriteMarkdown(&markdown, report))
+	assert.Equal(t, "# Control Coverage\n\nNo catalogs are loaded.\n", markdown.String())
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	github := basic.NewBasicMapper()
+	github.AddEvaluationPlan("CIS", mappertest.Plan("CIS", "CIS-1", "mfa"))
+	github.AddEvaluationPlan("OSPS-B", mappertest.Plan("OSPS-B", "OSPS-AC-01", "mfa"))
+	report := Report(mapper.Set{"github": github, "opaque": opaqueMapper{}}, testScope())
+
+	var markdown bytes.Buffer
+	require.NoError(t, WriteMarkdown(&markdown, report))
+	assert.Equal(t, `# Control Coverage
+
+## CIS: CIS Benchmark
+
+1 of 3 controls covered (33%).
+
+| Family | Controls | Covered | Coverage |
+|--------|----------|---------|----------|
+| Identity (IAM) | 2 | 1 | 50% |
+| Logging (LOG) | 1 | 0 | 0% |
+
+### Identity (IAM)
+
+| Control | Title | Policy Rules | Uncovered Requirements |
+|---------|-------|--------------|------------------------|
+| CIS-1 | MFA | github/mfa | CIS-1.02 |
+| CIS-2 | Key \| rotation | - | CIS-2.01 |
+
+### Logging (LOG)
+
+| Control | Title | Policy Rules | Uncovered Requirements |
+|---------|-------|--------------|------------------------|
+| CIS-3 | Audit logs | - | - |
+
+## Unresolved Procedures
+
+| Plugin | Policy Rule | Catalog | Control | Requirement | Reason |
+|--------|-------------|---------|---------|-------------|--------|
+| github | mfa | OSPS-B | OSPS-AC-01 | OSPS-AC-01.01 | catalog OSPS-B is not loaded |
+
+## Excluded Plugins
+
+These plugins cannot list their procedures, so their mappings are not included:
+
+- opaque
+`, markdown.String())
+}
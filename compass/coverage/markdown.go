@@ -0,0 +1,103 @@
+package coverage
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/complytime/complybeacon/compass/api"
+)
+
+// WriteMarkdown writes report to w as a Markdown document with a summary
+// table per catalog, a table of controls per family, and the unresolved
+// procedures and excluded plugins.
+func WriteMarkdown(w io.Writer, report api.CoverageReport) error {
+	b := bufio.NewWriter(w)
+	p := func(format string, args ...any) {
+		_, _ = fmt.Fprintf(b, format, args...)
+	}
+
+	p("# Control Coverage\n")
+	if len(report.Catalogs) == 0 {
+		p("\nNo catalogs are loaded.\n")
+	}
+
+	for _, catalog := range report.Catalogs {
+		p("\n## %s: %s\n\n", cell(catalog.CatalogId), cell(catalog.Title))
+		p("%d of %d controls covered (%s).\n\n", catalog.CoveredControlCount, catalog.ControlCount, percent(catalog.CoveredControlCount, catalog.ControlCount))
+		p("| Family | Controls | Covered | Coverage |\n")
+		p("|--------|----------|---------|----------|\n")
+		for _, family := range catalog.Families {
+			p("| %s | %d | %d | %s |\n", cell(familyName(family)), family.ControlCount, family.CoveredControlCount, percent(family.CoveredControlCount, family.ControlCount))
+		}
+
+		for _, family := range catalog.Families {
+			if len(family.Controls) == 0 {
+				continue
+			}
+			p("\n### %s\n\n", cell(familyName(family)))
+			p("| Control | Title | Policy Rules | Uncovered Requirements |\n")
+			p("|---------|-------|--------------|------------------------|\n")
+			for _, control := range family.Controls {
+				rules := make([]string, 0, len(control.PolicyRules))
+				for _, rule := range control.PolicyRules {
+					rules = append(rules, rule.PluginId+"/"+rule.PolicyRuleId)
+				}
+				p("| %s | %s | %s | %s |\n", cell(control.ControlId), cell(control.Title), list(rules), list(control.UncoveredRequirements))
+			}
+		}
+	}
+
+	if len(report.UnresolvedProcedures) > 0 {
+		p("\n## Unresolved Procedures\n\n")
+		p("| Plugin | Policy Rule | Catalog | Control | Requirement | Reason |\n")
+		p("|--------|-------------|---------|---------|-------------|--------|\n")
+		for _, unresolved := range report.UnresolvedProcedures {
+			procedure := unresolved.Procedure
+			p("| %s | %s | %s | %s | %s | %s |\n", cell(procedure.PluginId), cell(procedure.PolicyRuleId),
+				cell(procedure.CatalogId), cell(procedure.ControlId), cell(procedure.RequirementId), cell(unresolved.Reason))
+		}
+	}
+
+	if len(report.ExcludedPlugins) > 0 {
+		p("\n## Excluded Plugins\n\nThese plugins cannot list their procedures, so their mappings are not included:\n\n")
+		for _, pluginID := range report.ExcludedPlugins {
+			p("- %s\n", pluginID)
+		}
+	}
+	return b.Flush()
+}
+
+func familyName(family api.FamilyCoverage) string {
+	switch {
+	case family.Title == "":
+		return family.Id
+	case family.Id == "":
+		return family.Title
+	default:
+		return family.Title + " (" + family.Id + ")"
+	}
+}
+
+// percent formats covered as a whole percentage of total.
+func percent(covered, total int) string {
+	if total == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%d%%", covered*100/total)
+}
+
+// list formats values for a table cell, or a dash when there are none.
+func list(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return cell(strings.Join(values, ", "))
+}
+
+// cell escapes value for use in a Markdown table cell.
+func cell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.Join(strings.Fields(value), " ")
+}
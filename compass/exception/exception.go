@@ -0,0 +1,175 @@
+// Package exception holds approved compliance exceptions (waivers) and
+// matches them against enriched findings.
+package exception
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// dateLayout is accepted for expiry dates without a time. Such exceptions
+// stay active through the end of that day in UTC.
+const dateLayout = "2006-01-02"
+
+// Exception is an approved waiver for findings that match its scope. Empty
+// scope fields match any value, but at least one must be set.
+type Exception struct {
+	ID string `json:"id"`
+	// Catalog limits the exception to controls from one catalog.
+	Catalog string `json:"catalog"`
+	// Control is a control ID or assessment requirement ID.
+	Control string `json:"control"`
+	// PolicyRule is the policy rule ID reported by the policy engine.
+	PolicyRule string `json:"policy-rule"`
+	// Target is the ID of the evaluated resource.
+	Target        string `json:"target"`
+	Justification string `json:"justification"`
+	Approver      string `json:"approver"`
+	// Expires is an RFC 3339 timestamp or a YYYY-MM-DD date.
+	Expires string `json:"expires"`
+
+	expiresAt time.Time
+}
+
+// ExpiresAt returns the time the exception stops applying.
+func (e Exception) ExpiresAt() time.Time {
+	return e.expiresAt
+}
+
+// Subject describes the finding an exception is matched against.
+type Subject struct {
+	CatalogID     string
+	ControlID     string
+	RequirementID string
+	PolicyRuleID  string
+	TargetID      string
+}
+
+// File is the on-disk format of an exceptions file.
+type File struct {
+	Exceptions []Exception `json:"exceptions"`
+}
+
+// Registry is an immutable set of validated exceptions.
+type Registry struct {
+	exceptions []Exception
+	now        func() time.Time
+}
+
+// Load reads and validates an exceptions file.
+func Load(path string) (*Registry, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var file File
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("parsing exceptions file %s: %w", path, err)
+	}
+
+	registry, err := NewRegistry(file.Exceptions...)
+	if err != nil {
+		return nil, fmt.Errorf("exceptions file %s: %w", path, err)
+	}
+	return registry, nil
+}
+
+// NewRegistry validates exceptions and returns a Registry holding them.
+func NewRegistry(exceptions ...Exception) (*Registry, error) {
+	registry := &Registry{now: time.Now}
+	seen := make(map[string]bool)
+	for _, exception := range exceptions {
+		if err := exception.parse(); err != nil {
+			return nil, err
+		}
+		if seen[exception.ID] {
+			return nil, fmt.Errorf("duplicate exception id %s", exception.ID)
+		}
+		seen[exception.ID] = true
+		registry.exceptions = append(registry.exceptions, exception)
+	}
+	return registry, nil
+}
+
+// Len returns the number of exceptions in the registry.
+func (r *Registry) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.exceptions)
+}
+
+// Match returns the exception that applies to subject. An active exception
+// is preferred; otherwise the most recently expired match is returned with
+// active set to false. found is false when no exception matches.
+func (r *Registry) Match(subject Subject) (match Exception, active bool, found bool) {
+	if r == nil {
+		return Exception{}, false, false
+	}
+
+	now := r.now()
+	for _, exception := range r.exceptions {
+		if !exception.matches(subject) {
+			continue
+		}
+		if now.Before(exception.expiresAt) {
+			return exception, true, true
+		}
+		if !found || exception.expiresAt.After(match.expiresAt) {
+			match, found = exception, true
+		}
+	}
+	return match, false, found
+}
+
+func (e Exception) matches(subject Subject) bool {
+	if e.Catalog != "" && e.Catalog != subject.CatalogID {
+		return false
+	}
+	if e.Control != "" && e.Control != subject.ControlID && e.Control != subject.RequirementID {
+		return false
+	}
+	if e.PolicyRule != "" && e.PolicyRule != subject.PolicyRuleID {
+		return false
+	}
+	if e.Target != "" && e.Target != subject.TargetID {
+		return false
+	}
+	return true
+}
+
+// parse validates the exception and resolves its expiry time.
+func (e *Exception) parse() error {
+	if e.ID == "" {
+		return errors.New("exception is missing id")
+	}
+	if e.Control == "" && e.PolicyRule == "" && e.Target == "" {
+		return fmt.Errorf("exception %s must be scoped by control, policy-rule, or target", e.ID)
+	}
+	if e.Justification == "" {
+		return fmt.Errorf("exception %s is missing justification", e.ID)
+	}
+	if e.Approver == "" {
+		return fmt.Errorf("exception %s is missing approver", e.ID)
+	}
+	if e.Expires == "" {
+		return fmt.Errorf("exception %s is missing expires", e.ID)
+	}
+
+	if expiresAt, err := time.Parse(time.RFC3339, e.Expires); err == nil {
+		e.expiresAt = expiresAt
+		return nil
+	}
+	expiresOn, err := time.Parse(dateLayout, e.Expires)
+	if err != nil {
+		return fmt.Errorf("exception %s has invalid expires %q: use RFC 3339 or YYYY-MM-DD", e.ID, e.Expires)
+	}
+	e.expiresAt = expiresOn.AddDate(0, 0, 1)
+	return nil
+}
@@ -0,0 +1,139 @@
+package exception
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	registry, err := Load("testdata/exceptions.yaml")
+	require.NoError(t, err)
+	require.Equal(t, 2, registry.Len())
+
+	assert.Equal(t, time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC), registry.exceptions[0].ExpiresAt())
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), registry.exceptions[1].ExpiresAt())
+}
+
+func TestNewRegistryValidation(t *testing.T) {
+	valid := Exception{
+		ID:            "EX-1",
+		Control:       "AC-1",
+		Justification: "accepted risk",
+		Approver:      "ciso",
+		Expires:       "2030-01-01",
+	}
+
+	tests := []struct {
+		name     string
+		mutate   func(*Exception)
+		errorMsg string
+	}{
+		{name: "missing id", mutate: func(e *Exception) { e.ID = "" }, errorMsg: "missing id"},
+		{name: "unscoped", mutate: func(e *Exception) { e.Control = "" }, errorMsg: "must be scoped"},
+		{name: "missing justification", mutate: func(e *Exception) { e.Justification = "" }, errorMsg: "missing justification"},
+		{name: "missing approver", mutate: func(e *Exception) { e.Approver = "" }, errorMsg: "missing approver"},
+		{name: "missing expiry", mutate: func(e *Exception) { e.Expires = "" }, errorMsg: "missing expires"},
+		{name: "invalid expiry", mutate: func(e *Exception) { e.Expires = "next year" }, errorMsg: "invalid expires"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exception := valid
+			tt.mutate(&exception)
+			_, err := NewRegistry(exception)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+
+	_, err := NewRegistry(valid, valid)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate exception id EX-1")
+}
+
+func TestRegistryMatch(t *testing.T) {
+	registry, err := NewRegistry(
+		Exception{
+			ID:            "EX-CONTROL",
+			Catalog:       "OSPS-B",
+			Control:       "OSPS-QA-07",
+			Target:        "repo-a",
+			Justification: "accepted risk",
+			Approver:      "ciso",
+			Expires:       "2030-01-01",
+		},
+		Exception{
+			ID:            "EX-EXPIRED-OLD",
+			PolicyRule:    "branch_protection",
+			Justification: "migration",
+			Approver:      "ciso",
+			Expires:       "2024-01-01",
+		},
+		Exception{
+			ID:            "EX-EXPIRED-NEW",
+			PolicyRule:    "branch_protection",
+			Justification: "migration extended",
+			Approver:      "ciso",
+			Expires:       "2025-01-01",
+		},
+	)
+	require.NoError(t, err)
+	registry.now = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name           string
+		subject        Subject
+		expectedID     string
+		expectedActive bool
+		expectedFound  bool
+	}{
+		{
+			name:           "active exception by control and target",
+			subject:        Subject{CatalogID: "OSPS-B", ControlID: "OSPS-QA-07", TargetID: "repo-a", PolicyRuleID: "other"},
+			expectedID:     "EX-CONTROL",
+			expectedActive: true,
+			expectedFound:  true,
+		},
+		{
+			name:           "requirement id matches control scope",
+			subject:        Subject{CatalogID: "OSPS-B", RequirementID: "OSPS-QA-07", TargetID: "repo-a"},
+			expectedID:     "EX-CONTROL",
+			expectedActive: true,
+			expectedFound:  true,
+		},
+		{
+			name:    "other target",
+			subject: Subject{CatalogID: "OSPS-B", ControlID: "OSPS-QA-07", TargetID: "repo-b"},
+		},
+		{
+			name:    "other catalog",
+			subject: Subject{CatalogID: "CIS", ControlID: "OSPS-QA-07", TargetID: "repo-a"},
+		},
+		{
+			name:           "most recently expired exception is reported inactive",
+			subject:        Subject{PolicyRuleID: "branch_protection"},
+			expectedID:     "EX-EXPIRED-NEW",
+			expectedActive: false,
+			expectedFound:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, active, found := registry.Match(tt.subject)
+			assert.Equal(t, tt.expectedFound, found)
+			assert.Equal(t, tt.expectedActive, active)
+			assert.Equal(t, tt.expectedID, match.ID)
+		})
+	}
+}
+
+func TestNilRegistry(t *testing.T) {
+	var registry *Registry
+	assert.Equal(t, 0, registry.Len())
+	_, _, found := registry.Match(Subject{ControlID: "AC-1"})
+	assert.False(t, found)
+}
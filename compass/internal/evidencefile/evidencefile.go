@@ -0,0 +1,202 @@
+// Package evidencefile reads evidence for enrichment from files, so that
+// Compass can map evidence without serving the API.
+package evidencefile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/compass/api"
+)
+
+// Log record attributes holding evidence, as read by the truthbeam
+// processor.
+const (
+	attrPolicyEngineName        = "policy.engine.name"
+	attrPolicyEvaluationResult  = "policy.evaluation.result"
+	attrPolicyRuleID            = "policy.rule.id"
+	attrPolicyTargetEnvironment = "policy.target.environment"
+	attrPolicyTargetID          = "policy.target.id"
+)
+
+// Record is one piece of evidence read from a file.
+type Record struct {
+	Evidence api.Evidence
+	// Mode is the enrichment mode requested with the evidence, if any.
+	Mode *api.EnrichmentMode
+	// Err is set when the record does not hold usable evidence, such as a
+	// log record missing a required attribute. Evidence then holds whatever
+	// could be read.
+	Err error
+}
+
+// Reader reads evidence from a stream of JSON values. Each value may be an
+// enrichment request, a batch enrichment request, an array of enrichment
+// requests, or an OTLP JSON logs export, so both single JSON documents and
+// newline-delimited JSON are accepted.
+type Reader struct {
+	decoder *json.Decoder
+	pending []Record
+}
+
+// NewReader returns a Reader that reads evidence from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{decoder: json.NewDecoder(bufio.NewReader(r))}
+}
+
+// Next returns the next record. It returns io.EOF when the stream is
+// exhausted, and any other error when the stream is not valid JSON or holds a
+// value of an unknown shape; the stream cannot be read further after that.
+func (r *Reader) Next() (Record, error) {
+	for len(r.pending) == 0 {
+		var value json.RawMessage
+		if err := r.decoder.Decode(&value); err != nil {
+			if errors.Is(err, io.EOF) {
+				return Record{}, io.EOF
+			}
+			return Record{}, fmt.Errorf("reading evidence: %w", err)
+		}
+		records, err := decode(value)
+		if err != nil {
+			return Record{}, fmt.Errorf("reading evidence at offset %d: %w", r.decoder.InputOffset(), err)
+		}
+		r.pending = records
+	}
+
+	record := r.pending[0]
+	r.pending = r.pending[1:]
+	return record, nil
+}
+
+// decode converts a single JSON value to records.
+func decode(value json.RawMessage) ([]Record, error) {
+	if bytes.HasPrefix(value, []byte("[")) {
+		var requests []api.EnrichmentRequest
+		if err := json.Unmarshal(value, &requests); err != nil {
+			return nil, err
+		}
+		records := make([]Record, len(requests))
+		for i, request := range requests {
+			records[i] = Record{Evidence: request.Evidence, Mode: request.Mode}
+		}
+		return records, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(value, &fields); err != nil {
+		return nil, errors.New("expected a JSON object or array")
+	}
+	if _, ok := fields["resourceLogs"]; ok {
+		return decodeLogs(value)
+	}
+	evidence, ok := fields["evidence"]
+	if !ok {
+		return nil, errors.New(`expected an object with "evidence" or "resourceLogs"`)
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(evidence), []byte("[")) {
+		var batch api.BatchEnrichmentRequest
+		if err := json.Unmarshal(value, &batch); err != nil {
+			return nil, err
+		}
+		records := make([]Record, len(batch.Evidence))
+		for i, evidence := range batch.Evidence {
+			records[i] = Record{Evidence: evidence, Mode: batch.Mode}
+		}
+		return records, nil
+	}
+
+	var request api.EnrichmentRequest
+	if err := json.Unmarshal(value, &request); err != nil {
+		return nil, err
+	}
+	return []Record{{Evidence: request.Evidence, Mode: request.Mode}}, nil
+}
+
+// decodeLogs converts each log record of an OTLP JSON logs export to a
+// record.
+func decodeLogs(value json.RawMessage) ([]Record, error) {
+	var unmarshaler plog.JSONUnmarshaler
+	logs, err := unmarshaler.UnmarshalLogs(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	resourceLogs := logs.ResourceLogs()
+	for i := 0; i < resourceLogs.Len(); i++ {
+		scopeLogs := resourceLogs.At(i).ScopeLogs()
+		for j := 0; j < scopeLogs.Len(); j++ {
+			logRecords := scopeLogs.At(j).LogRecords()
+			for k := 0; k < logRecords.Len(); k++ {
+				records = append(records, logRecordEvidence(logRecords.At(k)))
+			}
+		}
+	}
+	return records, nil
+}
+
+// logRecordEvidence reads evidence from the attributes of logRecord, and its
+// raw data from a body holding a JSON object.
+func logRecordEvidence(logRecord plog.LogRecord) Record {
+	attrs := logRecord.Attributes()
+	var record Record
+	var missingAttrs []string
+	str := func(key string) string {
+		value, ok := attrs.Get(key)
+		if !ok {
+			missingAttrs = append(missingAttrs, key)
+			return ""
+		}
+		return value.AsString()
+	}
+
+	record.Evidence.PolicyRuleId = str(attrPolicyRuleID)
+	record.Evidence.PolicyEngineName = str(attrPolicyEngineName)
+	record.Evidence.PolicyEvaluationStatus = api.EvidencePolicyEvaluationStatus(str(attrPolicyEvaluationResult))
+	if len(missingAttrs) > 0 {
+		record.Err = fmt.Errorf("log record is missing required attributes: %s", strings.Join(missingAttrs, ", "))
+	}
+
+	timestamp := logRecord.Timestamp()
+	if timestamp == 0 {
+		timestamp = logRecord.ObservedTimestamp()
+	}
+	record.Evidence.Timestamp = timestamp.AsTime()
+
+	if value, ok := attrs.Get(attrPolicyTargetEnvironment); ok && value.AsString() != "" {
+		environment := value.AsString()
+		record.Evidence.PolicyTargetEnvironment = &environment
+	}
+	if value, ok := attrs.Get(attrPolicyTargetID); ok && value.AsString() != "" {
+		targetID := value.AsString()
+		record.Evidence.PolicyTargetId = &targetID
+	}
+
+	if rawData := bodyData(logRecord.Body()); rawData != nil {
+		record.Evidence.RawData = &rawData
+	}
+	return record
+}
+
+// bodyData returns the body as a JSON object, if it holds one.
+func bodyData(body pcommon.Value) map[string]interface{} {
+	switch body.Type() {
+	case pcommon.ValueTypeMap:
+		return body.Map().AsRaw()
+	case pcommon.ValueTypeStr:
+		var rawData map[string]interface{}
+		if err := json.Unmarshal([]byte(body.Str()), &rawData); err == nil {
+			return rawData
+		}
+	}
+	return nil
+}
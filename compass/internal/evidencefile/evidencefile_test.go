@@ -0,0 +1,118 @@
+package evidencefile
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+)
+
+func readAll(t *testing.T, input string) ([]Record, error) {
+	t.Helper()
+	reader := NewReader(strings.NewReader(input))
+	var records []Record
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
+
+const otlpLogs = `{"resourceLogs":[{"resource":{},"scopeLogs":[{"logRecords":[
+  {
+    "timeUnixNano": "1757087791576000000",
+    "traceId": "5b8efff798038103d269b633813fc60c",
+    "body": {"stringValue": "{\"status\":\"failure\"}"},
+    "attributes": [
+      {"key": "policy.rule.id", "value": {"stringValue": "github_branch_protection"}},
+      {"key": "policy.engine.name", "value": {"stringValue": "conforma"}},
+      {"key": "policy.evaluation.result", "value": {"stringValue": "Failed"}},
+      {"key": "policy.target.id", "value": {"stringValue": "repo"}}
+    ]
+  },
+  {
+    "observedTimeUnixNano": "1757087791576000000",
+    "attributes": [
+      {"key": "policy.rule.id", "value": {"stringValue": "github_branch_protection"}}
+    ]
+  }
+]}]}]}`
+
+func TestReader(t *testing.T) {
+	t.Run("enrichment requests as NDJSON", func(t *testing.T) {
+		records, err := readAll(t, `{"evidence":{"policyEngineName":"opa","policyRuleId":"rule-1","policyEvaluationStatus":"Passed","timestamp":"2025-09-01T00:00:00Z"}}
+{"evidence":{"policyEngineName":"opa","policyRuleId":"rule-2","policyEvaluationStatus":"Failed","timestamp":"2025-09-01T00:00:00Z"},"mode":"all"}
+`)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "rule-1", records[0].Evidence.PolicyRuleId)
+		assert.Nil(t, records[0].Mode)
+		assert.Equal(t, api.Failed, records[1].Evidence.PolicyEvaluationStatus)
+		require.NotNil(t, records[1].Mode)
+		assert.Equal(t, api.All, *records[1].Mode)
+	})
+
+	t.Run("array of enrichment requests", func(t *testing.T) {
+		records, err := readAll(t, `[{"evidence":{"policyRuleId":"rule-1"}},{"evidence":{"policyRuleId":"rule-2"}}]`)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "rule-2", records[1].Evidence.PolicyRuleId)
+	})
+
+	t.Run("batch enrichment request", func(t *testing.T) {
+		records, err := readAll(t, `{"evidence":[{"policyRuleId":"rule-1"},{"policyRuleId":"rule-2"}],"mode":"all"}`)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.NotNil(t, records[1].Mode)
+		assert.Equal(t, api.All, *records[1].Mode)
+	})
+
+	t.Run("OTLP logs", func(t *testing.T) {
+		records, err := readAll(t, otlpLogs)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+
+		evidence := records[0].Evidence
+		require.NoError(t, records[0].Err)
+		assert.Equal(t, "github_branch_protection", evidence.PolicyRuleId)
+		assert.Equal(t, "conforma", evidence.PolicyEngineName)
+		assert.Equal(t, api.Failed, evidence.PolicyEvaluationStatus)
+		assert.Equal(t, time.Unix(0, 1757087791576000000).UTC(), evidence.Timestamp)
+		require.NotNil(t, evidence.PolicyTargetId)
+		assert.Equal(t, "repo", *evidence.PolicyTargetId)
+		assert.Nil(t, evidence.PolicyTargetEnvironment)
+		require.NotNil(t, evidence.RawData)
+		assert.Equal(t, "failure", (*evidence.RawData)["status"])
+
+		assert.EqualError(t, records[1].Err, "log record is missing required attributes: policy.engine.name, policy.evaluation.result")
+		assert.Equal(t, time.Unix(0, 1757087791576000000).UTC(), records[1].Evidence.Timestamp)
+		assert.Nil(t, records[1].Evidence.RawData)
+	})
+
+	for _, tt := range []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "invalid JSON", input: `{"evidence":`, wantErr: "reading evidence"},
+		{name: "unknown shape", input: `{"records":[]}`, wantErr: `expected an object with "evidence" or "resourceLogs"`},
+		{name: "not an object", input: `"evidence"`, wantErr: "expected a JSON object or array"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readAll(t, tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
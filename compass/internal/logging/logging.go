@@ -2,6 +2,7 @@ package logging
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
@@ -11,13 +12,18 @@ import (
 // level: one of debug, info, warn, error (case-insensitive)
 // format is fixed to JSON.
 func Init(level string) (*slog.Logger, error) {
+	return InitWriter(os.Stdout, level)
+}
+
+// InitWriter is like Init, but writes logs to w instead of stdout.
+func InitWriter(w io.Writer, level string) (*slog.Logger, error) {
 	lvl, err := parseLevel(level)
 	if err != nil {
 		return nil, err
 	}
 
 	opts := &slog.HandlerOptions{Level: lvl}
-	handler := slog.NewJSONHandler(os.Stdout, opts)
+	handler := slog.NewJSONHandler(w, opts)
 	logger := slog.New(handler)
 	slog.SetDefault(logger)
 	return logger, nil
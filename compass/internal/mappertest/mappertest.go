@@ -0,0 +1,63 @@
+// Package mappertest provides catalog and evaluation plan fixtures for
+// mapper plugin tests.
+package mappertest
+
+import (
+	"github.com/ossf/gemara/layer2"
+	"github.com/ossf/gemara/layer4"
+
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// RequirementID returns the ID of the single assessment requirement that
+// Scope gives controlID.
+func RequirementID(controlID string) string {
+	return controlID + ".01"
+}
+
+// Scope returns a scope holding one catalog, catalogID, with a family of the
+// given controls. Each control has a single assessment requirement, named by
+// RequirementID.
+func Scope(catalogID string, controlIDs ...string) mapper.Scope {
+	controls := make([]layer2.Control, 0, len(controlIDs))
+	for _, id := range controlIDs {
+		controls = append(controls, layer2.Control{
+			Id:                     id,
+			AssessmentRequirements: []layer2.AssessmentRequirement{{Id: RequirementID(id)}},
+		})
+	}
+	return mapper.Scope{
+		catalogID: layer2.Catalog{
+			Metadata: layer2.Metadata{Id: catalogID},
+			ControlFamilies: []layer2.ControlFamily{{
+				Title:    "Test Family",
+				Controls: controls,
+			}},
+		},
+	}
+}
+
+// Plan returns an evaluation plan mapping each procedure to the assessment
+// requirement of controlID in catalogID. Procedures are documented as
+// "See <id>".
+func Plan(catalogID, controlID string, procedureIDs ...string) layer4.AssessmentPlan {
+	return RequirementPlan(catalogID, controlID, RequirementID(controlID), procedureIDs...)
+}
+
+// RequirementPlan returns an evaluation plan like Plan, but for the
+// assessment requirement requirementID, which may be empty or need not
+// belong to controlID, so that plans that do not match a catalog can be
+// built.
+func RequirementPlan(catalogID, controlID, requirementID string, procedureIDs ...string) layer4.AssessmentPlan {
+	procedures := make([]layer4.AssessmentProcedure, 0, len(procedureIDs))
+	for _, id := range procedureIDs {
+		procedures = append(procedures, layer4.AssessmentProcedure{Id: id, Documentation: "See " + id})
+	}
+	return layer4.AssessmentPlan{
+		Control: layer4.Mapping{EntryId: controlID, ReferenceId: catalogID},
+		Assessments: []layer4.Assessment{{
+			Requirement: layer4.Mapping{EntryId: requirementID, ReferenceId: catalogID},
+			Procedures:  procedures,
+		}},
+	}
+}
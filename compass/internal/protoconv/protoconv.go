@@ -0,0 +1,184 @@
+// Package protoconv converts between the HTTP API types and the gRPC messages
+// that mirror them.
+package protoconv
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/api/compassv1"
+)
+
+var policyEvaluationStatuses = map[compassv1.PolicyEvaluationStatus]api.EvidencePolicyEvaluationStatus{
+	compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_NOT_RUN:        api.NotRun,
+	compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_PASSED:         api.Passed,
+	compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_FAILED:         api.Failed,
+	compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_NEEDS_REVIEW:   api.NeedsReview,
+	compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_NOT_APPLICABLE: api.NotApplicable,
+	compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_UNKNOWN:        api.Unknown,
+}
+
+var complianceStatuses = map[api.ComplianceStatus]compassv1.ComplianceStatus{
+	api.ComplianceStatusCompliant:     compassv1.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT,
+	api.ComplianceStatusNonCompliant:  compassv1.ComplianceStatus_COMPLIANCE_STATUS_NON_COMPLIANT,
+	api.ComplianceStatusNeedsReview:   compassv1.ComplianceStatus_COMPLIANCE_STATUS_NEEDS_REVIEW,
+	api.ComplianceStatusExempt:        compassv1.ComplianceStatus_COMPLIANCE_STATUS_EXEMPT,
+	api.ComplianceStatusNotApplicable: compassv1.ComplianceStatus_COMPLIANCE_STATUS_NOT_APPLICABLE,
+	api.ComplianceStatusUnknown:       compassv1.ComplianceStatus_COMPLIANCE_STATUS_UNKNOWN,
+}
+
+var enrichmentStatuses = map[api.ComplianceEnrichmentStatus]compassv1.EnrichmentStatus{
+	api.ComplianceEnrichmentStatusSuccess:  compassv1.EnrichmentStatus_ENRICHMENT_STATUS_SUCCESS,
+	api.ComplianceEnrichmentStatusUnmapped: compassv1.EnrichmentStatus_ENRICHMENT_STATUS_UNMAPPED,
+	api.ComplianceEnrichmentStatusPartial:  compassv1.EnrichmentStatus_ENRICHMENT_STATUS_PARTIAL,
+	api.ComplianceEnrichmentStatusUnknown:  compassv1.EnrichmentStatus_ENRICHMENT_STATUS_UNKNOWN,
+	api.ComplianceEnrichmentStatusSkipped:  compassv1.EnrichmentStatus_ENRICHMENT_STATUS_SKIPPED,
+}
+
+var riskLevels = map[api.ComplianceRiskLevel]compassv1.RiskLevel{
+	api.Informational: compassv1.RiskLevel_RISK_LEVEL_INFORMATIONAL,
+	api.Low:           compassv1.RiskLevel_RISK_LEVEL_LOW,
+	api.Medium:        compassv1.RiskLevel_RISK_LEVEL_MEDIUM,
+	api.High:          compassv1.RiskLevel_RISK_LEVEL_HIGH,
+	api.Critical:      compassv1.RiskLevel_RISK_LEVEL_CRITICAL,
+}
+
+// invert returns the reverse lookup of m.
+func invert[K, V comparable](m map[K]V) map[V]K {
+	inverted := make(map[V]K, len(m))
+	for k, v := range m {
+		inverted[v] = k
+	}
+	return inverted
+}
+
+var (
+	protoPolicyEvaluationStatuses = invert(policyEvaluationStatuses)
+	apiComplianceStatuses         = invert(complianceStatuses)
+	apiEnrichmentStatuses         = invert(enrichmentStatuses)
+	apiRiskLevels                 = invert(riskLevels)
+)
+
+// EvidenceFromProto converts gRPC evidence. The timestamp and policy
+// evaluation status are required.
+func EvidenceFromProto(in *compassv1.Evidence) (api.Evidence, error) {
+	if in == nil {
+		return api.Evidence{}, errors.New("evidence is required")
+	}
+	if in.GetTimestamp() == nil {
+		return api.Evidence{}, errors.New("evidence timestamp is required")
+	}
+	evaluationStatus, ok := policyEvaluationStatuses[in.GetPolicyEvaluationStatus()]
+	if !ok {
+		return api.Evidence{}, errors.New("evidence policyEvaluationStatus is required")
+	}
+
+	evidence := api.Evidence{
+		Timestamp:               in.GetTimestamp().AsTime(),
+		PolicyEngineName:        in.GetPolicyEngineName(),
+		PolicyRuleId:            in.GetPolicyRuleId(),
+		PolicyEvaluationStatus:  evaluationStatus,
+		PolicyTargetEnvironment: in.PolicyTargetEnvironment,
+		PolicyTargetId:          in.PolicyTargetId,
+	}
+	if in.GetRawData() != nil {
+		rawData := in.GetRawData().AsMap()
+		evidence.RawData = &rawData
+	}
+	return evidence, nil
+}
+
+// EvidenceToProto converts evidence to its gRPC message. It fails when the
+// rawData holds values that a protobuf Struct cannot represent.
+func EvidenceToProto(evidence api.Evidence) (*compassv1.Evidence, error) {
+	out := &compassv1.Evidence{
+		Timestamp:               timestamppb.New(evidence.Timestamp),
+		PolicyEngineName:        evidence.PolicyEngineName,
+		PolicyRuleId:            evidence.PolicyRuleId,
+		PolicyEvaluationStatus:  protoPolicyEvaluationStatuses[evidence.PolicyEvaluationStatus],
+		PolicyTargetEnvironment: evidence.PolicyTargetEnvironment,
+		PolicyTargetId:          evidence.PolicyTargetId,
+	}
+	if evidence.RawData != nil {
+		rawData, err := structpb.NewStruct(*evidence.RawData)
+		if err != nil {
+			return nil, err
+		}
+		out.RawData = rawData
+	}
+	return out, nil
+}
+
+// ComplianceToProto converts a compliance finding to its gRPC message.
+func ComplianceToProto(compliance api.Compliance) *compassv1.Compliance {
+	out := &compassv1.Compliance{
+		Control: &compassv1.ComplianceControl{
+			Id:                     compliance.Control.Id,
+			Category:               compliance.Control.Category,
+			CatalogId:              compliance.Control.CatalogId,
+			RemediationDescription: compliance.Control.RemediationDescription,
+		},
+		Frameworks: &compassv1.ComplianceFrameworks{
+			Frameworks:   compliance.Frameworks.Frameworks,
+			Requirements: compliance.Frameworks.Requirements,
+		},
+		Status:           complianceStatuses[compliance.Status],
+		EnrichmentStatus: enrichmentStatuses[compliance.EnrichmentStatus],
+		TargetId:         compliance.TargetId,
+	}
+	if compliance.Control.Applicability != nil {
+		out.Control.Applicability = *compliance.Control.Applicability
+	}
+	if compliance.Risk != nil && compliance.Risk.Level != nil {
+		out.Risk = &compassv1.ComplianceRisk{Level: riskLevels[*compliance.Risk.Level]}
+	}
+	if compliance.Exception != nil {
+		out.Exception = &compassv1.ComplianceException{
+			Id:     compliance.Exception.Id,
+			Active: compliance.Exception.Active,
+		}
+	}
+	return out
+}
+
+// ComplianceFromProto converts a gRPC compliance finding. Unspecified
+// statuses become Unknown.
+func ComplianceFromProto(compliance *compassv1.Compliance) api.Compliance {
+	out := api.Compliance{
+		Control: api.ComplianceControl{
+			Id:                     compliance.GetControl().GetId(),
+			Category:               compliance.GetControl().GetCategory(),
+			CatalogId:              compliance.GetControl().GetCatalogId(),
+			RemediationDescription: compliance.GetControl().RemediationDescription,
+		},
+		Frameworks: api.ComplianceFrameworks{
+			Frameworks:   compliance.GetFrameworks().GetFrameworks(),
+			Requirements: compliance.GetFrameworks().GetRequirements(),
+		},
+		Status:           api.ComplianceStatusUnknown,
+		EnrichmentStatus: api.ComplianceEnrichmentStatusUnknown,
+		TargetId:         compliance.TargetId,
+	}
+	if status, ok := apiComplianceStatuses[compliance.GetStatus()]; ok {
+		out.Status = status
+	}
+	if status, ok := apiEnrichmentStatuses[compliance.GetEnrichmentStatus()]; ok {
+		out.EnrichmentStatus = status
+	}
+	if applicability := compliance.GetControl().GetApplicability(); len(applicability) > 0 {
+		out.Control.Applicability = &applicability
+	}
+	if level, ok := apiRiskLevels[compliance.GetRisk().GetLevel()]; ok {
+		out.Risk = &api.ComplianceRisk{Level: &level}
+	}
+	if compliance.GetException() != nil {
+		out.Exception = &api.ComplianceException{
+			Id:     compliance.GetException().GetId(),
+			Active: compliance.GetException().GetActive(),
+		}
+	}
+	return out
+}
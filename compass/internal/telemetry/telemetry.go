@@ -0,0 +1,140 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+// Exporter names accepted in Config.
+const (
+	ExporterNone       = "none"
+	ExporterOTLP       = "otlp"
+	ExporterPrometheus = "prometheus"
+)
+
+// Config selects how Compass exports its own metrics and traces. OTLP
+// exporters are configured through the standard OTEL_EXPORTER_OTLP_*
+// environment variables.
+type Config struct {
+	// Metrics is one of "otlp", "prometheus", or "none". Empty means none.
+	Metrics string `json:"metrics"`
+	// Traces is one of "otlp" or "none". Empty means none.
+	Traces string `json:"traces"`
+}
+
+// Validate checks that the configured exporters are supported.
+func (c Config) Validate() error {
+	switch c.Metrics {
+	case "", ExporterNone, ExporterOTLP, ExporterPrometheus:
+	default:
+		return fmt.Errorf("unsupported metrics exporter %q: must be one of otlp, prometheus, none", c.Metrics)
+	}
+	switch c.Traces {
+	case "", ExporterNone, ExporterOTLP:
+	default:
+		return fmt.Errorf("unsupported traces exporter %q: must be one of otlp, none", c.Traces)
+	}
+	return nil
+}
+
+// Telemetry holds the providers installed by Setup.
+type Telemetry struct {
+	metricsHandler http.Handler
+	shutdowns      []func(context.Context) error
+}
+
+// Setup installs global meter and tracer providers for the configured
+// exporters, and the W3C trace context and baggage propagators so incoming
+// requests join the caller's trace.
+func Setup(ctx context.Context, cfg Config) (*Telemetry, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName("compass")),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating telemetry resource: %w", err)
+	}
+
+	t := &Telemetry{}
+	switch cfg.Metrics {
+	case ExporterOTLP:
+		exporter, err := otlpmetricgrpc.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("creating otlp metrics exporter: %w", err)
+		}
+		t.setMeterProvider(sdkmetric.NewMeterProvider(
+			sdkmetric.WithResource(res),
+			sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
+		))
+	case ExporterPrometheus:
+		registry := prometheus.NewRegistry()
+		exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+		if err != nil {
+			return nil, fmt.Errorf("creating prometheus metrics exporter: %w", err)
+		}
+		t.setMeterProvider(sdkmetric.NewMeterProvider(
+			sdkmetric.WithResource(res),
+			sdkmetric.WithReader(exporter),
+		))
+		t.metricsHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	}
+
+	if cfg.Traces == ExporterOTLP {
+		exporter, err := otlptracegrpc.New(ctx)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("creating otlp traces exporter: %w", err), t.Shutdown(ctx))
+		}
+		provider := sdktrace.NewTracerProvider(
+			sdktrace.WithResource(res),
+			sdktrace.WithBatcher(exporter),
+		)
+		otel.SetTracerProvider(provider)
+		t.shutdowns = append(t.shutdowns, provider.Shutdown)
+	}
+
+	return t, nil
+}
+
+func (t *Telemetry) setMeterProvider(provider *sdkmetric.MeterProvider) {
+	otel.SetMeterProvider(provider)
+	t.shutdowns = append(t.shutdowns, provider.Shutdown)
+}
+
+// MetricsHandler returns the Prometheus scrape handler, or nil when metrics
+// are not exported to Prometheus.
+func (t *Telemetry) MetricsHandler() http.Handler {
+	return t.metricsHandler
+}
+
+// Shutdown flushes and stops the installed providers.
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, shutdown := range t.shutdowns {
+		errs = append(errs, shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
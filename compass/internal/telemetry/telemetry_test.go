@@ -0,0 +1,64 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{name: "empty", config: Config{}},
+		{name: "otlp", config: Config{Metrics: ExporterOTLP, Traces: ExporterOTLP}},
+		{name: "prometheus", config: Config{Metrics: ExporterPrometheus, Traces: ExporterNone}},
+		{name: "unknown metrics exporter", config: Config{Metrics: "statsd"}, wantErr: "unsupported metrics exporter"},
+		{name: "prometheus traces", config: Config{Traces: ExporterPrometheus}, wantErr: "unsupported traces exporter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSetupPrometheus(t *testing.T) {
+	tel, err := Setup(context.Background(), Config{Metrics: ExporterPrometheus})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	require.NotNil(t, tel.MetricsHandler())
+
+	counter, err := otel.Meter("test").Int64Counter("compass.test.requests")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 1)
+
+	w := httptest.NewRecorder()
+	tel.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "compass_test_requests_total")
+}
+
+func TestSetupNone(t *testing.T) {
+	tel, err := Setup(context.Background(), Config{})
+	require.NoError(t, err)
+	assert.Nil(t, tel.MetricsHandler())
+	assert.NoError(t, tel.Shutdown(context.Background()))
+
+	_, err = Setup(context.Background(), Config{Metrics: "statsd"})
+	assert.Error(t, err)
+}
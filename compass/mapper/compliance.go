@@ -0,0 +1,132 @@
+package mapper
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ossf/gemara/layer2"
+
+	"github.com/complytime/complybeacon/compass/api"
+)
+
+// Unmapped returns the Compliance reported for evidence that no control
+// could be found for.
+func Unmapped() api.Compliance {
+	return api.Compliance{
+		Status: api.ComplianceStatusUnknown,
+		Control: api.ComplianceControl{
+			Id:        "UNMAPPED",
+			CatalogId: "UNMAPPED",
+			Category:  "UNCATEGORIZED",
+		},
+		EnrichmentStatus: api.ComplianceEnrichmentStatusUnmapped,
+		Frameworks: api.ComplianceFrameworks{
+			Frameworks:   []string{},
+			Requirements: []string{},
+		},
+	}
+}
+
+// StatusFromEvaluation returns the compliance status for a policy
+// evaluation result.
+func StatusFromEvaluation(status api.EvidencePolicyEvaluationStatus) api.ComplianceStatus {
+	switch status {
+	case api.Passed:
+		return api.ComplianceStatusCompliant
+	case api.Failed:
+		return api.ComplianceStatusNonCompliant
+	case api.NotRun, api.NotApplicable:
+		return api.ComplianceStatusNotApplicable
+	default:
+		return api.ComplianceStatusUnknown
+	}
+}
+
+// Frameworks returns the external requirements and frameworks that control
+// is mapped to by its guideline mappings.
+func Frameworks(control *layer2.Control) api.ComplianceFrameworks {
+	frameworks := api.ComplianceFrameworks{
+		Frameworks:   []string{},
+		Requirements: []string{},
+	}
+	for _, mapping := range control.GuidelineMappings {
+		frameworks.Frameworks = append(frameworks.Frameworks, mapping.ReferenceId)
+		for _, entry := range mapping.Entries {
+			frameworks.Requirements = append(frameworks.Requirements, entry.ReferenceId)
+		}
+	}
+	return frameworks
+}
+
+// Resolve returns a successful finding for a control or assessment
+// requirement of the catalog with catalogID in index. When requirementID is
+// set, the finding is reported for the requirement, and controlID, if set,
+// must name its control. The caller sets the status.
+func Resolve(index ScopeIndex, catalogID, controlID, requirementID string) (api.Compliance, error) {
+	catalogIndex, ok := index[catalogID]
+	if !ok {
+		return api.Compliance{}, fmt.Errorf("catalog %s not found in scope", catalogID)
+	}
+
+	var ctrl ControlEntry
+	id := requirementID
+	if requirementID != "" {
+		requirement, found := catalogIndex.Requirement(requirementID)
+		ok = found && (controlID == "" || requirement.Control.Id == controlID)
+		ctrl = requirement.ControlEntry
+	} else {
+		ctrl, ok = catalogIndex.Control(controlID)
+		id = controlID
+	}
+	if !ok {
+		return api.Compliance{}, fmt.Errorf("control %s not found in catalog %s", id, catalogID)
+	}
+
+	return api.Compliance{
+		Control: api.ComplianceControl{
+			Id:        id,
+			Category:  ctrl.Family.Title,
+			CatalogId: catalogID,
+		},
+		Frameworks:       Frameworks(ctrl.Control),
+		EnrichmentStatus: api.ComplianceEnrichmentStatusSuccess,
+	}, nil
+}
+
+// complianceStatuses lists the values of api.ComplianceStatus.
+var complianceStatuses = []api.ComplianceStatus{
+	api.ComplianceStatusCompliant,
+	api.ComplianceStatusNonCompliant,
+	api.ComplianceStatusNeedsReview,
+	api.ComplianceStatusNotApplicable,
+	api.ComplianceStatusExempt,
+	api.ComplianceStatusUnknown,
+}
+
+// ParseComplianceStatus returns the api.ComplianceStatus named by status.
+func ParseComplianceStatus(status string) (api.ComplianceStatus, error) {
+	for _, valid := range complianceStatuses {
+		if string(valid) == status {
+			return valid, nil
+		}
+	}
+	names := make([]string, len(complianceStatuses))
+	for i, valid := range complianceStatuses {
+		names[i] = fmt.Sprintf("%q", valid)
+	}
+	return "", fmt.Errorf("unknown compliance status %q: must be one of %s", status, strings.Join(names, ", "))
+}
+
+// ParseMappedStatus returns the api.ComplianceStatus named by status, for a
+// status that a plugin or its configuration assigns to findings. Exempt is
+// rejected, as it is reserved for exceptions.
+func ParseMappedStatus(status string) (api.ComplianceStatus, error) {
+	parsed, err := ParseComplianceStatus(status)
+	if err != nil {
+		return "", err
+	}
+	if parsed == api.ComplianceStatusExempt {
+		return "", fmt.Errorf("%q is reserved for exceptions", parsed)
+	}
+	return parsed, nil
+}
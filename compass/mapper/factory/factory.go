@@ -1,10 +1,35 @@
+// Package factory links the built-in mapper plugins into the binary and
+// creates mappers from plugin configuration.
 package factory
 
 import (
 	"github.com/complytime/complybeacon/compass/mapper"
 	"github.com/complytime/complybeacon/compass/mapper/plugins/basic"
+	_ "github.com/complytime/complybeacon/compass/mapper/plugins/cel"
+	_ "github.com/complytime/complybeacon/compass/mapper/plugins/conforma"
+	_ "github.com/complytime/complybeacon/compass/mapper/plugins/external"
+	_ "github.com/complytime/complybeacon/compass/mapper/plugins/kyverno"
+	_ "github.com/complytime/complybeacon/compass/mapper/plugins/opa"
+	_ "github.com/complytime/complybeacon/compass/mapper/plugins/wasm"
 )
 
-func MapperByID(_ mapper.ID) mapper.Mapper {
-	return basic.NewBasicMapper()
+// DefaultType is the plugin type used for a plugin that does not name one.
+const DefaultType = basic.Type
+
+// ResolveType returns the plugin type configured for a plugin. An empty
+// pluginType selects DefaultType, even when the plugin's ID names another
+// registered type, so that adding a type never changes how an existing
+// configuration is mapped.
+func ResolveType(pluginType mapper.Type) mapper.Type {
+	if pluginType != "" {
+		return pluginType
+	}
+	return DefaultType
+}
+
+// NewMapper returns a mapper of pluginType, or DefaultType when it is empty,
+// created for id with the plugin's type-specific config. It fails when the
+// type is not registered.
+func NewMapper(id mapper.ID, pluginType mapper.Type, config mapper.Config) (mapper.Mapper, error) {
+	return mapper.New(ResolveType(pluginType), id, config)
 }
@@ -0,0 +1,134 @@
+package mapper
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ossf/gemara/layer2"
+)
+
+// ControlEntry locates a control within its catalog.
+type ControlEntry struct {
+	CatalogID string
+	Family    *layer2.ControlFamily
+	Control   *layer2.Control
+}
+
+// RequirementEntry locates an assessment requirement within its control.
+type RequirementEntry struct {
+	ControlEntry
+	Requirement *layer2.AssessmentRequirement
+}
+
+// CatalogIndex is a read-only lookup over the controls, assessment
+// requirements, and threats of a Layer 2 catalog. It must not be modified
+// after creation.
+type CatalogIndex struct {
+	Catalog      *layer2.Catalog
+	controls     map[string]ControlEntry
+	requirements map[string]RequirementEntry
+	threats      map[string]*layer2.Threat
+}
+
+// NewCatalogIndex indexes the controls, assessment requirements, and threats
+// in catalog.
+func NewCatalogIndex(catalog layer2.Catalog) *CatalogIndex {
+	index := &CatalogIndex{
+		Catalog:      &catalog,
+		controls:     make(map[string]ControlEntry),
+		requirements: make(map[string]RequirementEntry),
+		threats:      make(map[string]*layer2.Threat),
+	}
+
+	for i := range catalog.Threats {
+		index.threats[catalog.Threats[i].Id] = &catalog.Threats[i]
+	}
+
+	for i := range catalog.ControlFamilies {
+		family := &catalog.ControlFamilies[i]
+		for j := range family.Controls {
+			control := &family.Controls[j]
+			entry := ControlEntry{
+				CatalogID: catalog.Metadata.Id,
+				Family:    family,
+				Control:   control,
+			}
+			index.controls[control.Id] = entry
+			for k := range control.AssessmentRequirements {
+				requirement := &control.AssessmentRequirements[k]
+				index.requirements[requirement.Id] = RequirementEntry{
+					ControlEntry: entry,
+					Requirement:  requirement,
+				}
+			}
+		}
+	}
+
+	return index
+}
+
+// Control returns the control with the given ID.
+func (i *CatalogIndex) Control(id string) (ControlEntry, bool) {
+	entry, ok := i.controls[id]
+	return entry, ok
+}
+
+// Requirement returns the assessment requirement with the given ID.
+func (i *CatalogIndex) Requirement(id string) (RequirementEntry, bool) {
+	entry, ok := i.requirements[id]
+	return entry, ok
+}
+
+// Threat returns the threat with the given ID.
+func (i *CatalogIndex) Threat(id string) (*layer2.Threat, bool) {
+	threat, ok := i.threats[id]
+	return threat, ok
+}
+
+// ScopeIndex holds a CatalogIndex for each catalog in a Scope, keyed by
+// catalog ID.
+type ScopeIndex map[string]*CatalogIndex
+
+// NewScopeIndex indexes every catalog in scope.
+func NewScopeIndex(scope Scope) ScopeIndex {
+	index := make(ScopeIndex, len(scope))
+	for catalogID, catalog := range scope {
+		index[catalogID] = NewCatalogIndex(catalog)
+	}
+	return index
+}
+
+// IndexCache memoizes the ScopeIndex of the most recently seen Scope, so that
+// the index is rebuilt only when the scope changes. Scopes are treated as
+// immutable once in use: a changed scope must be a new map, as produced by a
+// reload. The zero value is ready to use.
+type IndexCache struct {
+	mu      sync.Mutex
+	current atomic.Pointer[cachedIndex]
+}
+
+type cachedIndex struct {
+	// scope keeps the indexed map reachable so its address, used as key,
+	// cannot be reused by a different map.
+	scope Scope
+	key   uintptr
+	index ScopeIndex
+}
+
+// Get returns the index for scope, building it on first use.
+func (c *IndexCache) Get(scope Scope) ScopeIndex {
+	key := reflect.ValueOf(scope).Pointer()
+	if cached := c.current.Load(); cached != nil && cached.key == key {
+		return cached.index
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached := c.current.Load(); cached != nil && cached.key == key {
+		return cached.index
+	}
+	index := NewScopeIndex(scope)
+	c.current.Store(&cachedIndex{scope: scope, key: key, index: index})
+	return index
+}
@@ -0,0 +1,66 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/ossf/gemara/layer2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCatalog(id string) layer2.Catalog {
+	return layer2.Catalog{
+		Metadata: layer2.Metadata{Id: id},
+		ControlFamilies: []layer2.ControlFamily{
+			{
+				Id:    "AC",
+				Title: "Access Control",
+				Controls: []layer2.Control{
+					{
+						Id: "AC-1",
+						AssessmentRequirements: []layer2.AssessmentRequirement{
+							{Id: "AC-1.01", Applicability: []string{"Production"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCatalogIndex(t *testing.T) {
+	index := NewCatalogIndex(testCatalog("catalog-1"))
+
+	control, ok := index.Control("AC-1")
+	require.True(t, ok)
+	assert.Equal(t, "catalog-1", control.CatalogID)
+	assert.Equal(t, "Access Control", control.Family.Title)
+	assert.Equal(t, "AC-1", control.Control.Id)
+
+	requirement, ok := index.Requirement("AC-1.01")
+	require.True(t, ok)
+	assert.Equal(t, "AC-1", requirement.Control.Id)
+	assert.Equal(t, []string{"Production"}, requirement.Requirement.Applicability)
+
+	_, ok = index.Control("AC-2")
+	assert.False(t, ok)
+	_, ok = index.Requirement("AC-2.01")
+	assert.False(t, ok)
+}
+
+func TestIndexCache(t *testing.T) {
+	var cache IndexCache
+
+	scope := Scope{"catalog-1": testCatalog("catalog-1")}
+	first := cache.Get(scope)
+	require.Contains(t, first, "catalog-1")
+
+	// The same scope reuses the cached index.
+	assert.Same(t, first["catalog-1"], cache.Get(scope)["catalog-1"])
+
+	// A new scope, as produced by a reload, is indexed again.
+	reloaded := Scope{"catalog-2": testCatalog("catalog-2")}
+	second := cache.Get(reloaded)
+	assert.Contains(t, second, "catalog-2")
+	assert.NotContains(t, second, "catalog-1")
+}
@@ -1,6 +1,10 @@
 package mapper
 
 import (
+	"errors"
+	"fmt"
+	"io"
+
 	"github.com/ossf/gemara/layer2"
 	"github.com/ossf/gemara/layer4"
 
@@ -15,6 +19,67 @@ type Mapper interface {
 	AddEvaluationPlan(catalogId string, plans ...layer4.AssessmentPlan)
 }
 
+// MultiMapper is implemented by mappers that can map evidence to every
+// matching control instead of only the first one.
+type MultiMapper interface {
+	Mapper
+	// MapAll returns a successful Compliance for each control the evidence
+	// maps to, in a stable order. It returns an empty slice when the
+	// evidence cannot be mapped.
+	MapAll(evidence api.Evidence, scope Scope) []api.Compliance
+}
+
+// Conflict is a policy rule that is mapped by assessment procedures in more
+// than one catalog.
+type Conflict struct {
+	PolicyRuleID string
+	// CatalogIDs lists the catalogs in precedence order. The first one is
+	// used when a single match is requested.
+	CatalogIDs []string
+}
+
+// PrecedenceMapper is implemented by mappers that order catalogs by a
+// configurable precedence when a policy rule is mapped in several of them.
+type PrecedenceMapper interface {
+	Mapper
+	// SetCatalogPrecedence places the given catalogs first, in order. Other
+	// catalogs follow in order of their IDs.
+	SetCatalogPrecedence(catalogIDs ...string)
+	// Conflicts returns the policy rules mapped in more than one catalog,
+	// ordered by policy rule ID.
+	Conflicts() []Conflict
+}
+
+// StatusMapper is implemented by mappers whose translation of policy
+// evaluation results to compliance statuses can be configured. Mappers that
+// read results from rawData translate them to evaluation results first, so
+// the mapping applies to every status they do not set explicitly.
+type StatusMapper interface {
+	Mapper
+	// SetStatusMapping replaces the status mapping. The mapping must be
+	// valid.
+	SetStatusMapping(mapping StatusMapping)
+}
+
+// Procedure is an assessment procedure that maps a policy rule to an
+// assessment requirement of a control.
+type Procedure struct {
+	PolicyRuleID  string
+	CatalogID     string
+	ControlID     string
+	RequirementID string
+	Documentation string
+}
+
+// ProcedureLister is implemented by mappers that can list the assessment
+// procedures they map.
+type ProcedureLister interface {
+	Mapper
+	// Procedures returns every procedure the mapper knows, in catalog order
+	// and then by policy rule ID.
+	Procedures() []Procedure
+}
+
 // ID represents the identity for a transformer.
 type ID string
 
@@ -26,6 +91,21 @@ func NewID(id string) ID {
 // Set defines Transformers by ID
 type Set map[ID]Mapper
 
+// Close releases the resources held by the mappers in s that implement
+// io.Closer, such as external plugin processes. The set must not be used
+// afterwards.
+func (s Set) Close() error {
+	var errs []error
+	for id, m := range s {
+		if closer, ok := m.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("closing plugin %s: %w", id, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Scope defined in scope Layer2 Catalogs by the
 // catalog ID
 type Scope map[string]layer2.Catalog
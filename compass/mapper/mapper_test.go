@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -103,6 +104,35 @@ func TestSet(t *testing.T) {
 	assert.Contains(t, set, ID("control-mapper"))
 }
 
+// closingMapper records whether it was closed.
+type closingMapper struct {
+	mockMapper
+	closed bool
+	err    error
+}
+
+func (m *closingMapper) Close() error {
+	m.closed = true
+	return m.err
+}
+
+func TestSetClose(t *testing.T) {
+	closer := &closingMapper{mockMapper: mockMapper{id: "closer"}}
+	failing := &closingMapper{mockMapper: mockMapper{id: "failing"}, err: errors.New("process did not exit")}
+	set := Set{
+		"plain":   &mockMapper{id: "plain"},
+		"closer":  closer,
+		"failing": failing,
+	}
+
+	err := set.Close()
+	assert.True(t, closer.closed)
+	assert.True(t, failing.closed)
+	assert.EqualError(t, err, "closing plugin failing: process did not exit")
+
+	assert.NoError(t, Set(nil).Close())
+}
+
 func TestScope(t *testing.T) {
 	scope := make(Scope)
 
@@ -2,8 +2,9 @@ package basic
 
 import (
 	"log"
+	"sort"
+	"sync"
 
-	"github.com/ossf/gemara/layer2"
 	"github.com/ossf/gemara/layer4"
 
 	"github.com/complytime/complybeacon/compass/api"
@@ -17,25 +18,58 @@ type ProcedureInfo struct {
 	Documentation string
 }
 
-// ControlData represents control information including mappings and category
-type ControlData struct {
-	Mappings []layer2.Mapping
-	Category string
-}
-
 // A basic mapper processes assessment plans and maps evidence to compliance controls,
 // requirements, and standards using the gemara framework.
 
 var (
-	_  mapper.Mapper = (*Mapper)(nil)
-	ID               = mapper.NewID("basic")
+	_  mapper.MultiMapper      = (*Mapper)(nil)
+	_  mapper.PrecedenceMapper = (*Mapper)(nil)
+	_  mapper.ProcedureLister  = (*Mapper)(nil)
+	_  mapper.StatusMapper     = (*Mapper)(nil)
+	ID                         = mapper.NewID("basic")
 )
 
+// Type is the plugin type the basic mapper is registered under.
+const Type mapper.Type = "basic"
+
+func init() {
+	mapper.Register(Type, func(id mapper.ID, config mapper.Config) (mapper.Mapper, error) {
+		// The basic mapper has no settings of its own.
+		if err := config.Decode(&struct{}{}); err != nil {
+			return nil, err
+		}
+		m := NewBasicMapper()
+		m.id = id
+		return m, nil
+	})
+}
+
 type Mapper struct {
+	// id is the configured plugin ID. It is empty for mappers created with
+	// NewBasicMapper, which report ID.
+	id mapper.ID
+	// mu guards plans and procedures, which change only while plans are loaded.
+	mu    sync.RWMutex
 	plans map[string][]layer4.AssessmentPlan
+	// procedures indexes procedure IDs by catalog ID. It is updated as
+	// plans are added so Map never walks the plans.
+	procedures map[string]map[string]ProcedureInfo
+	// precedence lists catalog IDs that are matched before all others.
+	precedence []string
+	// catalogOrder is the order in which catalogs are matched: precedence
+	// first, then the remaining catalogs by ID.
+	catalogOrder []string
+	// statusMapping translates evaluation results to compliance statuses.
+	statusMapping mapper.StatusMapping
+	// scopeIndex holds the control lookup for the scope most recently
+	// passed to Map.
+	scopeIndex mapper.IndexCache
 }
 
 func (m *Mapper) AddEvaluationPlan(catalogId string, plans ...layer4.AssessmentPlan) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	existingPlans, ok := m.plans[catalogId]
 	if !ok {
 		m.plans[catalogId] = plans
@@ -43,109 +77,202 @@ func (m *Mapper) AddEvaluationPlan(catalogId string, plans ...layer4.AssessmentP
 		existingPlans = append(existingPlans, plans...)
 		m.plans[catalogId] = existingPlans
 	}
+
+	proceduresById, ok := m.procedures[catalogId]
+	if !ok {
+		proceduresById = make(map[string]ProcedureInfo)
+		m.procedures[catalogId] = proceduresById
+	}
+	indexProcedures(proceduresById, plans)
+	m.updateCatalogOrder()
+}
+
+// SetCatalogPrecedence places the given catalogs first, in order, when
+// evidence matches procedures in several catalogs.
+func (m *Mapper) SetCatalogPrecedence(catalogIDs ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.precedence = append([]string(nil), catalogIDs...)
+	m.updateCatalogOrder()
+}
+
+// SetStatusMapping replaces the translation of evaluation results to
+// compliance statuses.
+func (m *Mapper) SetStatusMapping(mapping mapper.StatusMapping) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statusMapping = mapping
+}
+
+// Status returns the compliance status for an evaluation result on a finding
+// for the given catalog and control or assessment requirement IDs, following
+// the status mapping.
+func (m *Mapper) Status(result api.EvidencePolicyEvaluationStatus, catalogID string, controlIDs ...string) api.ComplianceStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.statusMapping.Status(result, catalogID, controlIDs...)
+}
+
+// Conflicts returns the procedure IDs that appear in more than one catalog.
+func (m *Mapper) Conflicts() []mapper.Conflict {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	catalogsByProcedure := make(map[string][]string)
+	for _, catalogId := range m.catalogOrder {
+		for procedureId := range m.procedures[catalogId] {
+			catalogsByProcedure[procedureId] = append(catalogsByProcedure[procedureId], catalogId)
+		}
+	}
+
+	var conflicts []mapper.Conflict
+	for procedureId, catalogIds := range catalogsByProcedure {
+		if len(catalogIds) > 1 {
+			conflicts = append(conflicts, mapper.Conflict{PolicyRuleID: procedureId, CatalogIDs: catalogIds})
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].PolicyRuleID < conflicts[j].PolicyRuleID
+	})
+	return conflicts
+}
+
+// Procedures returns the indexed procedures of every catalog, in catalog
+// order and then by procedure ID.
+func (m *Mapper) Procedures() []mapper.Procedure {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var procedures []mapper.Procedure
+	for _, catalogId := range m.catalogOrder {
+		proceduresById := m.procedures[catalogId]
+		procedureIds := make([]string, 0, len(proceduresById))
+		for procedureId := range proceduresById {
+			procedureIds = append(procedureIds, procedureId)
+		}
+		sort.Strings(procedureIds)
+
+		for _, procedureId := range procedureIds {
+			info := proceduresById[procedureId]
+			procedures = append(procedures, mapper.Procedure{
+				PolicyRuleID:  procedureId,
+				CatalogID:     catalogId,
+				ControlID:     info.ControlID,
+				RequirementID: info.RequirementID,
+				Documentation: info.Documentation,
+			})
+		}
+	}
+	return procedures
+}
+
+// updateCatalogOrder recomputes catalogOrder. It must be called with mu held.
+func (m *Mapper) updateCatalogOrder() {
+	order := make([]string, 0, len(m.procedures))
+	ranked := make(map[string]bool, len(m.precedence))
+	for _, catalogId := range m.precedence {
+		if _, ok := m.procedures[catalogId]; ok && !ranked[catalogId] {
+			order = append(order, catalogId)
+			ranked[catalogId] = true
+		}
+	}
+
+	var rest []string
+	for catalogId := range m.procedures {
+		if !ranked[catalogId] {
+			rest = append(rest, catalogId)
+		}
+	}
+	sort.Strings(rest)
+	m.catalogOrder = append(order, rest...)
 }
 
 func NewBasicMapper() *Mapper {
 	return &Mapper{
-		plans: make(map[string][]layer4.AssessmentPlan),
+		plans:      make(map[string][]layer4.AssessmentPlan),
+		procedures: make(map[string]map[string]ProcedureInfo),
 	}
 }
 
 func (m *Mapper) PluginName() mapper.ID {
-	return ID
+	if m.id == "" {
+		return ID
+	}
+	return m.id
 }
 
 func (m *Mapper) Map(evidence api.Evidence, scope mapper.Scope) api.Compliance {
+	if matches := m.MapAll(evidence, scope); len(matches) > 0 {
+		return matches[0]
+	}
 
-	// Map decision to status
-	status := m.mapDecision(evidence.PolicyEvaluationStatus)
+	return mapper.Unmapped()
+}
 
+// MapAll maps the evidence against every catalog with a matching procedure.
+// Catalogs are visited in precedence order, then in order of their IDs.
+func (m *Mapper) MapAll(evidence api.Evidence, scope mapper.Scope) []api.Compliance {
 	var failureReasons []string
+	matches := []api.Compliance{}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// A mapper without plans, such as the fallback for unknown engines,
+	// cannot match anything, so the scope is not indexed for it.
+	if len(m.catalogOrder) == 0 {
+		return matches
+	}
+	index := m.scopeIndex.Get(scope)
 
 	// Process each catalog
-	for catalogId, plans := range m.plans {
-		catalog, ok := scope[catalogId]
+	for _, catalogId := range m.catalogOrder {
+		proceduresById := m.procedures[catalogId]
+		catalogIndex, ok := index[catalogId]
 		if !ok {
 			log.Printf("WARNING: Catalog %s not found in scope for policy %s", catalogId, evidence.PolicyRuleId)
 			failureReasons = append(failureReasons, "catalog not found")
 			continue
 		}
 
-		// Build procedures map
-		proceduresById := m.buildProceduresMap(plans)
-
-		// Build control data map
-		controlData := m.buildControlDataMap(catalog)
-
 		// Look up policy in procedures
-		if procedureInfo, ok := proceduresById[evidence.PolicyRuleId]; ok {
-
-			// Look up control data
-			if ctrlData, ok := controlData[procedureInfo.ControlID]; ok {
-				compliance := api.Compliance{
-					Control: api.ComplianceControl{
-						Id:                     procedureInfo.RequirementID,
-						Category:               ctrlData.Category,
-						RemediationDescription: &procedureInfo.Documentation,
-						CatalogId:              catalogId,
-					},
-					Frameworks: api.ComplianceFrameworks{
-						Requirements: m.extractRequirements(ctrlData.Mappings),
-						Frameworks:   m.extractStandards(ctrlData.Mappings),
-					},
-					Status:           status,
-					EnrichmentStatus: api.ComplianceEnrichmentStatusSuccess,
-				}
-
-				return compliance
-			} else {
-				log.Printf("WARNING: Control data not found for control ID %s in catalog %s for policy %s", procedureInfo.ControlID, catalogId, evidence.PolicyRuleId)
-				failureReasons = append(failureReasons, "control data not found")
-			}
-		} else {
-			log.Printf("WARNING: Policy rule %s not found in procedures for catalog %s", evidence.PolicyRuleId, catalogId)
+		procedureInfo, ok := proceduresById[evidence.PolicyRuleId]
+		if !ok {
 			failureReasons = append(failureReasons, "policy rule not found")
+			continue
 		}
+
+		// Look up control data
+		ctrl, ok := catalogIndex.Control(procedureInfo.ControlID)
+		if !ok {
+			log.Printf("WARNING: Control data not found for control ID %s in catalog %s for policy %s", procedureInfo.ControlID, catalogId, evidence.PolicyRuleId)
+			failureReasons = append(failureReasons, "control data not found")
+			continue
+		}
+
+		matches = append(matches, api.Compliance{
+			Control: api.ComplianceControl{
+				Id:                     procedureInfo.RequirementID,
+				Category:               ctrl.Family.Title,
+				RemediationDescription: &procedureInfo.Documentation,
+				CatalogId:              catalogId,
+			},
+			Frameworks:       mapper.Frameworks(ctrl.Control),
+			Status:           m.statusMapping.Status(evidence.PolicyEvaluationStatus, catalogId, procedureInfo.ControlID, procedureInfo.RequirementID),
+			EnrichmentStatus: api.ComplianceEnrichmentStatusSuccess,
+		})
 	}
 
 	// Log final failure if no mapping was found
-	if len(failureReasons) > 0 {
+	if len(matches) == 0 && len(failureReasons) > 0 {
 		log.Printf("WARNING: Failed to map policy %s from engine %s. Reasons: %v", evidence.PolicyRuleId, evidence.PolicyEngineName, failureReasons)
 	}
 
-	return api.Compliance{
-		Status: api.ComplianceStatusUnknown,
-		Control: api.ComplianceControl{
-			Id:        "UNMAPPED",
-			CatalogId: "UNMAPPED",
-			Category:  "UNCATEGORIZED",
-		},
-		EnrichmentStatus: api.ComplianceEnrichmentStatusUnmapped,
-		Frameworks: api.ComplianceFrameworks{
-			Frameworks:   []string{},
-			Requirements: []string{},
-		},
-	}
-}
-
-// mapDecision maps a decision string to status and status ID.
-func (m *Mapper) mapDecision(status api.EvidencePolicyEvaluationStatus) api.ComplianceStatus {
-	switch status {
-	case api.Passed:
-		return api.ComplianceStatusCompliant
-	case api.Failed:
-		return api.ComplianceStatusNonCompliant
-	case api.NotRun, api.NotApplicable:
-		return api.ComplianceStatusNotApplicable
-	default:
-		return api.ComplianceStatusUnknown
-	}
+	return matches
 }
 
-// buildProceduresMap builds a map of procedure ID to procedure info.
-func (m *Mapper) buildProceduresMap(plans []layer4.AssessmentPlan) map[string]ProcedureInfo {
-	proceduresById := make(map[string]ProcedureInfo)
-
+// indexProcedures adds the procedures in plans to proceduresById.
+func indexProcedures(proceduresById map[string]ProcedureInfo, plans []layer4.AssessmentPlan) {
 	for _, plan := range plans {
 		for _, requirement := range plan.Assessments {
 			for _, procedure := range requirement.Procedures {
@@ -157,42 +284,4 @@ func (m *Mapper) buildProceduresMap(plans []layer4.AssessmentPlan) map[string]Pr
 			}
 		}
 	}
-
-	return proceduresById
-}
-
-// buildControlDataMap builds a map of control ID to control data.
-func (m *Mapper) buildControlDataMap(catalog layer2.Catalog) map[string]ControlData {
-	controlData := make(map[string]ControlData)
-
-	for _, family := range catalog.ControlFamilies {
-		for _, control := range family.Controls {
-			controlData[control.Id] = ControlData{
-				Mappings: control.GuidelineMappings,
-				Category: family.Title,
-			}
-		}
-	}
-
-	return controlData
-}
-
-// extractRequirements extracts requirement IDs from mappings.
-func (m *Mapper) extractRequirements(mappings []layer2.Mapping) []string {
-	var requirements []string
-	for _, mapping := range mappings {
-		for _, entry := range mapping.Entries {
-			requirements = append(requirements, entry.ReferenceId)
-		}
-	}
-	return requirements
-}
-
-// extractStandards extracts standard IDs from mappings.
-func (m *Mapper) extractStandards(mappings []layer2.Mapping) []string {
-	var standards []string
-	for _, mapping := range mappings {
-		standards = append(standards, mapping.ReferenceId)
-	}
-	return standards
 }
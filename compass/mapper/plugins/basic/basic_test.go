@@ -1,12 +1,14 @@
 package basic
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/ossf/gemara/layer2"
 	"github.com/ossf/gemara/layer4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/complytime/complybeacon/compass/api"
 	"github.com/complytime/complybeacon/compass/mapper"
@@ -47,6 +49,11 @@ func TestBasicMapper_MapWithPlans(t *testing.T) {
 			status:         api.NotApplicable,
 			expectedStatus: api.ComplianceStatusNotApplicable,
 		},
+		{
+			name:           "compliance status needs review defaults to unknown",
+			status:         api.NeedsReview,
+			expectedStatus: api.ComplianceStatusUnknown,
+		},
 		{
 			name:           "unmapped compliance status defaults to unknown",
 			status:         api.Unknown,
@@ -174,3 +181,230 @@ func TestBasicMapper_AddEvaluationPlan(t *testing.T) {
 		assert.Equal(t, "AC-2", basicMapper.plans["test-catalog"][1].Control.ReferenceId)
 	})
 }
+
+func TestBasicMapper_MapAfterChanges(t *testing.T) {
+	basicMapper := NewBasicMapper()
+	evidence := api.Evidence{
+		PolicyEngineName:       "test-policy-engine",
+		PolicyRuleId:           "AC-1",
+		PolicyEvaluationStatus: api.Passed,
+		Timestamp:              time.Now(),
+	}
+	scope := mapper.Scope{"test-catalog": benchmarkCatalog("test-catalog", 1, 1)}
+
+	// Nothing is mapped before plans are added.
+	compliance := basicMapper.Map(evidence, scope)
+	assert.Equal(t, api.ComplianceEnrichmentStatusUnmapped, compliance.EnrichmentStatus)
+
+	// Plans added after the first Map are used.
+	basicMapper.AddEvaluationPlan("test-catalog", benchmarkPlans("test-catalog", 1, 1)...)
+	evidence.PolicyRuleId = "proc-0-0"
+	compliance = basicMapper.Map(evidence, scope)
+	assert.Equal(t, api.ComplianceEnrichmentStatusSuccess, compliance.EnrichmentStatus)
+	assert.Equal(t, "CTRL-0-0.01", compliance.Control.Id)
+
+	// A replaced scope is used in place of the previously indexed one.
+	compliance = basicMapper.Map(evidence, mapper.Scope{})
+	assert.Equal(t, api.ComplianceEnrichmentStatusUnmapped, compliance.EnrichmentStatus)
+}
+
+func TestBasicMapper_MapAll(t *testing.T) {
+	basicMapper := NewBasicMapper()
+	scope := mapper.Scope{}
+	// The same procedure ID is mapped by three catalogs; one is not in scope.
+	for _, catalogID := range []string{"catalog-c", "catalog-a", "catalog-b", "catalog-missing"} {
+		if catalogID != "catalog-missing" {
+			scope[catalogID] = benchmarkCatalog(catalogID, 1, 1)
+		}
+		basicMapper.AddEvaluationPlan(catalogID, benchmarkPlans(catalogID, 1, 1)...)
+	}
+	evidence := api.Evidence{
+		PolicyEngineName:       "test-policy-engine",
+		PolicyRuleId:           "proc-0-0",
+		PolicyEvaluationStatus: api.Failed,
+		Timestamp:              time.Now(),
+	}
+
+	matches := basicMapper.MapAll(evidence, scope)
+	require.Len(t, matches, 3)
+	for i, catalogID := range []string{"catalog-a", "catalog-b", "catalog-c"} {
+		assert.Equal(t, catalogID, matches[i].Control.CatalogId)
+		assert.Equal(t, "CTRL-0-0.01", matches[i].Control.Id)
+		assert.Equal(t, api.ComplianceStatusNonCompliant, matches[i].Status)
+		assert.Equal(t, api.ComplianceEnrichmentStatusSuccess, matches[i].EnrichmentStatus)
+	}
+
+	// Map returns the first match.
+	assert.Equal(t, matches[0], basicMapper.Map(evidence, scope))
+
+	evidence.PolicyRuleId = "unknown"
+	assert.Empty(t, basicMapper.MapAll(evidence, scope))
+}
+
+func TestBasicMapper_CatalogPrecedence(t *testing.T) {
+	basicMapper := NewBasicMapper()
+	scope := mapper.Scope{}
+	for _, catalogID := range []string{"catalog-a", "catalog-b", "catalog-c"} {
+		scope[catalogID] = benchmarkCatalog(catalogID, 1, 1)
+		basicMapper.AddEvaluationPlan(catalogID, benchmarkPlans(catalogID, 1, 1)...)
+	}
+	// proc-0-1 is only mapped in catalog-a.
+	scope["catalog-a"] = benchmarkCatalog("catalog-a", 1, 2)
+	basicMapper.AddEvaluationPlan("catalog-a", benchmarkPlans("catalog-a", 1, 2)...)
+
+	evidence := api.Evidence{
+		PolicyEngineName:       "test-policy-engine",
+		PolicyRuleId:           "proc-0-0",
+		PolicyEvaluationStatus: api.Passed,
+		Timestamp:              time.Now(),
+	}
+
+	// Without precedence, catalogs are ordered by ID.
+	assert.Equal(t, "catalog-a", basicMapper.Map(evidence, scope).Control.CatalogId)
+
+	// The result is the same on every call.
+	basicMapper.SetCatalogPrecedence("catalog-c", "catalog-b")
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, "catalog-c", basicMapper.Map(evidence, scope).Control.CatalogId)
+	}
+
+	matches := basicMapper.MapAll(evidence, scope)
+	require.Len(t, matches, 3)
+	assert.Equal(t, "catalog-c", matches[0].Control.CatalogId)
+	assert.Equal(t, "catalog-b", matches[1].Control.CatalogId)
+	assert.Equal(t, "catalog-a", matches[2].Control.CatalogId)
+
+	assert.Equal(t, []mapper.Conflict{
+		{PolicyRuleID: "proc-0-0", CatalogIDs: []string{"catalog-c", "catalog-b", "catalog-a"}},
+	}, basicMapper.Conflicts())
+}
+
+func TestBasicMapper_Procedures(t *testing.T) {
+	basicMapper := NewBasicMapper()
+	basicMapper.AddEvaluationPlan("catalog-b", benchmarkPlans("catalog-b", 1, 1)...)
+	basicMapper.AddEvaluationPlan("catalog-a", benchmarkPlans("catalog-a", 1, 2)...)
+
+	procedureIDs := func() []string {
+		var ids []string
+		for _, procedure := range basicMapper.Procedures() {
+			ids = append(ids, procedure.CatalogID+"/"+procedure.PolicyRuleID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"catalog-a/proc-0-0", "catalog-a/proc-0-1", "catalog-b/proc-0-0"}, procedureIDs())
+
+	procedure := basicMapper.Procedures()[1]
+	assert.Equal(t, "CTRL-0-1", procedure.ControlID)
+	assert.Equal(t, "CTRL-0-1.01", procedure.RequirementID)
+
+	// Procedures follow the catalog precedence.
+	basicMapper.SetCatalogPrecedence("catalog-b")
+	assert.Equal(t, []string{"catalog-b/proc-0-0", "catalog-a/proc-0-0", "catalog-a/proc-0-1"}, procedureIDs())
+}
+
+// benchmarkCatalog builds a catalog with families*controls controls.
+func benchmarkCatalog(id string, families, controls int) layer2.Catalog {
+	catalog := layer2.Catalog{Metadata: layer2.Metadata{Id: id}}
+	for f := 0; f < families; f++ {
+		family := layer2.ControlFamily{Title: fmt.Sprintf("Family %d", f)}
+		for c := 0; c < controls; c++ {
+			controlID := fmt.Sprintf("CTRL-%d-%d", f, c)
+			family.Controls = append(family.Controls, layer2.Control{
+				Id: controlID,
+				AssessmentRequirements: []layer2.AssessmentRequirement{
+					{Id: controlID + ".01"},
+				},
+				GuidelineMappings: []layer2.Mapping{
+					{ReferenceId: "NIST-800-53", Entries: []layer2.MappingEntry{{ReferenceId: "AC-1"}}},
+				},
+			})
+		}
+		catalog.ControlFamilies = append(catalog.ControlFamilies, family)
+	}
+	return catalog
+}
+
+// benchmarkPlans builds one assessment plan with one procedure for every
+// control in a catalog built by benchmarkCatalog.
+func benchmarkPlans(catalogID string, families, controls int) []layer4.AssessmentPlan {
+	var plans []layer4.AssessmentPlan
+	for f := 0; f < families; f++ {
+		for c := 0; c < controls; c++ {
+			controlID := fmt.Sprintf("CTRL-%d-%d", f, c)
+			plans = append(plans, layer4.AssessmentPlan{
+				Control: layer4.Mapping{EntryId: controlID, ReferenceId: catalogID},
+				Assessments: []layer4.Assessment{
+					{
+						Requirement: layer4.Mapping{EntryId: controlID + ".01", ReferenceId: catalogID},
+						Procedures: []layer4.AssessmentProcedure{
+							{Id: fmt.Sprintf("proc-%d-%d", f, c)},
+						},
+					},
+				},
+			})
+		}
+	}
+	return plans
+}
+
+func benchmarkMapper(b *testing.B) (*Mapper, mapper.Scope, api.Evidence) {
+	b.Helper()
+	const families, controls = 50, 100
+
+	basicMapper := NewBasicMapper()
+	basicMapper.AddEvaluationPlan("bench-catalog", benchmarkPlans("bench-catalog", families, controls)...)
+	scope := mapper.Scope{"bench-catalog": benchmarkCatalog("bench-catalog", families, controls)}
+	evidence := api.Evidence{
+		PolicyEngineName:       "bench-engine",
+		PolicyRuleId:           fmt.Sprintf("proc-%d-%d", families-1, controls-1),
+		PolicyEvaluationStatus: api.Failed,
+		Timestamp:              time.Now(),
+	}
+	return basicMapper, scope, evidence
+}
+
+// BenchmarkBasicMapper_Map measures Map against a 5000 control catalog once
+// the scope has been indexed.
+func BenchmarkBasicMapper_Map(b *testing.B) {
+	basicMapper, scope, evidence := benchmarkMapper(b)
+	basicMapper.Map(evidence, scope)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		compliance := basicMapper.Map(evidence, scope)
+		if compliance.EnrichmentStatus != api.ComplianceEnrichmentStatusSuccess {
+			b.Fatalf("unexpected enrichment status %s", compliance.EnrichmentStatus)
+		}
+	}
+}
+
+// BenchmarkBasicMapper_MapUnindexedScope measures Map when every call sees a
+// new scope, which is the cost every request paid before scopes were indexed.
+func BenchmarkBasicMapper_MapUnindexedScope(b *testing.B) {
+	basicMapper, scope, evidence := benchmarkMapper(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fresh := mapper.Scope{"bench-catalog": scope["bench-catalog"]}
+		compliance := basicMapper.Map(evidence, fresh)
+		if compliance.EnrichmentStatus != api.ComplianceEnrichmentStatusSuccess {
+			b.Fatalf("unexpected enrichment status %s", compliance.EnrichmentStatus)
+		}
+	}
+}
+
+// BenchmarkBasicMapper_MapWithoutPlans measures Map for a mapper without
+// plans, as used for evidence from engines that have no plugin configured.
+func BenchmarkBasicMapper_MapWithoutPlans(b *testing.B) {
+	_, scope, evidence := benchmarkMapper(b)
+	fallback := NewBasicMapper()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fresh := mapper.Scope{"bench-catalog": scope["bench-catalog"]}
+		compliance := fallback.Map(evidence, fresh)
+		if compliance.EnrichmentStatus != api.ComplianceEnrichmentStatusUnmapped {
+			b.Fatalf("unexpected enrichment status %s", compliance.EnrichmentStatus)
+		}
+	}
+}
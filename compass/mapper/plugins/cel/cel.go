@@ -0,0 +1,242 @@
+// Package cel provides a mapper whose rules are CEL expressions over the
+// evidence and the raw policy engine output. It suits policy engines whose
+// rule IDs are generic and whose meaning is carried in the decision payload.
+package cel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	celgo "github.com/google/cel-go/cel"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+	"github.com/complytime/complybeacon/compass/mapper/plugins/basic"
+)
+
+// Type is the plugin type the CEL mapper is registered under.
+const Type mapper.Type = "cel"
+
+// costLimit bounds the work a single rule may do on one piece of evidence.
+const costLimit = 100000
+
+var _ mapper.MultiMapper = (*Mapper)(nil)
+
+func init() {
+	mapper.Register(Type, func(id mapper.ID, config mapper.Config) (mapper.Mapper, error) {
+		var cfg Config
+		if err := config.Decode(&cfg); err != nil {
+			return nil, err
+		}
+		return New(id, cfg.Rules...)
+	})
+}
+
+// Config is the plugin config of the CEL mapper.
+type Config struct {
+	// Rules are evaluated in order.
+	Rules []Rule `json:"rules"`
+}
+
+// Rule maps evidence for which Expression is true to a control or
+// assessment requirement.
+type Rule struct {
+	// ID names the rule in logs and errors.
+	ID string `json:"id"`
+	// Expression is a CEL expression returning a bool. It may use the
+	// variables evidence, holding the evidence fields as in the API, and
+	// rawData, holding the raw policy engine output.
+	Expression string `json:"expression"`
+	Catalog    string `json:"catalog"`
+	// Control is the control ID. It may be left out when Requirement is
+	// set.
+	Control string `json:"control"`
+	// Requirement is the assessment requirement ID.
+	Requirement string `json:"requirement"`
+	// Status overrides the compliance status derived from the evidence
+	// policy evaluation status. Exempt is reserved for exceptions.
+	Status        string `json:"status"`
+	Documentation string `json:"documentation"`
+}
+
+// Mapper maps evidence with CEL rules. Evidence that no rule matches is
+// mapped by policy rule ID with the evaluation plans added to the mapper, as
+// the basic mapper does.
+type Mapper struct {
+	*basic.Mapper
+	id    mapper.ID
+	rules []compiledRule
+	// scopeIndex holds the control lookup for the scope most recently
+	// passed to Map.
+	scopeIndex mapper.IndexCache
+}
+
+type compiledRule struct {
+	Rule
+	program celgo.Program
+	status  api.ComplianceStatus
+}
+
+// New compiles rules and returns a mapper using them.
+func New(id mapper.ID, rules ...Rule) (*Mapper, error) {
+	env, err := celgo.NewEnv(
+		celgo.Variable("evidence", celgo.MapType(celgo.StringType, celgo.DynType)),
+		celgo.Variable("rawData", celgo.MapType(celgo.StringType, celgo.DynType)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Mapper{Mapper: basic.NewBasicMapper(), id: id}
+	ids := make(map[string]bool)
+	for i, rule := range rules {
+		if rule.ID == "" {
+			return nil, fmt.Errorf("rule %d has no id", i)
+		}
+		if ids[rule.ID] {
+			return nil, fmt.Errorf("duplicate rule id %s", rule.ID)
+		}
+		ids[rule.ID] = true
+
+		compiled, err := compileRule(env, rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+		m.rules = append(m.rules, compiled)
+	}
+	return m, nil
+}
+
+func compileRule(env *celgo.Env, rule Rule) (compiledRule, error) {
+	compiled := compiledRule{Rule: rule}
+	if rule.Expression == "" {
+		return compiled, errors.New("expression is required")
+	}
+	if rule.Catalog == "" {
+		return compiled, errors.New("catalog is required")
+	}
+	if rule.Control == "" && rule.Requirement == "" {
+		return compiled, errors.New("control or requirement is required")
+	}
+	if rule.Status != "" {
+		status, err := mapper.ParseMappedStatus(rule.Status)
+		if err != nil {
+			return compiled, err
+		}
+		compiled.status = status
+	}
+
+	ast, issues := env.Compile(rule.Expression)
+	if issues.Err() != nil {
+		return compiled, issues.Err()
+	}
+	if ast.OutputType() != celgo.BoolType {
+		return compiled, fmt.Errorf("expression must return bool, not %s", ast.OutputType())
+	}
+	program, err := env.Program(ast, celgo.CostLimit(costLimit))
+	if err != nil {
+		return compiled, err
+	}
+	compiled.program = program
+	return compiled, nil
+}
+
+func (m *Mapper) PluginName() mapper.ID {
+	return m.id
+}
+
+func (m *Mapper) Map(evidence api.Evidence, scope mapper.Scope) api.Compliance {
+	if matches := m.MapAll(evidence, scope); len(matches) > 0 {
+		return matches[0]
+	}
+	return mapper.Unmapped()
+}
+
+// MapAll returns a finding for each rule that matches the evidence, in rule
+// order. When no rule matches, the evidence is mapped by its policy rule ID.
+func (m *Mapper) MapAll(evidence api.Evidence, scope mapper.Scope) []api.Compliance {
+	vars, err := variables(evidence)
+	if err != nil {
+		slog.Warn("cannot evaluate CEL rules",
+			slog.String("plugin_id", string(m.id)),
+			slog.String("error", err.Error()),
+		)
+		return m.Mapper.MapAll(evidence, scope)
+	}
+
+	index := m.scopeIndex.Get(scope)
+	matches := []api.Compliance{}
+	for _, rule := range m.rules {
+		out, _, err := rule.program.Eval(vars)
+		if err != nil {
+			// Rules commonly address fields that only some payloads have.
+			slog.Debug("CEL rule not evaluated",
+				slog.String("plugin_id", string(m.id)),
+				slog.String("rule_id", rule.ID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if matched, ok := out.Value().(bool); !ok || !matched {
+			continue
+		}
+
+		compliance, ok := rule.compliance(index)
+		if !ok {
+			continue
+		}
+		if compliance.Status == "" {
+			compliance.Status = m.Mapper.Status(evidence.PolicyEvaluationStatus, rule.Catalog, rule.Control, rule.Requirement)
+		}
+		matches = append(matches, compliance)
+	}
+
+	if len(matches) == 0 {
+		return m.Mapper.MapAll(evidence, scope)
+	}
+	return matches
+}
+
+// compliance returns the finding for evidence matched by rule, or false when
+// the rule's control is not in scope. The status is left empty unless the
+// rule sets one.
+func (rule compiledRule) compliance(index mapper.ScopeIndex) (api.Compliance, bool) {
+	compliance, err := mapper.Resolve(index, rule.Catalog, rule.Control, rule.Requirement)
+	if err != nil {
+		slog.Warn("control of CEL rule not found in scope",
+			slog.String("rule_id", rule.ID),
+			slog.String("error", err.Error()),
+		)
+		return api.Compliance{}, false
+	}
+
+	compliance.Status = rule.status
+	if rule.Documentation != "" {
+		documentation := rule.Documentation
+		compliance.Control.RemediationDescription = &documentation
+	}
+	return compliance, true
+}
+
+// variables returns the CEL variables for evidence.
+func variables(evidence api.Evidence) (map[string]any, error) {
+	content, err := json.Marshal(evidence)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, err
+	}
+
+	rawData := map[string]any{}
+	if evidence.RawData != nil {
+		rawData = *evidence.RawData
+	}
+	return map[string]any{
+		"evidence": fields,
+		"rawData":  rawData,
+	}, nil
+}
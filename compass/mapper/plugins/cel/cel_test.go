@@ -0,0 +1,204 @@
+package cel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ossf/gemara/layer2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/internal/mappertest"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+func testScope() mapper.Scope {
+	scope := mappertest.Scope("OSPS-B", "OSPS-VM-05", "OSPS-VM-06")
+	// Findings for OSPS-VM-05 carry the frameworks it maps to.
+	scope["OSPS-B"].ControlFamilies[0].Controls[0].GuidelineMappings = []layer2.Mapping{
+		{ReferenceId: "NIST-800-53", Entries: []layer2.MappingEntry{{ReferenceId: "RA-5"}}},
+	}
+	return scope
+}
+
+func evidenceWith(ruleID string, status api.EvidencePolicyEvaluationStatus, rawData map[string]interface{}) api.Evidence {
+	return api.Evidence{
+		PolicyEngineName:       "scanner",
+		PolicyRuleId:           ruleID,
+		PolicyEvaluationStatus: status,
+		RawData:                &rawData,
+		Timestamp:              time.Now(),
+	}
+}
+
+func TestMapper_MapAll(t *testing.T) {
+	rules := []Rule{
+		{
+			ID:          "critical-cves",
+			Expression:  `evidence.policyRuleId == "deny" && rawData.severity == "critical"`,
+			Catalog:     "OSPS-B",
+			Requirement: "OSPS-VM-05.01",
+			Status:      "Non-Compliant",
+		},
+		{
+			ID:         "unsigned",
+			Expression: `has(rawData.signature) && rawData.signature.verified == false`,
+			Catalog:    "OSPS-B",
+			Control:    "OSPS-VM-06",
+		},
+	}
+	m, err := New("scanner", rules...)
+	require.NoError(t, err)
+	m.AddEvaluationPlan("OSPS-B", mappertest.Plan("OSPS-B", "OSPS-VM-06", "sbom_present"))
+
+	tests := []struct {
+		name     string
+		evidence api.Evidence
+		expected []string
+		status   api.ComplianceStatus
+	}{
+		{
+			name:     "rule status overrides evaluation",
+			evidence: evidenceWith("deny", api.Passed, map[string]interface{}{"severity": "critical"}),
+			expected: []string{"OSPS-VM-05.01"},
+			status:   api.ComplianceStatusNonCompliant,
+		},
+		{
+			name:     "status from evaluation",
+			evidence: evidenceWith("deny", api.Failed, map[string]interface{}{"signature": map[string]interface{}{"verified": false}}),
+			expected: []string{"OSPS-VM-06"},
+			status:   api.ComplianceStatusNonCompliant,
+		},
+		{
+			name: "several rules match",
+			evidence: evidenceWith("deny", api.Failed, map[string]interface{}{
+				"severity":  "critical",
+				"signature": map[string]interface{}{"verified": false},
+			}),
+			expected: []string{"OSPS-VM-05.01", "OSPS-VM-06"},
+			status:   api.ComplianceStatusNonCompliant,
+		},
+		{
+			name:     "missing field does not match",
+			evidence: evidenceWith("deny", api.Failed, map[string]interface{}{}),
+			expected: []string{},
+		},
+		{
+			name:     "falls back to evaluation plans",
+			evidence: evidenceWith("sbom_present", api.Passed, nil),
+			expected: []string{"OSPS-VM-06.01"},
+			status:   api.ComplianceStatusCompliant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := m.MapAll(tt.evidence, testScope())
+			ids := []string{}
+			for _, match := range matches {
+				ids = append(ids, match.Control.Id)
+				assert.Equal(t, "OSPS-B", match.Control.CatalogId)
+				assert.Equal(t, "Test Family", match.Control.Category)
+				assert.Equal(t, tt.status, match.Status)
+				assert.Equal(t, api.ComplianceEnrichmentStatusSuccess, match.EnrichmentStatus)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+
+	t.Run("frameworks from the catalog", func(t *testing.T) {
+		compliance := m.Map(evidenceWith("deny", api.Failed, map[string]interface{}{"severity": "critical"}), testScope())
+		assert.Equal(t, []string{"NIST-800-53"}, compliance.Frameworks.Frameworks)
+		assert.Equal(t, []string{"RA-5"}, compliance.Frameworks.Requirements)
+	})
+
+	t.Run("unmapped", func(t *testing.T) {
+		compliance := m.Map(evidenceWith("allow", api.Passed, nil), testScope())
+		assert.Equal(t, api.ComplianceEnrichmentStatusUnmapped, compliance.EnrichmentStatus)
+	})
+
+	t.Run("control not in scope", func(t *testing.T) {
+		m, err := New("scanner", Rule{ID: "r", Expression: "true", Catalog: "OSPS-B", Control: "OSPS-XX-01"})
+		require.NoError(t, err)
+		assert.Empty(t, m.MapAll(evidenceWith("deny", api.Failed, nil), testScope()))
+	})
+}
+
+func TestNew_InvalidRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []Rule
+		wantErr string
+	}{
+		{
+			name:    "missing id",
+			rules:   []Rule{{Expression: "true", Catalog: "OSPS-B", Control: "OSPS-VM-05"}},
+			wantErr: "rule 0 has no id",
+		},
+		{
+			name: "duplicate id",
+			rules: []Rule{
+				{ID: "r", Expression: "true", Catalog: "OSPS-B", Control: "OSPS-VM-05"},
+				{ID: "r", Expression: "true", Catalog: "OSPS-B", Control: "OSPS-VM-05"},
+			},
+			wantErr: "duplicate rule id r",
+		},
+		{
+			name:    "missing control",
+			rules:   []Rule{{ID: "r", Expression: "true", Catalog: "OSPS-B"}},
+			wantErr: "control or requirement is required",
+		},
+		{
+			name:    "syntax error",
+			rules:   []Rule{{ID: "r", Expression: "rawData.severity ==", Catalog: "OSPS-B", Control: "OSPS-VM-05"}},
+			wantErr: "rule r: ERROR",
+		},
+		{
+			name:    "not a bool",
+			rules:   []Rule{{ID: "r", Expression: `"critical"`, Catalog: "OSPS-B", Control: "OSPS-VM-05"}},
+			wantErr: "expression must return bool",
+		},
+		{
+			name:    "unknown variable",
+			rules:   []Rule{{ID: "r", Expression: "payload.ok", Catalog: "OSPS-B", Control: "OSPS-VM-05"}},
+			wantErr: "undeclared reference to 'payload'",
+		},
+		{
+			name:    "unknown status",
+			rules:   []Rule{{ID: "r", Expression: "true", Catalog: "OSPS-B", Control: "OSPS-VM-05", Status: "Failed"}},
+			wantErr: `unknown compliance status "Failed"`,
+		},
+		{
+			name:    "exempt status",
+			rules:   []Rule{{ID: "r", Expression: "true", Catalog: "OSPS-B", Control: "OSPS-VM-05", Status: "Exempt"}},
+			wantErr: `"Exempt" is reserved for exceptions`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("scanner", tt.rules...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	mpr, err := mapper.New(Type, "scanner", mapper.Config{
+		"rules": []any{
+			map[string]any{
+				"id":          "critical",
+				"expression":  `rawData.severity == "critical"`,
+				"catalog":     "OSPS-B",
+				"requirement": "OSPS-VM-05.01",
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, mapper.ID("scanner"), mpr.PluginName())
+
+	_, err = mapper.New(Type, "scanner", mapper.Config{"rule": []any{}})
+	require.Error(t, err)
+}
@@ -0,0 +1,226 @@
+// Package conforma provides a mapper for Conforma (Enterprise Contract)
+// validation reports.
+package conforma
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+	"github.com/complytime/complybeacon/compass/mapper/plugins/basic"
+)
+
+// Type is the plugin type the Conforma mapper is registered under.
+const Type mapper.Type = "conforma"
+
+var _ mapper.MultiMapper = (*Mapper)(nil)
+
+func init() {
+	mapper.Register(Type, func(id mapper.ID, config mapper.Config) (mapper.Mapper, error) {
+		// The Conforma mapper has no settings of its own.
+		if err := config.Decode(&struct{}{}); err != nil {
+			return nil, err
+		}
+		return New(id), nil
+	})
+}
+
+// Report is the part of a Conforma validation report used for mapping, as
+// written by "ec validate" with the JSON or YAML output format.
+type Report struct {
+	Success    bool        `json:"success"`
+	Components []Component `json:"components"`
+}
+
+// Component holds the results for one validated component.
+type Component struct {
+	Name           string   `json:"name"`
+	ContainerImage string   `json:"containerImage"`
+	Violations     []Result `json:"violations"`
+	Warnings       []Result `json:"warnings"`
+	Successes      []Result `json:"successes"`
+	Success        bool     `json:"success"`
+}
+
+// Target returns the ID reported for findings on the component: its
+// container image, or its name when there is none.
+func (c Component) Target() string {
+	if c.ContainerImage != "" {
+		return c.ContainerImage
+	}
+	return c.Name
+}
+
+// Result is a single policy rule result.
+type Result struct {
+	Message  string         `json:"msg"`
+	Metadata ResultMetadata `json:"metadata"`
+}
+
+// ResultMetadata describes the rule that produced a result.
+type ResultMetadata struct {
+	// Code is the rule code, in the form "package.rule".
+	Code        string `json:"code"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Solution    string `json:"solution"`
+}
+
+// severity orders the results a component can have for one control. A worse
+// result for the same control replaces a better one.
+var severity = map[api.EvidencePolicyEvaluationStatus]int{
+	api.Passed:      0,
+	api.NeedsReview: 1,
+	api.Failed:      2,
+}
+
+// defaultResults gives the compliance status of evaluation results that
+// Conforma reports differently from mapper.StatusFromEvaluation.
+var defaultResults = mapper.StatusTable{
+	api.NeedsReview: api.ComplianceStatusNeedsReview,
+}
+
+// Mapper maps Conforma validation reports carried as evidence rawData. Each
+// rule code is mapped through the assessment plans added to the mapper, and
+// each finding names the component it applies to as its target. Evidence
+// without a report is mapped by its policy rule ID, as the basic mapper does.
+type Mapper struct {
+	*basic.Mapper
+	id mapper.ID
+}
+
+// New returns a Conforma mapper for the plugin configured with id.
+func New(id mapper.ID) *Mapper {
+	m := &Mapper{Mapper: basic.NewBasicMapper(), id: id}
+	m.SetStatusMapping(mapper.StatusMapping{})
+	return m
+}
+
+// SetStatusMapping replaces the status mapping. Evaluation results that
+// mapping does not list keep the plugin defaults, by which a warning needs review.
+func (m *Mapper) SetStatusMapping(mapping mapper.StatusMapping) {
+	m.Mapper.SetStatusMapping(mapping.WithDefaults(defaultResults))
+}
+
+func (m *Mapper) PluginName() mapper.ID {
+	return m.id
+}
+
+func (m *Mapper) Map(evidence api.Evidence, scope mapper.Scope) api.Compliance {
+	if matches := m.MapAll(evidence, scope); len(matches) > 0 {
+		return matches[0]
+	}
+	return mapper.Unmapped()
+}
+
+// MapAll returns a finding for each component and control that the report's
+// rule codes map to, in component order. Violations are mapped as Failed,
+// warnings as Needs Review, and successes as Passed, through the plugin's
+// status mapping. When several rules of one component map to the same
+// control, the worst result is reported.
+func (m *Mapper) MapAll(evidence api.Evidence, scope mapper.Scope) []api.Compliance {
+	if evidence.RawData == nil {
+		return m.Mapper.MapAll(evidence, scope)
+	}
+	report, err := ParseReport(*evidence.RawData)
+	if err != nil {
+		return m.Mapper.MapAll(evidence, scope)
+	}
+
+	matches := []api.Compliance{}
+	for _, component := range report.Components {
+		target := component.Target()
+		positions := make(map[string]int)
+		reported := make(map[string]api.EvidencePolicyEvaluationStatus)
+		add := func(results []Result, evaluationResult api.EvidencePolicyEvaluationStatus) {
+			for _, result := range results {
+				for _, finding := range m.mapResult(evidence, scope, result, evaluationResult) {
+					finding.TargetId = &target
+					key := finding.Control.CatalogId + "/" + finding.Control.Id
+					if i, ok := positions[key]; ok {
+						if severity[evaluationResult] > severity[reported[key]] {
+							matches[i] = finding
+							reported[key] = evaluationResult
+						}
+						continue
+					}
+					positions[key] = len(matches)
+					reported[key] = evaluationResult
+					matches = append(matches, finding)
+				}
+			}
+		}
+		add(component.Violations, api.Failed)
+		add(component.Warnings, api.NeedsReview)
+		add(component.Successes, api.Passed)
+	}
+	return matches
+}
+
+// mapResult maps the rule code of result through the assessment plans, first
+// as the full code and then as the rule name without its package. The
+// compliance status follows evaluationResult.
+func (m *Mapper) mapResult(evidence api.Evidence, scope mapper.Scope, result Result, evaluationResult api.EvidencePolicyEvaluationStatus) []api.Compliance {
+	code := result.Metadata.Code
+	if code == "" {
+		return nil
+	}
+	candidates := []string{code}
+	if i := strings.LastIndex(code, "."); i >= 0 {
+		candidates = append(candidates, code[i+1:])
+	}
+
+	evidence.PolicyEvaluationStatus = evaluationResult
+	for _, procedureID := range candidates {
+		evidence.PolicyRuleId = procedureID
+		findings := m.Mapper.MapAll(evidence, scope)
+		if len(findings) == 0 {
+			continue
+		}
+		description := result.Metadata.Solution
+		if evaluationResult != api.Passed && description == "" {
+			description = result.Message
+		}
+		for i := range findings {
+			if description != "" {
+				findings[i].Control.RemediationDescription = &description
+			}
+		}
+		return findings
+	}
+	return nil
+}
+
+// ParseReport reads a Conforma validation report from rawData. A single
+// component, as found in the components list, is read as a report holding
+// only that component.
+func ParseReport(rawData map[string]interface{}) (Report, error) {
+	content, err := json.Marshal(rawData)
+	if err != nil {
+		return Report{}, err
+	}
+
+	var report Report
+	if _, ok := rawData["components"]; ok {
+		if err := json.Unmarshal(content, &report); err != nil {
+			return Report{}, err
+		}
+		return report, nil
+	}
+
+	_, hasViolations := rawData["violations"]
+	_, hasWarnings := rawData["warnings"]
+	_, hasSuccesses := rawData["successes"]
+	if !hasViolations && !hasWarnings && !hasSuccesses {
+		return Report{}, errors.New("not a Conforma report: no components or results")
+	}
+	var component Component
+	if err := json.Unmarshal(content, &component); err != nil {
+		return Report{}, err
+	}
+	report.Components = []Component{component}
+	report.Success = component.Success
+	return report, nil
+}
@@ -0,0 +1,147 @@
+package conforma
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/internal/mappertest"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+const (
+	checkoutImage = "quay.io/example/checkout@sha256:1f0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d4c3b2a1f0e"
+	paymentsImage = "quay.io/example/payments@sha256:9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b"
+)
+
+func loadReport(t *testing.T) map[string]interface{} {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", "report.json"))
+	require.NoError(t, err)
+	var rawData map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &rawData))
+	return rawData
+}
+
+func testScope() mapper.Scope {
+	return mappertest.Scope("OSPS-B", "OSPS-BR-01", "OSPS-BR-02", "OSPS-BR-03")
+}
+
+func newTestMapper() *Mapper {
+	m := New("conforma")
+	m.AddEvaluationPlan("OSPS-B",
+		mappertest.Plan("OSPS-B", "OSPS-BR-01", "tasks.required_tasks_found", "unsupported_bundle"),
+		mappertest.Plan("OSPS-B", "OSPS-BR-02", "builtin.image.signature_check"),
+		mappertest.Plan("OSPS-B", "OSPS-BR-03", "attestation_type.known_attestation_type"),
+	)
+	return m
+}
+
+func TestMapper_MapAll(t *testing.T) {
+	type finding struct {
+		target  string
+		control string
+		status  api.ComplianceStatus
+	}
+
+	evidence := api.Evidence{
+		PolicyEngineName:       "conforma",
+		PolicyRuleId:           "ec-validate",
+		PolicyEvaluationStatus: api.Failed,
+		Timestamp:              time.Now(),
+	}
+	rawData := loadReport(t)
+	evidence.RawData = &rawData
+
+	matches := newTestMapper().MapAll(evidence, testScope())
+	var actual []finding
+	for _, match := range matches {
+		require.NotNil(t, match.TargetId)
+		actual = append(actual, finding{
+			target:  *match.TargetId,
+			control: match.Control.Id,
+			status:  match.Status,
+		})
+	}
+
+	// The checkout warning maps to OSPS-BR-01 by its rule name, but the
+	// violation for the same control is worse. The source code reference
+	// success has no procedure.
+	assert.Equal(t, []finding{
+		{target: checkoutImage, control: "OSPS-BR-01.01", status: api.ComplianceStatusNonCompliant},
+		{target: checkoutImage, control: "OSPS-BR-02.01", status: api.ComplianceStatusNonCompliant},
+		{target: checkoutImage, control: "OSPS-BR-03.01", status: api.ComplianceStatusCompliant},
+		{target: paymentsImage, control: "OSPS-BR-01.01", status: api.ComplianceStatusCompliant},
+		{target: paymentsImage, control: "OSPS-BR-02.01", status: api.ComplianceStatusCompliant},
+	}, actual)
+
+	t.Run("remediation from solution or message", func(t *testing.T) {
+		require.NotNil(t, matches[0].Control.RemediationDescription)
+		assert.Equal(t, "Make sure all required tasks are in the build pipeline.", *matches[0].Control.RemediationDescription)
+		require.NotNil(t, matches[1].Control.RemediationDescription)
+		assert.Equal(t, "Image signature does not match the expected identity", *matches[1].Control.RemediationDescription)
+	})
+}
+
+func TestMapper_MapAllSingleComponent(t *testing.T) {
+	rawData := map[string]interface{}{
+		"name": "checkout",
+		"warnings": []any{
+			map[string]any{"msg": "bundle expires soon", "metadata": map[string]any{"code": "tasks.unsupported_bundle"}},
+		},
+	}
+	evidence := api.Evidence{
+		PolicyEngineName:       "conforma",
+		PolicyRuleId:           "ec-validate",
+		PolicyEvaluationStatus: api.Passed,
+		RawData:                &rawData,
+		Timestamp:              time.Now(),
+	}
+
+	compliance := newTestMapper().Map(evidence, testScope())
+	assert.Equal(t, "OSPS-BR-01.01", compliance.Control.Id)
+	assert.Equal(t, api.ComplianceStatusNeedsReview, compliance.Status)
+	require.NotNil(t, compliance.TargetId)
+	assert.Equal(t, "checkout", *compliance.TargetId)
+
+	t.Run("status mapping", func(t *testing.T) {
+		m := newTestMapper()
+		m.SetStatusMapping(mapper.StatusMapping{
+			Results: mapper.StatusTable{api.NeedsReview: api.ComplianceStatusNonCompliant},
+		})
+		assert.Equal(t, api.ComplianceStatusNonCompliant, m.Map(evidence, testScope()).Status)
+	})
+}
+
+func TestMapper_MapWithoutReport(t *testing.T) {
+	evidence := api.Evidence{
+		PolicyEngineName:       "conforma",
+		PolicyRuleId:           "builtin.image.signature_check",
+		PolicyEvaluationStatus: api.Passed,
+		Timestamp:              time.Now(),
+	}
+
+	compliance := newTestMapper().Map(evidence, testScope())
+	assert.Equal(t, "OSPS-BR-02.01", compliance.Control.Id)
+	assert.Equal(t, api.ComplianceStatusCompliant, compliance.Status)
+	assert.Nil(t, compliance.TargetId)
+}
+
+func TestParseReport(t *testing.T) {
+	report, err := ParseReport(loadReport(t))
+	require.NoError(t, err)
+	assert.False(t, report.Success)
+	require.Len(t, report.Components, 2)
+	assert.Equal(t, checkoutImage, report.Components[0].Target())
+	assert.Len(t, report.Components[0].Violations, 2)
+	assert.Equal(t, "tasks.required_tasks_found", report.Components[0].Violations[0].Metadata.Code)
+
+	_, err = ParseReport(map[string]interface{}{"status": "failure"})
+	require.Error(t, err)
+}
@@ -0,0 +1,501 @@
+// Package external runs mappers as separate plugin processes. Compass starts
+// the plugin binary and talks to it over the gRPC protocol defined by the
+// compass.mapper.v1 package, using hashicorp/go-plugin for the handshake and
+// process management. Plugin binaries call Serve with their mapper.
+//
+// A plugin that exits or fails its health check is restarted, and the
+// evaluation plans and scope it was given are sent to it again.
+package external
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/ossf/gemara/layer4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/api/mapperv1"
+	"github.com/complytime/complybeacon/compass/internal/protoconv"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// Type is the plugin type external mappers are registered under.
+const Type mapper.Type = "external"
+
+const (
+	defaultHealthInterval = 10 * time.Second
+	defaultTimeout        = 5 * time.Second
+	// maxRestartBackoff bounds the wait between attempts to restart a
+	// plugin that keeps failing to start.
+	maxRestartBackoff = time.Minute
+	// maxPrepareAttempts bounds how often a call starts the plugin or sends
+	// it the scope before giving up.
+	maxPrepareAttempts = 3
+)
+
+var (
+	_ mapper.MultiMapper = (*Mapper)(nil)
+	_ io.Closer          = (*Mapper)(nil)
+)
+
+func init() {
+	mapper.Register(Type, func(id mapper.ID, config mapper.Config) (mapper.Mapper, error) {
+		var cfg Config
+		if err := config.Decode(&cfg); err != nil {
+			return nil, err
+		}
+		return New(id, cfg)
+	})
+}
+
+// Config holds the settings of an external plugin.
+type Config struct {
+	// Command is the path of the plugin binary.
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	// Checksum is the hex encoded SHA-256 of the plugin binary. When set,
+	// the binary is verified each time it is started.
+	Checksum string `json:"checksum"`
+	// HealthInterval is how often the plugin is checked, as a Go duration.
+	// It defaults to 10s.
+	HealthInterval string `json:"health-interval"`
+	// Timeout bounds each call to the plugin, as a Go duration. It defaults
+	// to 5s.
+	Timeout string `json:"timeout"`
+}
+
+// evaluationPlan records plans sent to the plugin so they can be sent again
+// after a restart.
+type evaluationPlan struct {
+	catalogID string
+	plans     []byte
+}
+
+// Mapper maps evidence by calling a plugin process. Calls that fail, for
+// example because the plugin crashed, return an Unknown enrichment status
+// and the plugin is restarted.
+type Mapper struct {
+	id             mapper.ID
+	command        string
+	args           []string
+	checksum       []byte
+	healthInterval time.Duration
+	timeout        time.Duration
+
+	// mu guards the fields below. Calls to the plugin hold it for reading;
+	// starting the plugin and changing its plans or scope hold it for
+	// writing.
+	mu     sync.RWMutex
+	client *plugin.Client
+	rpc    mapperv1.MapperServiceClient
+	plans  []evaluationPlan
+	// scope is the scope last sent to the running plugin, identified by the
+	// map itself: scopes are replaced rather than modified.
+	scope    mapper.Scope
+	scopeSet bool
+	// retryAt delays restarts of a plugin that failed to start.
+	retryAt time.Time
+	backoff time.Duration
+	closed  bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New starts the plugin configured with cfg for the plugin configured with
+// id. It fails if the plugin cannot be started.
+func New(id mapper.ID, cfg Config) (*Mapper, error) {
+	if cfg.Command == "" {
+		return nil, errors.New("command is required")
+	}
+	m := &Mapper{
+		id:             id,
+		command:        cfg.Command,
+		args:           cfg.Args,
+		healthInterval: defaultHealthInterval,
+		timeout:        defaultTimeout,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	if cfg.Checksum != "" {
+		checksum, err := hex.DecodeString(cfg.Checksum)
+		if err != nil || len(checksum) != sha256.Size {
+			return nil, fmt.Errorf("checksum %q is not a hex encoded SHA-256", cfg.Checksum)
+		}
+		m.checksum = checksum
+	}
+	var err error
+	if cfg.HealthInterval != "" {
+		if m.healthInterval, err = parsePositiveDuration(cfg.HealthInterval); err != nil {
+			return nil, fmt.Errorf("health-interval: %w", err)
+		}
+	}
+	if cfg.Timeout != "" {
+		if m.timeout, err = parsePositiveDuration(cfg.Timeout); err != nil {
+			return nil, fmt.Errorf("timeout: %w", err)
+		}
+	}
+
+	m.mu.Lock()
+	err = m.start()
+	m.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	go m.watch()
+	return m, nil
+}
+
+func parsePositiveDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s is not positive", value)
+	}
+	return d, nil
+}
+
+func (m *Mapper) PluginName() mapper.ID {
+	return m.id
+}
+
+func (m *Mapper) Map(evidence api.Evidence, scope mapper.Scope) api.Compliance {
+	findings, err := m.call(evidence, scope, false)
+	if err != nil {
+		return failed()
+	}
+	if len(findings) == 0 {
+		return mapper.Unmapped()
+	}
+	return findings[0]
+}
+
+// MapAll asks the plugin for every matching control. Plugins whose mapper
+// is not a MultiMapper return at most one.
+func (m *Mapper) MapAll(evidence api.Evidence, scope mapper.Scope) []api.Compliance {
+	findings, err := m.call(evidence, scope, true)
+	if err != nil {
+		return []api.Compliance{}
+	}
+	return findings
+}
+
+// AddEvaluationPlan sends plans to the plugin and records them for restarts.
+// Failures are logged, as the plugin receives the recorded plans again when
+// it is restarted.
+func (m *Mapper) AddEvaluationPlan(catalogId string, plans ...layer4.AssessmentPlan) {
+	content, err := json.Marshal(plans)
+	if err != nil {
+		slog.Error("failed to encode evaluation plans for plugin",
+			slog.String("plugin_id", string(m.id)),
+			slog.String("catalog_id", catalogId),
+			slog.String("err", err.Error()),
+		)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	plan := evaluationPlan{catalogID: catalogId, plans: content}
+	m.plans = append(m.plans, plan)
+	if m.rpc == nil {
+		return
+	}
+	if err := m.sendPlan(plan); err != nil {
+		slog.Error("failed to send evaluation plans to plugin",
+			slog.String("plugin_id", string(m.id)),
+			slog.String("catalog_id", catalogId),
+			slog.String("err", err.Error()),
+		)
+		m.kill()
+	}
+}
+
+// Close stops the health checks and the plugin process.
+func (m *Mapper) Close() error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	m.closed = true
+	m.kill()
+	m.mu.Unlock()
+
+	close(m.stop)
+	<-m.done
+	return nil
+}
+
+// call maps evidence with the plugin, first making sure the plugin is
+// running and has the given scope.
+func (m *Mapper) call(evidence api.Evidence, scope mapper.Scope, all bool) ([]api.Compliance, error) {
+	in, err := protoconv.EvidenceToProto(evidence)
+	if err != nil {
+		return nil, m.logFailure(evidence, fmt.Errorf("encoding evidence: %w", err))
+	}
+
+	m.mu.RLock()
+	for attempt := 0; !m.ready(scope); attempt++ {
+		m.mu.RUnlock()
+		// Another call may restart the plugin between prepare and the
+		// read lock; give up if that keeps happening.
+		if attempt == maxPrepareAttempts {
+			return nil, m.logFailure(evidence, errors.New("plugin is not ready"))
+		}
+		if err := m.prepare(scope); err != nil {
+			return nil, m.logFailure(evidence, err)
+		}
+		m.mu.RLock()
+	}
+	client := m.client
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	response, err := m.rpc.Map(ctx, &mapperv1.MapRequest{Evidence: in, All: all})
+	m.mu.RUnlock()
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			// The plugin may have crashed before go-plugin noticed; stop it
+			// so that the next call starts a new one.
+			m.mu.Lock()
+			if m.client == client {
+				m.kill()
+			}
+			m.mu.Unlock()
+		}
+		return nil, m.logFailure(evidence, err)
+	}
+
+	findings := make([]api.Compliance, 0, len(response.GetCompliance()))
+	for _, compliance := range response.GetCompliance() {
+		findings = append(findings, protoconv.ComplianceFromProto(compliance))
+	}
+	return findings, nil
+}
+
+// ready reports whether the plugin is running with scope. The caller must
+// hold mu.
+func (m *Mapper) ready(scope mapper.Scope) bool {
+	return m.rpc != nil && !m.client.Exited() && m.scopeSet && sameScope(m.scope, scope)
+}
+
+// prepare starts the plugin if it is not running and sends it scope.
+func (m *Mapper) prepare(scope mapper.Scope) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return errors.New("plugin is closed")
+	}
+	if m.rpc == nil || m.client.Exited() {
+		if err := m.restart("plugin is not running"); err != nil {
+			return err
+		}
+	}
+	if m.scopeSet && sameScope(m.scope, scope) {
+		return nil
+	}
+
+	content, err := json.Marshal(scope)
+	if err != nil {
+		return fmt.Errorf("encoding scope: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	if _, err := m.rpc.SetScope(ctx, &mapperv1.SetScopeRequest{Catalogs: content}); err != nil {
+		m.kill()
+		return fmt.Errorf("sending scope: %w", err)
+	}
+	m.scope = scope
+	m.scopeSet = true
+	return nil
+}
+
+// sameScope reports whether a and b are the same scope map.
+func sameScope(a, b mapper.Scope) bool {
+	return reflect.ValueOf(a).UnsafePointer() == reflect.ValueOf(b).UnsafePointer()
+}
+
+// watch checks the health of the plugin every health interval and restarts
+// it when it has exited or does not respond.
+func (m *Mapper) watch() {
+	defer close(m.done)
+	ticker := time.NewTicker(m.healthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+		}
+
+		m.mu.RLock()
+		healthy := m.healthy()
+		m.mu.RUnlock()
+		if healthy {
+			continue
+		}
+
+		m.mu.Lock()
+		if !m.closed {
+			if err := m.restart("health check failed"); err != nil {
+				slog.Error("failed to restart plugin",
+					slog.String("plugin_id", string(m.id)),
+					slog.String("err", err.Error()),
+				)
+			}
+		}
+		m.mu.Unlock()
+	}
+}
+
+// healthy pings the plugin. The caller must hold mu.
+func (m *Mapper) healthy() bool {
+	if m.closed {
+		return true
+	}
+	if m.rpc == nil || m.client.Exited() {
+		return false
+	}
+	protocol, err := m.client.Client()
+	if err != nil {
+		return false
+	}
+	return protocol.Ping() == nil
+}
+
+// restart replaces the plugin process and sends it the recorded plans. The
+// scope is sent again by the next call. The caller must hold mu.
+func (m *Mapper) restart(reason string) error {
+	if time.Now().Before(m.retryAt) {
+		return fmt.Errorf("plugin failed to start; retrying after %s", m.retryAt.Format(time.RFC3339))
+	}
+	slog.Warn("restarting plugin",
+		slog.String("plugin_id", string(m.id)),
+		slog.String("reason", reason),
+	)
+	m.kill()
+	if err := m.start(); err != nil {
+		if m.backoff == 0 {
+			m.backoff = time.Second
+		} else {
+			m.backoff = min(2*m.backoff, maxRestartBackoff)
+		}
+		m.retryAt = time.Now().Add(m.backoff)
+		return err
+	}
+	m.backoff = 0
+	m.retryAt = time.Time{}
+	return nil
+}
+
+// start launches the plugin process and sends it the recorded plans. The
+// caller must hold mu.
+func (m *Mapper) start() error {
+	config := &plugin.ClientConfig{
+		HandshakeConfig:  Handshake,
+		Plugins:          plugin.PluginSet{pluginKey: &grpcPlugin{}},
+		Cmd:              exec.Command(m.command, m.args...),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		// Plugin output is passed on as written rather than through
+		// go-plugin's logger.
+		Logger: hclog.NewNullLogger(),
+		Stderr: os.Stderr,
+	}
+	if m.checksum != nil {
+		config.SecureConfig = &plugin.SecureConfig{Checksum: m.checksum, Hash: sha256.New()}
+	}
+
+	client := plugin.NewClient(config)
+	protocol, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return fmt.Errorf("starting plugin %s: %w", m.command, err)
+	}
+	raw, err := protocol.Dispense(pluginKey)
+	if err != nil {
+		client.Kill()
+		return fmt.Errorf("starting plugin %s: %w", m.command, err)
+	}
+	m.client = client
+	m.rpc = raw.(mapperv1.MapperServiceClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	name, err := m.rpc.PluginName(ctx, &mapperv1.PluginNameRequest{})
+	if err != nil {
+		m.kill()
+		return fmt.Errorf("starting plugin %s: %w", m.command, err)
+	}
+	for _, plan := range m.plans {
+		if err := m.sendPlan(plan); err != nil {
+			m.kill()
+			return fmt.Errorf("sending evaluation plans for %s: %w", plan.catalogID, err)
+		}
+	}
+
+	slog.Info("plugin started",
+		slog.String("plugin_id", string(m.id)),
+		slog.String("command", m.command),
+		slog.String("plugin_name", name.GetName()),
+		slog.Int("protocol_version", client.NegotiatedVersion()),
+	)
+	return nil
+}
+
+// sendPlan sends recorded plans to the running plugin. The caller must hold
+// mu.
+func (m *Mapper) sendPlan(plan evaluationPlan) error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	_, err := m.rpc.AddEvaluationPlan(ctx, &mapperv1.AddEvaluationPlanRequest{
+		CatalogId: plan.catalogID,
+		Plans:     plan.plans,
+	})
+	return err
+}
+
+// kill stops the plugin process, if any. The caller must hold mu.
+func (m *Mapper) kill() {
+	if m.client != nil {
+		m.client.Kill()
+	}
+	m.client = nil
+	m.rpc = nil
+	m.scope = nil
+	m.scopeSet = false
+}
+
+func (m *Mapper) logFailure(evidence api.Evidence, err error) error {
+	slog.Error("plugin call failed",
+		slog.String("plugin_id", string(m.id)),
+		slog.String("policy_rule_id", evidence.PolicyRuleId),
+		slog.String("err", err.Error()),
+	)
+	return err
+}
+
+// failed returns the Compliance reported when the plugin could not be
+// called.
+func failed() api.Compliance {
+	compliance := mapper.Unmapped()
+	compliance.EnrichmentStatus = api.ComplianceEnrichmentStatusUnknown
+	return compliance
+}
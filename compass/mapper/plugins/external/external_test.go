@@ -0,0 +1,177 @@
+package external
+
+import (
+	"maps"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/internal/mappertest"
+	"github.com/complytime/complybeacon/compass/mapper"
+	"github.com/complytime/complybeacon/compass/mapper/plugins/basic"
+)
+
+// crashRuleID makes the test plugin exit while mapping.
+const crashRuleID = "crash"
+
+// crashingMapper is the mapper served by the test plugin.
+type crashingMapper struct {
+	*basic.Mapper
+}
+
+func (m crashingMapper) Map(evidence api.Evidence, scope mapper.Scope) api.Compliance {
+	if evidence.PolicyRuleId == crashRuleID {
+		os.Exit(1)
+	}
+	return m.Mapper.Map(evidence, scope)
+}
+
+// TestMain runs the test binary as the plugin when Compass starts it as one.
+func TestMain(m *testing.M) {
+	if os.Getenv(Handshake.MagicCookieKey) == Handshake.MagicCookieValue {
+		Serve(crashingMapper{Mapper: basic.NewBasicMapper()})
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func testScope() mapper.Scope {
+	scope := mappertest.Scope("CIS", "CIS-1")
+	maps.Copy(scope, mappertest.Scope("OSPS-B", "OSPS-AC-01"))
+	return scope
+}
+
+func evidence(ruleID string) api.Evidence {
+	return api.Evidence{
+		PolicyEngineName:       "external",
+		PolicyRuleId:           ruleID,
+		PolicyEvaluationStatus: api.Failed,
+		Timestamp:              time.Now(),
+	}
+}
+
+func newTestMapper(t *testing.T, cfg Config) *Mapper {
+	t.Helper()
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	cfg.Command = executable
+
+	m, err := New("external", cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, m.Close()) })
+	m.AddEvaluationPlan("CIS", mappertest.Plan("CIS", "CIS-1", "mfa"))
+	m.AddEvaluationPlan("OSPS-B", mappertest.Plan("OSPS-B", "OSPS-AC-01", "mfa"))
+	return m
+}
+
+func TestMapper_Map(t *testing.T) {
+	m := newTestMapper(t, Config{})
+	scope := testScope()
+
+	compliance := m.Map(evidence("mfa"), scope)
+	assert.Equal(t, api.ComplianceEnrichmentStatusSuccess, compliance.EnrichmentStatus)
+	assert.Equal(t, "CIS-1.01", compliance.Control.Id)
+	assert.Equal(t, "CIS", compliance.Control.CatalogId)
+	assert.Equal(t, "Test Family", compliance.Control.Category)
+	assert.Equal(t, api.ComplianceStatusNonCompliant, compliance.Status)
+
+	matches := m.MapAll(evidence("mfa"), scope)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "OSPS-AC-01.01", matches[1].Control.Id)
+	assert.Equal(t, "OSPS-B", matches[1].Control.CatalogId)
+	assert.Equal(t, "Test Family", matches[1].Control.Category)
+
+	compliance = m.Map(evidence("branch_protection"), scope)
+	assert.Equal(t, api.ComplianceEnrichmentStatusUnmapped, compliance.EnrichmentStatus)
+	assert.Empty(t, m.MapAll(evidence("branch_protection"), scope))
+}
+
+func TestMapper_Restart(t *testing.T) {
+	t.Run("after a crash during a call", func(t *testing.T) {
+		m := newTestMapper(t, Config{})
+		scope := testScope()
+
+		compliance := m.Map(evidence(crashRuleID), scope)
+		assert.Equal(t, api.ComplianceEnrichmentStatusUnknown, compliance.EnrichmentStatus)
+
+		// The restarted plugin gets the plans and scope again.
+		compliance = m.Map(evidence("mfa"), scope)
+		assert.Equal(t, api.ComplianceEnrichmentStatusSuccess, compliance.EnrichmentStatus)
+		assert.Equal(t, "Test Family", compliance.Control.Category)
+	})
+
+	t.Run("by the health check", func(t *testing.T) {
+		m := newTestMapper(t, Config{HealthInterval: "50ms"})
+
+		m.mu.RLock()
+		crashed := m.client
+		m.mu.RUnlock()
+		crashed.Kill()
+
+		assert.Eventually(t, func() bool {
+			m.mu.RLock()
+			defer m.mu.RUnlock()
+			return m.client != crashed && m.healthy()
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
+
+func TestNew(t *testing.T) {
+	executable, err := os.Executable()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{
+			name:    "missing command",
+			config:  Config{},
+			wantErr: "command is required",
+		},
+		{
+			name:    "invalid checksum",
+			config:  Config{Command: executable, Checksum: "abc"},
+			wantErr: "not a hex encoded SHA-256",
+		},
+		{
+			name:    "checksum mismatch",
+			config:  Config{Command: executable, Checksum: "0000000000000000000000000000000000000000000000000000000000000000"},
+			wantErr: "checksums did not match",
+		},
+		{
+			name:    "invalid health interval",
+			config:  Config{Command: executable, HealthInterval: "0s"},
+			wantErr: "health-interval: 0s is not positive",
+		},
+		{
+			name:    "invalid timeout",
+			config:  Config{Command: executable, Timeout: "soon"},
+			wantErr: "timeout: time: invalid duration",
+		},
+		{
+			name:    "not a plugin",
+			config:  Config{Command: "true"},
+			wantErr: "starting plugin true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("external", tt.config)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	_, err := mapper.New(Type, "external", mapper.Config{"command": "plugin", "unknown": true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid config")
+}
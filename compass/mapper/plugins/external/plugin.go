@@ -0,0 +1,46 @@
+package external
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+
+	"github.com/complytime/complybeacon/compass/api/mapperv1"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// ProtocolVersion is the version of the plugin protocol, defined by the
+// compass.mapper.v1 gRPC package. Compass refuses to start plugins built for
+// another version.
+const ProtocolVersion = 1
+
+// Handshake is the go-plugin handshake shared by Compass and its plugins.
+// The magic cookie only guards against running a plugin binary directly; it
+// is not a security measure.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  ProtocolVersion,
+	MagicCookieKey:   "COMPASS_MAPPER_PLUGIN",
+	MagicCookieValue: "6f1e3a0c-compass-mapper",
+}
+
+// pluginKey names the mapper in the plugin set served by a plugin process.
+const pluginKey = "mapper"
+
+// grpcPlugin connects the MapperService to go-plugin. In the plugin process
+// it serves impl; in Compass it returns a client for the service.
+type grpcPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+	impl mapper.Mapper
+}
+
+var _ plugin.GRPCPlugin = (*grpcPlugin)(nil)
+
+func (p *grpcPlugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	mapperv1.RegisterMapperServiceServer(s, newServer(p.impl))
+	return nil
+}
+
+func (p *grpcPlugin) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return mapperv1.NewMapperServiceClient(conn), nil
+}
@@ -0,0 +1,106 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/ossf/gemara/layer4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/api/mapperv1"
+	"github.com/complytime/complybeacon/compass/internal/protoconv"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// Serve runs impl as an external mapper plugin. It is called from the main
+// function of the plugin binary and returns when Compass stops the plugin.
+// Anything the plugin writes to stderr is passed on to Compass's stderr.
+func Serve(impl mapper.Mapper) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         plugin.PluginSet{pluginKey: &grpcPlugin{impl: impl}},
+		GRPCServer:      plugin.DefaultGRPCServer,
+		Logger: hclog.New(&hclog.LoggerOptions{
+			Level:      hclog.Warn,
+			Output:     os.Stderr,
+			JSONFormat: true,
+		}),
+	})
+}
+
+// server serves a mapper in the plugin process. The scope is kept here, as
+// Compass sends it once rather than with every Map call.
+type server struct {
+	mapperv1.UnimplementedMapperServiceServer
+	impl mapper.Mapper
+
+	// mu serializes plan and scope changes with Map calls.
+	mu    sync.RWMutex
+	scope mapper.Scope
+}
+
+func newServer(impl mapper.Mapper) *server {
+	return &server{impl: impl}
+}
+
+func (s *server) PluginName(context.Context, *mapperv1.PluginNameRequest) (*mapperv1.PluginNameResponse, error) {
+	return &mapperv1.PluginNameResponse{Name: string(s.impl.PluginName())}, nil
+}
+
+func (s *server) Map(_ context.Context, req *mapperv1.MapRequest) (*mapperv1.MapResponse, error) {
+	evidence, err := protoconv.EvidenceFromProto(req.GetEvidence())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var findings []api.Compliance
+	switch multiMapper, ok := s.impl.(mapper.MultiMapper); {
+	case !req.GetAll():
+		findings = []api.Compliance{s.impl.Map(evidence, s.scope)}
+	case ok:
+		findings = multiMapper.MapAll(evidence, s.scope)
+	default:
+		if compliance := s.impl.Map(evidence, s.scope); compliance.EnrichmentStatus == api.ComplianceEnrichmentStatusSuccess {
+			findings = []api.Compliance{compliance}
+		}
+	}
+
+	response := &mapperv1.MapResponse{}
+	for _, finding := range findings {
+		response.Compliance = append(response.Compliance, protoconv.ComplianceToProto(finding))
+	}
+	return response, nil
+}
+
+func (s *server) AddEvaluationPlan(_ context.Context, req *mapperv1.AddEvaluationPlanRequest) (*mapperv1.AddEvaluationPlanResponse, error) {
+	var plans []layer4.AssessmentPlan
+	if err := json.Unmarshal(req.GetPlans(), &plans); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid plans: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.impl.AddEvaluationPlan(req.GetCatalogId(), plans...)
+	return &mapperv1.AddEvaluationPlanResponse{}, nil
+}
+
+func (s *server) SetScope(_ context.Context, req *mapperv1.SetScopeRequest) (*mapperv1.SetScopeResponse, error) {
+	var scope mapper.Scope
+	if err := json.Unmarshal(req.GetCatalogs(), &scope); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid catalogs: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scope = scope
+	return &mapperv1.SetScopeResponse{}, nil
+}
@@ -0,0 +1,152 @@
+// Package kyverno provides a mapper for Kyverno PolicyReport results.
+package kyverno
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+	"github.com/complytime/complybeacon/compass/mapper/plugins/basic"
+)
+
+// Type is the plugin type the Kyverno mapper is registered under.
+const Type mapper.Type = "kyverno"
+
+var _ mapper.MultiMapper = (*Mapper)(nil)
+
+func init() {
+	mapper.Register(Type, func(id mapper.ID, config mapper.Config) (mapper.Mapper, error) {
+		// The Kyverno mapper has no settings of its own.
+		if err := config.Decode(&struct{}{}); err != nil {
+			return nil, err
+		}
+		return New(id), nil
+	})
+}
+
+// Result is a single entry of the results of a PolicyReport or
+// ClusterPolicyReport.
+type Result struct {
+	Policy   string `json:"policy"`
+	Rule     string `json:"rule"`
+	Result   string `json:"result"`
+	Severity string `json:"severity"`
+	Category string `json:"category"`
+	Message  string `json:"message"`
+}
+
+// evaluationResults translates PolicyReport result values. The plugin's
+// status mapping then gives the compliance status.
+var evaluationResults = map[string]api.EvidencePolicyEvaluationStatus{
+	"pass":  api.Passed,
+	"fail":  api.Failed,
+	"warn":  api.NeedsReview,
+	"error": api.Unknown,
+	"skip":  api.NotApplicable,
+}
+
+// defaultResults gives the compliance status of evaluation results that
+// Kyverno reports differently from mapper.StatusFromEvaluation.
+var defaultResults = mapper.StatusTable{
+	api.NeedsReview: api.ComplianceStatusNeedsReview,
+}
+
+// riskLevels translates PolicyReport severities.
+var riskLevels = map[string]api.ComplianceRiskLevel{
+	"critical": api.Critical,
+	"high":     api.High,
+	"medium":   api.Medium,
+	"low":      api.Low,
+	"info":     api.Informational,
+}
+
+// Mapper maps Kyverno PolicyReport results carried as evidence rawData. The
+// procedure ID of a result is "policy/rule", or the policy name alone when
+// the evaluation plans do not define one for the rule.
+type Mapper struct {
+	*basic.Mapper
+	id mapper.ID
+}
+
+// New returns a Kyverno mapper for the plugin configured with id.
+func New(id mapper.ID) *Mapper {
+	m := &Mapper{Mapper: basic.NewBasicMapper(), id: id}
+	m.SetStatusMapping(mapper.StatusMapping{})
+	return m
+}
+
+// SetStatusMapping replaces the status mapping. Evaluation results that
+// mapping does not list keep the plugin defaults, by which a "warn" result needs review.
+func (m *Mapper) SetStatusMapping(mapping mapper.StatusMapping) {
+	m.Mapper.SetStatusMapping(mapping.WithDefaults(defaultResults))
+}
+
+func (m *Mapper) PluginName() mapper.ID {
+	return m.id
+}
+
+func (m *Mapper) Map(evidence api.Evidence, scope mapper.Scope) api.Compliance {
+	if matches := m.MapAll(evidence, scope); len(matches) > 0 {
+		return matches[0]
+	}
+	return mapper.Unmapped()
+}
+
+// MapAll maps the PolicyReport result in the evidence rawData. The result
+// value replaces the evaluation status, from which the status mapping gives
+// the compliance status, and the severity, when given, sets the risk level.
+// Without a result in rawData, the evidence policy rule ID is read as
+// "policy/rule" and the evaluation status is used as sent.
+func (m *Mapper) MapAll(evidence api.Evidence, scope mapper.Scope) []api.Compliance {
+	result := ParseResult(evidence)
+	if evaluationResult, ok := evaluationResults[strings.ToLower(result.Result)]; ok {
+		evidence.PolicyEvaluationStatus = evaluationResult
+	}
+	level, hasLevel := riskLevels[strings.ToLower(result.Severity)]
+
+	for _, procedureID := range procedureIDs(result) {
+		evidence.PolicyRuleId = procedureID
+		findings := m.Mapper.MapAll(evidence, scope)
+		if len(findings) == 0 {
+			continue
+		}
+
+		for i := range findings {
+			if hasLevel {
+				findings[i].Risk = &api.ComplianceRisk{Level: &level}
+			}
+			if result.Message != "" && evidence.PolicyEvaluationStatus != api.Passed {
+				message := result.Message
+				findings[i].Control.RemediationDescription = &message
+			}
+		}
+		return findings
+	}
+	return []api.Compliance{}
+}
+
+// ParseResult reads the PolicyReport result from the evidence rawData. The
+// policy and rule default to the parts of the evidence policy rule ID.
+func ParseResult(evidence api.Evidence) Result {
+	var result Result
+	if evidence.RawData != nil {
+		// Fields of unexpected types are left empty.
+		if content, err := json.Marshal(*evidence.RawData); err == nil {
+			_ = json.Unmarshal(content, &result)
+		}
+	}
+	if result.Policy == "" {
+		result.Policy, result.Rule, _ = strings.Cut(evidence.PolicyRuleId, "/")
+	}
+	return result
+}
+
+// procedureIDs returns the procedure IDs tried for result, most specific
+// first.
+func procedureIDs(result Result) []string {
+	if result.Rule == "" {
+		return []string{result.Policy}
+	}
+	return []string{result.Policy + "/" + result.Rule, result.Policy}
+}
@@ -0,0 +1,168 @@
+package kyverno
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/internal/mappertest"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// loadReportResults returns evidence for each result of the PolicyReport in
+// testdata/name, carrying the result as rawData.
+func loadReportResults(t *testing.T, name string) []api.Evidence {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	var report struct {
+		Results []map[string]interface{} `json:"results"`
+	}
+	require.NoError(t, yaml.Unmarshal(content, &report))
+
+	var evidence []api.Evidence
+	for _, result := range report.Results {
+		rawData := result
+		evidence = append(evidence, api.Evidence{
+			PolicyEngineName:       "kyverno",
+			PolicyRuleId:           result["policy"].(string) + "/" + result["rule"].(string),
+			PolicyEvaluationStatus: api.Unknown,
+			RawData:                &rawData,
+			Timestamp:              time.Now(),
+		})
+	}
+	return evidence
+}
+
+func testScope() mapper.Scope {
+	return mappertest.Scope("OSPS-B", "OSPS-BR-01", "OSPS-BR-02", "OSPS-BR-03", "OSPS-BR-04")
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestMapper_MapPolicyReport(t *testing.T) {
+	m := New("kyverno")
+	m.AddEvaluationPlan("OSPS-B",
+		// Procedures for a whole policy and for a single rule.
+		mappertest.Plan("OSPS-B", "OSPS-BR-01", "disallow-privileged-containers"),
+		mappertest.Plan("OSPS-B", "OSPS-BR-02", "require-image-signature/verify-signature"),
+		mappertest.Plan("OSPS-B", "OSPS-BR-03", "require-image-signature/check-attestation"),
+		mappertest.Plan("OSPS-B", "OSPS-BR-04", "restrict-node-port"),
+	)
+
+	expected := []struct {
+		control string
+		status  api.ComplianceStatus
+		risk    *api.ComplianceRiskLevel
+		message string
+	}{
+		{
+			control: "OSPS-BR-01.01",
+			status:  api.ComplianceStatusNonCompliant,
+			risk:    ptr(api.High),
+			message: "validation error: Privileged mode is disallowed. rule privileged-containers failed at path /spec/template/spec/containers/0/securityContext/privileged/",
+		},
+		{
+			control: "OSPS-BR-01.01",
+			status:  api.ComplianceStatusCompliant,
+			risk:    ptr(api.High),
+		},
+		{
+			control: "OSPS-BR-02.01",
+			status:  api.ComplianceStatusNeedsReview,
+			risk:    ptr(api.Medium),
+			message: "image ghcr.io/example/checkout:1.4.2 signature could not be verified in audit mode",
+		},
+		{
+			control: "OSPS-BR-03.01",
+			status:  api.ComplianceStatusUnknown,
+			message: "failed to fetch attestations: context deadline exceeded",
+		},
+		{
+			control: "OSPS-BR-04.01",
+			status:  api.ComplianceStatusNotApplicable,
+			risk:    ptr(api.Low),
+			message: "rule skipped due to preconditions",
+		},
+	}
+
+	evidence := loadReportResults(t, "policyreport.yaml")
+	require.Len(t, evidence, len(expected))
+	for i, tt := range expected {
+		t.Run(evidence[i].PolicyRuleId, func(t *testing.T) {
+			compliance := m.Map(evidence[i], testScope())
+			assert.Equal(t, api.ComplianceEnrichmentStatusSuccess, compliance.EnrichmentStatus)
+			assert.Equal(t, tt.control, compliance.Control.Id)
+			assert.Equal(t, tt.status, compliance.Status)
+			if tt.risk == nil {
+				assert.Nil(t, compliance.Risk)
+			} else {
+				require.NotNil(t, compliance.Risk)
+				assert.Equal(t, tt.risk, compliance.Risk.Level)
+			}
+			if tt.message != "" {
+				require.NotNil(t, compliance.Control.RemediationDescription)
+				assert.Equal(t, tt.message, *compliance.Control.RemediationDescription)
+			}
+		})
+	}
+
+	t.Run("policy rule id without rawData", func(t *testing.T) {
+		compliance := m.Map(api.Evidence{
+			PolicyEngineName:       "kyverno",
+			PolicyRuleId:           "require-image-signature/verify-signature",
+			PolicyEvaluationStatus: api.Failed,
+			Timestamp:              time.Now(),
+		}, testScope())
+		assert.Equal(t, "OSPS-BR-02.01", compliance.Control.Id)
+		assert.Equal(t, api.ComplianceStatusNonCompliant, compliance.Status)
+		assert.Nil(t, compliance.Risk)
+	})
+
+	t.Run("unknown policy is unmapped", func(t *testing.T) {
+		compliance := m.Map(api.Evidence{
+			PolicyEngineName:       "kyverno",
+			PolicyRuleId:           "require-labels/check-team",
+			PolicyEvaluationStatus: api.Failed,
+			Timestamp:              time.Now(),
+		}, testScope())
+		assert.Equal(t, api.ComplianceEnrichmentStatusUnmapped, compliance.EnrichmentStatus)
+	})
+}
+
+func TestMapper_StatusMapping(t *testing.T) {
+	m := New("kyverno")
+	m.AddEvaluationPlan("OSPS-B",
+		mappertest.Plan("OSPS-B", "OSPS-BR-02", "require-image-signature/verify-signature"),
+		mappertest.Plan("OSPS-B", "OSPS-BR-04", "restrict-node-port"),
+	)
+	m.SetStatusMapping(mapper.StatusMapping{
+		Results: mapper.StatusTable{api.NeedsReview: api.ComplianceStatusNonCompliant},
+		Overrides: []mapper.StatusOverride{{
+			Catalog: "OSPS-B",
+			Control: "OSPS-BR-04",
+			Results: mapper.StatusTable{api.NotApplicable: api.ComplianceStatusCompliant},
+		}},
+	})
+
+	evidence := loadReportResults(t, "policyreport.yaml")
+	assert.Equal(t, api.ComplianceStatusNonCompliant, m.Map(evidence[2], testScope()).Status, "warn is mapped as Needs Review")
+	assert.Equal(t, api.ComplianceStatusCompliant, m.Map(evidence[4], testScope()).Status, "skip is mapped as Not Applicable")
+
+	m.SetStatusMapping(mapper.StatusMapping{Results: mapper.StatusTable{api.NotRun: api.ComplianceStatusNonCompliant}})
+	assert.Equal(t, api.ComplianceStatusNeedsReview, m.Map(evidence[2], testScope()).Status, "warn keeps the plugin default")
+}
+
+func TestParseResult(t *testing.T) {
+	rawData := map[string]interface{}{"result": "fail", "severity": 3}
+	result := ParseResult(api.Evidence{PolicyRuleId: "require-labels/check-team", RawData: &rawData})
+	assert.Equal(t, Result{Policy: "require-labels", Rule: "check-team", Result: "fail"}, result)
+}
@@ -0,0 +1,294 @@
+// Package opa provides a mapper for Open Policy Agent decision logs. The
+// policy package and the deny or violation sets in the decision result
+// determine the outcome, rather than a single policy rule ID.
+package opa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+	"github.com/complytime/complybeacon/compass/mapper/plugins/basic"
+)
+
+// Type is the plugin type the OPA mapper is registered under.
+const Type mapper.Type = "opa"
+
+var _ mapper.MultiMapper = (*Mapper)(nil)
+
+func init() {
+	mapper.Register(Type, func(id mapper.ID, config mapper.Config) (mapper.Mapper, error) {
+		// The OPA mapper has no settings of its own.
+		if err := config.Decode(&struct{}{}); err != nil {
+			return nil, err
+		}
+		return New(id), nil
+	})
+}
+
+// Rules whose value is a set of violations, and the rule whose value allows
+// the request.
+var (
+	violationRules = []string{"deny", "violation", "violations"}
+	allowRule      = "allow"
+)
+
+// DecisionLog is the part of an OPA decision log entry used for mapping.
+type DecisionLog struct {
+	DecisionID string `json:"decision_id"`
+	// Path is the queried rule or package, such as
+	// "kubernetes/admission/deny" or "data.kubernetes.admission".
+	Path   string `json:"path"`
+	Result any    `json:"result"`
+}
+
+// Violation is a single entry of a deny or violation set.
+type Violation struct {
+	// Rule names the procedure that the violation is reported for, if the
+	// policy sets one with an "id", "rule", or "procedure" field.
+	Rule    string
+	Message string
+}
+
+// Decision is the outcome of a decision log entry.
+type Decision struct {
+	// Package is the dotted policy package path, without the "data." prefix.
+	Package    string
+	Violations []Violation
+	// Allowed is false when the decision was denied by an allow rule.
+	Allowed bool
+}
+
+// Passed reports whether the decision found no violations.
+func (d Decision) Passed() bool {
+	return d.Allowed && len(d.Violations) == 0
+}
+
+// Mapper maps OPA decision logs carried as evidence rawData. The procedure
+// ID is derived from the policy package path, and violations are mapped to
+// controls through the assessment plans added to the mapper. Evidence that
+// is not a decision log is mapped by its policy rule ID, as the basic mapper
+// does.
+type Mapper struct {
+	*basic.Mapper
+	id mapper.ID
+}
+
+// New returns an OPA mapper for the plugin configured with id.
+func New(id mapper.ID) *Mapper {
+	return &Mapper{Mapper: basic.NewBasicMapper(), id: id}
+}
+
+func (m *Mapper) PluginName() mapper.ID {
+	return m.id
+}
+
+func (m *Mapper) Map(evidence api.Evidence, scope mapper.Scope) api.Compliance {
+	if matches := m.MapAll(evidence, scope); len(matches) > 0 {
+		return matches[0]
+	}
+	return mapper.Unmapped()
+}
+
+// MapAll returns a finding for each control mapped by the decision. A
+// failing decision yields a Non-Compliant finding for the procedure named by
+// each violation, or for the package procedure when a violation names none.
+// A passing decision yields a Compliant finding for the package procedure.
+func (m *Mapper) MapAll(evidence api.Evidence, scope mapper.Scope) []api.Compliance {
+	if evidence.RawData == nil {
+		return m.Mapper.MapAll(evidence, scope)
+	}
+	decision, err := ParseDecision(*evidence.RawData)
+	if err != nil {
+		return m.Mapper.MapAll(evidence, scope)
+	}
+
+	if decision.Passed() {
+		return m.mapProcedure(evidence, scope, api.Passed, nil, packageProcedures(decision.Package))
+	}
+
+	matches := []api.Compliance{}
+	seen := make(map[string]bool)
+	add := func(findings []api.Compliance) {
+		for _, finding := range findings {
+			key := finding.Control.CatalogId + "/" + finding.Control.Id
+			if !seen[key] {
+				seen[key] = true
+				matches = append(matches, finding)
+			}
+		}
+	}
+
+	// Violations naming a known procedure are reported for it; the rest,
+	// and a denied allow rule, for the package procedure.
+	var unattributed []string
+	for _, violation := range decision.Violations {
+		if violation.Rule != "" {
+			candidates := []string{decision.Package + "." + violation.Rule, violation.Rule}
+			if findings := m.mapProcedure(evidence, scope, api.Failed, []string{violation.Message}, candidates); len(findings) > 0 {
+				add(findings)
+				continue
+			}
+		}
+		unattributed = append(unattributed, violation.Message)
+	}
+	if len(unattributed) > 0 || !decision.Allowed {
+		add(m.mapProcedure(evidence, scope, api.Failed, unattributed, packageProcedures(decision.Package)))
+	}
+	return matches
+}
+
+// mapProcedure maps evidence for the first of candidates that the assessment
+// plans know. Non-empty messages replace the remediation description.
+func (m *Mapper) mapProcedure(evidence api.Evidence, scope mapper.Scope, status api.EvidencePolicyEvaluationStatus, messages []string, candidates []string) []api.Compliance {
+	evidence.PolicyEvaluationStatus = status
+	for _, procedureID := range candidates {
+		evidence.PolicyRuleId = procedureID
+		findings := m.Mapper.MapAll(evidence, scope)
+		if len(findings) == 0 {
+			continue
+		}
+		if description := strings.Join(nonEmpty(messages), "; "); description != "" {
+			for i := range findings {
+				findings[i].Control.RemediationDescription = &description
+			}
+		}
+		return findings
+	}
+	return nil
+}
+
+// packageProcedures returns the procedure IDs tried for a policy package:
+// the full dotted path, then its last segment.
+func packageProcedures(pkg string) []string {
+	candidates := []string{pkg}
+	if i := strings.LastIndex(pkg, "."); i >= 0 {
+		candidates = append(candidates, pkg[i+1:])
+	}
+	return candidates
+}
+
+// ParseDecision reads an OPA decision log entry from rawData.
+func ParseDecision(rawData map[string]interface{}) (Decision, error) {
+	content, err := json.Marshal(rawData)
+	if err != nil {
+		return Decision{}, err
+	}
+	var entry DecisionLog
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return Decision{}, err
+	}
+	if entry.Path == "" {
+		return Decision{}, errors.New("not an OPA decision log: no path")
+	}
+
+	segments := pathSegments(entry.Path)
+	if len(segments) == 0 {
+		return Decision{}, fmt.Errorf("invalid decision path %q", entry.Path)
+	}
+
+	decision := Decision{Allowed: true}
+	rule := segments[len(segments)-1]
+	switch {
+	case contains(violationRules, rule):
+		decision.Violations = parseViolations(entry.Result)
+	case rule == allowRule:
+		allowed, ok := entry.Result.(bool)
+		if !ok {
+			return Decision{}, fmt.Errorf("result of %s is not a bool", entry.Path)
+		}
+		decision.Allowed = allowed
+	default:
+		// The path names a package whose rules make up the result.
+		result, ok := entry.Result.(map[string]any)
+		if !ok {
+			return Decision{}, fmt.Errorf("result of package %s is not an object", entry.Path)
+		}
+		for _, name := range violationRules {
+			decision.Violations = append(decision.Violations, parseViolations(result[name])...)
+		}
+		if allowed, ok := result[allowRule].(bool); ok {
+			decision.Allowed = allowed
+		}
+		decision.Package = strings.Join(segments, ".")
+		return decision, nil
+	}
+
+	if len(segments) < 2 {
+		return Decision{}, fmt.Errorf("decision path %q has no package", entry.Path)
+	}
+	decision.Package = strings.Join(segments[:len(segments)-1], ".")
+	return decision, nil
+}
+
+// pathSegments splits a slash or dot separated decision path, dropping the
+// "data" root.
+func pathSegments(path string) []string {
+	path = strings.Trim(strings.ReplaceAll(path, "/", "."), ".")
+	var segments []string
+	for _, segment := range strings.Split(path, ".") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	if len(segments) > 0 && segments[0] == "data" {
+		segments = segments[1:]
+	}
+	return segments
+}
+
+// parseViolations reads a deny or violation set. Entries are either
+// messages or objects with a "msg" or "message" field.
+func parseViolations(value any) []Violation {
+	entries, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+
+	violations := make([]Violation, 0, len(entries))
+	for _, entry := range entries {
+		switch entry := entry.(type) {
+		case string:
+			violations = append(violations, Violation{Message: entry})
+		case map[string]any:
+			violations = append(violations, Violation{
+				Rule:    firstString(entry, "id", "rule", "procedure"),
+				Message: firstString(entry, "msg", "message"),
+			})
+		default:
+			violations = append(violations, Violation{Message: fmt.Sprint(entry)})
+		}
+	}
+	return violations
+}
+
+func firstString(fields map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := fields[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func nonEmpty(values []string) []string {
+	var result []string
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,201 @@
+package opa
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/internal/mappertest"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// loadFixture returns evidence carrying the decision log in testdata/name.
+func loadFixture(t *testing.T, name string) api.Evidence {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	var rawData map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &rawData))
+	return api.Evidence{
+		PolicyEngineName:       "opa",
+		PolicyRuleId:           "decision",
+		PolicyEvaluationStatus: api.Unknown,
+		RawData:                &rawData,
+		Timestamp:              time.Now(),
+	}
+}
+
+func testScope() mapper.Scope {
+	return mappertest.Scope("OSPS-B", "OSPS-AC-03", "OSPS-QA-07", "OSPS-VM-02")
+}
+
+func newTestMapper() *Mapper {
+	m := New("opa")
+	m.AddEvaluationPlan("OSPS-B",
+		mappertest.Plan("OSPS-B", "OSPS-AC-03", "github.branch_protection"),
+		mappertest.Plan("OSPS-B", "OSPS-QA-07", "signed_commits"),
+		mappertest.Plan("OSPS-B", "OSPS-VM-02", "repository"),
+	)
+	return m
+}
+
+func TestMapper_MapAll(t *testing.T) {
+	type finding struct {
+		control     string
+		status      api.ComplianceStatus
+		remediation string
+	}
+
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []finding
+	}{
+		{
+			name:    "deny set with violations",
+			fixture: "branch_protection_deny.json",
+			expected: []finding{{
+				control:     "OSPS-AC-03.01",
+				status:      api.ComplianceStatusNonCompliant,
+				remediation: "main branch does not require pull request reviews; main branch protection does not apply to administrators",
+			}},
+		},
+		{
+			name:    "empty deny set",
+			fixture: "branch_protection_pass.json",
+			expected: []finding{{
+				control:     "OSPS-AC-03.01",
+				status:      api.ComplianceStatusCompliant,
+				remediation: "See github.branch_protection",
+			}},
+		},
+		{
+			name:    "allow rule denied",
+			fixture: "branch_protection_allow.json",
+			expected: []finding{{
+				control:     "OSPS-AC-03.01",
+				status:      api.ComplianceStatusNonCompliant,
+				remediation: "See github.branch_protection",
+			}},
+		},
+		{
+			name:    "package result with violations naming procedures",
+			fixture: "repository_package.json",
+			expected: []finding{
+				{
+					control:     "OSPS-QA-07.01",
+					status:      api.ComplianceStatusNonCompliant,
+					remediation: "14 commits on master are not signed",
+				},
+				{
+					control:     "OSPS-VM-02.01",
+					status:      api.ComplianceStatusNonCompliant,
+					remediation: "repository has no SECURITY.md",
+				},
+			},
+		},
+	}
+
+	m := newTestMapper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := m.MapAll(loadFixture(t, tt.fixture), testScope())
+			var actual []finding
+			for _, match := range matches {
+				require.NotNil(t, match.Control.RemediationDescription)
+				assert.Equal(t, api.ComplianceEnrichmentStatusSuccess, match.EnrichmentStatus)
+				actual = append(actual, finding{
+					control:     match.Control.Id,
+					status:      match.Status,
+					remediation: *match.Control.RemediationDescription,
+				})
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+
+	t.Run("evidence without decision log uses policy rule id", func(t *testing.T) {
+		evidence := api.Evidence{
+			PolicyEngineName:       "opa",
+			PolicyRuleId:           "signed_commits",
+			PolicyEvaluationStatus: api.Passed,
+			Timestamp:              time.Now(),
+		}
+		compliance := m.Map(evidence, testScope())
+		assert.Equal(t, "OSPS-QA-07.01", compliance.Control.Id)
+		assert.Equal(t, api.ComplianceStatusCompliant, compliance.Status)
+	})
+
+	t.Run("unknown package is unmapped", func(t *testing.T) {
+		evidence := loadFixture(t, "branch_protection_deny.json")
+		(*evidence.RawData)["path"] = "kubernetes/admission/deny"
+		compliance := m.Map(evidence, testScope())
+		assert.Equal(t, api.ComplianceEnrichmentStatusUnmapped, compliance.EnrichmentStatus)
+	})
+}
+
+func TestParseDecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawData  map[string]interface{}
+		expected Decision
+		wantErr  string
+	}{
+		{
+			name:     "deny rule path",
+			rawData:  map[string]interface{}{"path": "data.k8s.admission.deny", "result": []any{"no root"}},
+			expected: Decision{Package: "k8s.admission", Allowed: true, Violations: []Violation{{Message: "no root"}}},
+		},
+		{
+			name: "violation objects",
+			rawData: map[string]interface{}{"path": "k8s/admission/violation", "result": []any{
+				map[string]any{"rule": "run_as_root", "message": "no root"},
+			}},
+			expected: Decision{Package: "k8s.admission", Allowed: true, Violations: []Violation{{Rule: "run_as_root", Message: "no root"}}},
+		},
+		{
+			name:     "allow rule path",
+			rawData:  map[string]interface{}{"path": "k8s/admission/allow", "result": true},
+			expected: Decision{Package: "k8s.admission", Allowed: true},
+		},
+		{
+			name:     "package path",
+			rawData:  map[string]interface{}{"path": "k8s/admission", "result": map[string]any{"violations": []any{"x"}}},
+			expected: Decision{Package: "k8s.admission", Allowed: true, Violations: []Violation{{Message: "x"}}},
+		},
+		{
+			name:    "no path",
+			rawData: map[string]interface{}{"result": true},
+			wantErr: "not an OPA decision log",
+		},
+		{
+			name:    "allow is not a bool",
+			rawData: map[string]interface{}{"path": "k8s/allow", "result": "yes"},
+			wantErr: "is not a bool",
+		},
+		{
+			name:    "rule without package",
+			rawData: map[string]interface{}{"path": "data.deny", "result": []any{}},
+			wantErr: "has no package",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := ParseDecision(tt.rawData)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, decision)
+		})
+	}
+}
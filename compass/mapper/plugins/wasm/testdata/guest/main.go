@@ -0,0 +1,117 @@
+//go:build wasip1
+
+// Command guest is the WebAssembly module used by the wasm mapper tests.
+// It is built as a WASI reactor into testdata/guest.wasm by running go
+// generate in the wasm package.
+//
+// It maps evidence to every assessment requirement whose procedure matches
+// the policy rule ID, and treats a few rule IDs specially to exercise the
+// limits of the host.
+package main
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+func main() {}
+
+type input struct {
+	Evidence struct {
+		PolicyRuleID string         `json:"policyRuleId"`
+		RawData      map[string]any `json:"rawData"`
+	} `json:"evidence"`
+	Plans map[string][]struct {
+		Assessments []struct {
+			Requirement struct {
+				EntryID string `json:"entry-id"`
+			} `json:"requirement"`
+			Procedures []struct {
+				ID string `json:"id"`
+			} `json:"procedures"`
+		} `json:"assessments"`
+	} `json:"plans"`
+}
+
+type finding struct {
+	Catalog     string `json:"catalog"`
+	Requirement string `json:"requirement,omitempty"`
+	Control     string `json:"control,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Remediation string `json:"remediation,omitempty"`
+	Target      string `json:"target,omitempty"`
+}
+
+// buffers keeps the memory handed to the host reachable until it is freed.
+var buffers = map[uint32][]byte{}
+
+//go:wasmexport compass_alloc
+func alloc(size uint32) uint32 {
+	return keep(make([]byte, size))
+}
+
+//go:wasmexport compass_free
+func free(ptr, _ uint32) {
+	delete(buffers, ptr)
+}
+
+//go:wasmexport compass_map
+func mapEvidence(ptr, size uint32) uint64 {
+	var in input
+	if err := json.Unmarshal(buffers[ptr][:size], &in); err != nil {
+		panic(err)
+	}
+
+	var findings []finding
+	switch in.Evidence.PolicyRuleID {
+	case "loop":
+		for {
+		}
+	case "grow":
+		var chunks [][]byte
+		for {
+			chunks = append(chunks, make([]byte, 1<<20))
+		}
+	case "out-of-scope":
+		findings = append(findings, finding{Catalog: "OSPS-B", Control: "OSPS-XX-99"})
+	case "exempt":
+		findings = append(findings, finding{Catalog: "OSPS-B", Control: "OSPS-VM-05", Status: "Exempt"})
+	case "unmatched":
+	}
+
+	for catalogID, plans := range in.Plans {
+		for _, plan := range plans {
+			for _, assessment := range plan.Assessments {
+				for _, procedure := range assessment.Procedures {
+					if procedure.ID != in.Evidence.PolicyRuleID {
+						continue
+					}
+					f := finding{Catalog: catalogID, Requirement: assessment.Requirement.EntryID}
+					if severity, _ := in.Evidence.RawData["severity"].(string); severity == "critical" {
+						f.Status = "Non-Compliant"
+						f.Remediation = "Fix critical findings first."
+					}
+					if target, _ := in.Evidence.RawData["image"].(string); target != "" {
+						f.Target = target
+					}
+					findings = append(findings, f)
+				}
+			}
+		}
+	}
+
+	output, err := json.Marshal(map[string]any{"findings": findings})
+	if err != nil {
+		panic(err)
+	}
+	return uint64(keep(output))<<32 | uint64(len(output))
+}
+
+func keep(buf []byte) uint32 {
+	if len(buf) == 0 {
+		buf = make([]byte, 1)[:0]
+	}
+	ptr := uint32(uintptr(unsafe.Pointer(unsafe.SliceData(buf[:cap(buf)]))))
+	buffers[ptr] = buf
+	return ptr
+}
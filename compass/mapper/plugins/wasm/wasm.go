@@ -0,0 +1,433 @@
+// Package wasm provides a mapper whose logic is a WebAssembly module. The
+// module runs inside Compass in a wazero sandbox, with its memory and the
+// time of each call limited, and has no access to the file system or the
+// network.
+//
+// A module exports its linear memory and the functions
+//
+//	compass_alloc(size i32) -> i32
+//	compass_map(ptr i32, len i32) -> i64
+//	compass_free(ptr i32, len i32)
+//
+// compass_alloc returns a buffer of size bytes that Compass writes the JSON
+// encoded Input into. compass_map maps the input at ptr and returns the
+// location of its JSON encoded Output, packed as ptr<<32 | len. compass_free,
+// which is optional, releases the input and output buffers after each call.
+// Modules built as WASI reactors have their _initialize function run when
+// they are instantiated.
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ossf/gemara/layer4"
+	"github.com/tetratelabs/wazero"
+	wasmapi "github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+	"github.com/complytime/complybeacon/compass/mapper/plugins/basic"
+)
+
+// Type is the plugin type the WebAssembly mapper is registered under.
+const Type mapper.Type = "wasm"
+
+// Names of the functions a module exports.
+const (
+	allocFunction = "compass_alloc"
+	mapFunction   = "compass_map"
+	freeFunction  = "compass_free"
+)
+
+const (
+	defaultMaxMemoryMB = 64
+	defaultTimeout     = time.Second
+	// pagesPerMB is the number of 64 KiB WebAssembly pages in a MiB.
+	pagesPerMB = 16
+	// maxIdleInstances bounds the module instances kept for reuse.
+	maxIdleInstances = 8
+)
+
+var (
+	_ mapper.MultiMapper = (*Mapper)(nil)
+	_ io.Closer          = (*Mapper)(nil)
+)
+
+// compilationCache holds the modules compiled by every mapper in the process,
+// so that a module is compiled once rather than on every reload. Compiling a
+// large module takes seconds.
+var compilationCache = wazero.NewCompilationCache()
+
+func init() {
+	mapper.Register(Type, func(id mapper.ID, config mapper.Config) (mapper.Mapper, error) {
+		var cfg Config
+		if err := config.Decode(&cfg); err != nil {
+			return nil, err
+		}
+		return New(id, cfg)
+	})
+}
+
+// Config is the plugin config of the WebAssembly mapper.
+type Config struct {
+	// Module is the path of the .wasm file.
+	Module string `json:"module"`
+	// MaxMemoryMB limits the linear memory of each module instance, in
+	// MiB. It defaults to 64.
+	MaxMemoryMB uint32 `json:"max-memory-mb"`
+	// Timeout bounds each call into the module, as a Go duration. It
+	// defaults to 1s.
+	Timeout string `json:"timeout"`
+}
+
+// Input is the JSON document passed to compass_map.
+type Input struct {
+	Evidence api.Evidence `json:"evidence"`
+	// Plans holds the assessment plans added to the mapper by catalog ID.
+	Plans map[string][]layer4.AssessmentPlan `json:"plans"`
+}
+
+// Output is the JSON document returned by compass_map.
+type Output struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Finding names a control or assessment requirement the evidence maps to.
+// Compass resolves it against the catalogs in scope.
+type Finding struct {
+	Catalog string `json:"catalog"`
+	// Control is the control ID. It may be left out when Requirement is
+	// set.
+	Control     string `json:"control"`
+	Requirement string `json:"requirement"`
+	// Status is a compliance status other than Exempt, which is reserved for
+	// exceptions. When empty, it is derived from the evidence policy
+	// evaluation status.
+	Status      string `json:"status"`
+	Remediation string `json:"remediation"`
+	// Target is the ID of the resource the finding applies to, when it
+	// differs from the evidence target.
+	Target string `json:"target"`
+}
+
+// Mapper maps evidence by calling a WebAssembly module. Evidence for which
+// the module returns no findings is mapped by its policy rule ID with the
+// evaluation plans added to the mapper, as the basic mapper does. Calls that
+// fail, for example by exceeding a limit, are logged and map nothing.
+type Mapper struct {
+	*basic.Mapper
+	id      mapper.ID
+	timeout time.Duration
+
+	runtime wazero.Runtime
+	module  wazero.CompiledModule
+	// idle holds instances ready for reuse. An instance serves one call at
+	// a time, and one whose call failed is discarded.
+	idle chan wasmapi.Module
+
+	// mu guards plans, which are also kept here to be passed to the module,
+	// and encodedPlans, their JSON encoding. Plans only change when they are
+	// loaded, so they are encoded then rather than on every call.
+	mu           sync.RWMutex
+	plans        map[string][]layer4.AssessmentPlan
+	encodedPlans []byte
+
+	// scopeIndex holds the control lookup for the scope most recently
+	// passed to Map.
+	scopeIndex mapper.IndexCache
+}
+
+// New compiles the module configured with cfg and checks that it can be
+// instantiated and exports the mapping functions.
+func New(id mapper.ID, cfg Config) (*Mapper, error) {
+	if cfg.Module == "" {
+		return nil, errors.New("module is required")
+	}
+	maxMemoryMB := cfg.MaxMemoryMB
+	if maxMemoryMB == 0 {
+		maxMemoryMB = defaultMaxMemoryMB
+	}
+	if maxMemoryMB > 4096 {
+		return nil, fmt.Errorf("max-memory-mb %d exceeds the 4096 MiB WebAssembly limit", maxMemoryMB)
+	}
+	timeout := defaultTimeout
+	if cfg.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("timeout: %w", err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("timeout: %s is not positive", cfg.Timeout)
+		}
+	}
+
+	content, err := os.ReadFile(cfg.Module)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(maxMemoryMB*pagesPerMB).
+		WithCloseOnContextDone(true).
+		WithCompilationCache(compilationCache))
+	m := &Mapper{
+		Mapper:       basic.NewBasicMapper(),
+		id:           id,
+		timeout:      timeout,
+		runtime:      runtime,
+		idle:         make(chan wasmapi.Module, maxIdleInstances),
+		plans:        make(map[string][]layer4.AssessmentPlan),
+		encodedPlans: []byte("{}"),
+	}
+	if err := m.load(ctx, content); err != nil {
+		_ = runtime.Close(ctx)
+		return nil, fmt.Errorf("module %s: %w", cfg.Module, err)
+	}
+	return m, nil
+}
+
+// load compiles the module and keeps a first instance, so that modules that
+// cannot run fail at startup.
+func (m *Mapper) load(ctx context.Context, content []byte) error {
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, m.runtime); err != nil {
+		return err
+	}
+	module, err := m.runtime.CompileModule(ctx, content)
+	if err != nil {
+		return err
+	}
+	exports := module.ExportedFunctions()
+	for _, name := range []string{allocFunction, mapFunction} {
+		if _, ok := exports[name]; !ok {
+			return fmt.Errorf("module does not export %s", name)
+		}
+	}
+	m.module = module
+
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+	instance, err := m.instantiate(ctx)
+	if err != nil {
+		return err
+	}
+	m.release(instance)
+	return nil
+}
+
+func (m *Mapper) PluginName() mapper.ID {
+	return m.id
+}
+
+func (m *Mapper) AddEvaluationPlan(catalogId string, plans ...layer4.AssessmentPlan) {
+	m.Mapper.AddEvaluationPlan(catalogId, plans...)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.plans[catalogId] = append(m.plans[catalogId], plans...)
+	encoded, err := json.Marshal(m.plans)
+	if err != nil {
+		slog.Error("WebAssembly mapper failed to encode evaluation plans",
+			slog.String("plugin_id", string(m.id)),
+			slog.String("catalog_id", catalogId),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	m.encodedPlans = encoded
+}
+
+func (m *Mapper) Map(evidence api.Evidence, scope mapper.Scope) api.Compliance {
+	if matches := m.MapAll(evidence, scope); len(matches) > 0 {
+		return matches[0]
+	}
+	return mapper.Unmapped()
+}
+
+// MapAll returns a finding for each control the module maps the evidence
+// to, in the order the module returns them. Findings for controls that are
+// not in scope are dropped.
+func (m *Mapper) MapAll(evidence api.Evidence, scope mapper.Scope) []api.Compliance {
+	output, err := m.call(evidence)
+	if err != nil {
+		slog.Error("WebAssembly mapper failed",
+			slog.String("plugin_id", string(m.id)),
+			slog.String("policy_rule_id", evidence.PolicyRuleId),
+			slog.String("error", err.Error()),
+		)
+		return []api.Compliance{}
+	}
+
+	index := m.scopeIndex.Get(scope)
+	matches := []api.Compliance{}
+	for _, finding := range output.Findings {
+		compliance, err := m.compliance(evidence, index, finding)
+		if err != nil {
+			slog.Warn("WebAssembly mapper finding dropped",
+				slog.String("plugin_id", string(m.id)),
+				slog.String("policy_rule_id", evidence.PolicyRuleId),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		matches = append(matches, compliance)
+	}
+
+	if len(matches) == 0 {
+		return m.Mapper.MapAll(evidence, scope)
+	}
+	return matches
+}
+
+// Close releases the module and all of its instances.
+func (m *Mapper) Close() error {
+	return m.runtime.Close(context.Background())
+}
+
+// compliance resolves a finding returned by the module.
+func (m *Mapper) compliance(evidence api.Evidence, index mapper.ScopeIndex, finding Finding) (api.Compliance, error) {
+	compliance, err := mapper.Resolve(index, finding.Catalog, finding.Control, finding.Requirement)
+	if err != nil {
+		return api.Compliance{}, err
+	}
+	compliance.Status = m.Mapper.Status(evidence.PolicyEvaluationStatus, finding.Catalog, finding.Control, finding.Requirement)
+	if finding.Status != "" {
+		if compliance.Status, err = mapper.ParseMappedStatus(finding.Status); err != nil {
+			return api.Compliance{}, err
+		}
+	}
+	if finding.Remediation != "" {
+		remediation := finding.Remediation
+		compliance.Control.RemediationDescription = &remediation
+	}
+	if finding.Target != "" {
+		target := finding.Target
+		compliance.TargetId = &target
+	}
+	return compliance, nil
+}
+
+// call runs compass_map on evidence in an idle or new module instance.
+func (m *Mapper) call(evidence api.Evidence) (Output, error) {
+	input, err := m.input(evidence)
+	if err != nil {
+		return Output{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	instance, err := m.acquire(ctx)
+	if err != nil {
+		return Output{}, err
+	}
+	content, err := invoke(ctx, instance, input)
+	if err != nil {
+		// The instance may be left in any state, or closed by the timeout.
+		_ = instance.Close(context.Background())
+		return Output{}, err
+	}
+	m.release(instance)
+
+	var output Output
+	if err := json.Unmarshal(content, &output); err != nil {
+		return Output{}, fmt.Errorf("invalid output: %w", err)
+	}
+	return output, nil
+}
+
+// input returns the JSON encoded Input for evidence. Only the evidence is
+// encoded for each call; the plans were encoded when they were added, but are
+// still copied into the module memory on every call, so the cost of a call
+// grows with the size of the plans.
+func (m *Mapper) input(evidence api.Evidence) ([]byte, error) {
+	encodedEvidence, err := json.Marshal(evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	input := make([]byte, 0, len(encodedEvidence)+len(m.encodedPlans)+len(`{"evidence":,"plans":}`))
+	input = append(input, `{"evidence":`...)
+	input = append(input, encodedEvidence...)
+	input = append(input, `,"plans":`...)
+	input = append(input, m.encodedPlans...)
+	input = append(input, '}')
+	return input, nil
+}
+
+// invoke writes input into the memory of instance, maps it, and returns a
+// copy of the output.
+func invoke(ctx context.Context, instance wasmapi.Module, input []byte) ([]byte, error) {
+	memory := instance.Memory()
+	if memory == nil {
+		return nil, errors.New("module does not export its memory")
+	}
+
+	results, err := instance.ExportedFunction(allocFunction).Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", allocFunction, err)
+	}
+	inputPtr := uint32(results[0])
+	if !memory.Write(inputPtr, input) {
+		return nil, fmt.Errorf("%s returned a buffer outside of memory", allocFunction)
+	}
+
+	results, err = instance.ExportedFunction(mapFunction).Call(ctx, uint64(inputPtr), uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", mapFunction, err)
+	}
+	outputPtr, outputLen := uint32(results[0]>>32), uint32(results[0])
+	view, ok := memory.Read(outputPtr, outputLen)
+	if !ok {
+		return nil, fmt.Errorf("%s returned output outside of memory", mapFunction)
+	}
+	output := append([]byte(nil), view...)
+
+	if free := instance.ExportedFunction(freeFunction); free != nil {
+		if _, err := free.Call(ctx, uint64(inputPtr), uint64(len(input))); err != nil {
+			return nil, fmt.Errorf("%s: %w", freeFunction, err)
+		}
+		if _, err := free.Call(ctx, uint64(outputPtr), uint64(outputLen)); err != nil {
+			return nil, fmt.Errorf("%s: %w", freeFunction, err)
+		}
+	}
+	return output, nil
+}
+
+// acquire returns an idle instance, or a new one.
+func (m *Mapper) acquire(ctx context.Context) (wasmapi.Module, error) {
+	select {
+	case instance := <-m.idle:
+		return instance, nil
+	default:
+		return m.instantiate(ctx)
+	}
+}
+
+// release keeps instance for reuse, or closes it when enough are idle.
+func (m *Mapper) release(instance wasmapi.Module) {
+	select {
+	case m.idle <- instance:
+	default:
+		_ = instance.Close(context.Background())
+	}
+}
+
+// instantiate creates a module instance. Instances are anonymous, so any
+// number may exist at once. Only stderr is connected, to Compass's stderr.
+func (m *Mapper) instantiate(ctx context.Context) (wasmapi.Module, error) {
+	return m.runtime.InstantiateModule(ctx, m.module, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize").
+		WithStderr(os.Stderr))
+}
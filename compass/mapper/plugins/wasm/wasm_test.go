@@ -0,0 +1,206 @@
+package wasm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/internal/mappertest"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+//go:generate env GOOS=wasip1 GOARCH=wasm go build -C testdata/guest -buildmode=c-shared -trimpath -ldflags=-s -o ../guest.wasm .
+
+// guestModule is testdata/guest built as a WASI reactor. It is checked in so
+// that tests do not need to build it; run go generate after changing the
+// guest.
+var guestModule = filepath.Join("testdata", "guest.wasm")
+
+func testScope() mapper.Scope {
+	return mappertest.Scope("OSPS-B", "OSPS-VM-05", "OSPS-VM-06")
+}
+
+func evidence(ruleID string, rawData map[string]interface{}) api.Evidence {
+	e := api.Evidence{
+		PolicyEngineName:       "scanner",
+		PolicyRuleId:           ruleID,
+		PolicyEvaluationStatus: api.Passed,
+		Timestamp:              time.Now(),
+	}
+	if rawData != nil {
+		e.RawData = &rawData
+	}
+	return e
+}
+
+func newTestMapper(t *testing.T, cfg Config) *Mapper {
+	t.Helper()
+	cfg.Module = guestModule
+	m, err := New("scanner", cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, m.Close()) })
+	m.AddEvaluationPlan("OSPS-B", mappertest.Plan("OSPS-B", "OSPS-VM-05", "cve-scan"), mappertest.Plan("OSPS-B", "OSPS-VM-06", "sbom"))
+	return m
+}
+
+func TestMapper_MapAll(t *testing.T) {
+	m := newTestMapper(t, Config{})
+	scope := testScope()
+
+	tests := []struct {
+		name        string
+		evidence    api.Evidence
+		control     string
+		status      api.ComplianceStatus
+		remediation string
+		target      string
+	}{
+		{
+			name:     "status from evaluation",
+			evidence: evidence("cve-scan", nil),
+			control:  "OSPS-VM-05.01",
+			status:   api.ComplianceStatusCompliant,
+		},
+		{
+			name:        "status and target from module",
+			evidence:    evidence("cve-scan", map[string]interface{}{"severity": "critical", "image": "quay.io/example/app:1.0"}),
+			control:     "OSPS-VM-05.01",
+			status:      api.ComplianceStatusNonCompliant,
+			remediation: "Fix critical findings first.",
+			target:      "quay.io/example/app:1.0",
+		},
+		{
+			name:     "second requirement",
+			evidence: evidence("sbom", nil),
+			control:  "OSPS-VM-06.01",
+			status:   api.ComplianceStatusCompliant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := m.MapAll(tt.evidence, scope)
+			require.Len(t, matches, 1)
+			compliance := matches[0]
+			assert.Equal(t, api.ComplianceEnrichmentStatusSuccess, compliance.EnrichmentStatus)
+			assert.Equal(t, tt.control, compliance.Control.Id)
+			assert.Equal(t, "Test Family", compliance.Control.Category)
+			assert.Equal(t, tt.status, compliance.Status)
+			if tt.remediation != "" {
+				require.NotNil(t, compliance.Control.RemediationDescription)
+				assert.Equal(t, tt.remediation, *compliance.Control.RemediationDescription)
+			}
+			if tt.target != "" {
+				require.NotNil(t, compliance.TargetId)
+				assert.Equal(t, tt.target, *compliance.TargetId)
+			}
+		})
+	}
+
+	t.Run("finding out of scope is dropped", func(t *testing.T) {
+		assert.Empty(t, m.MapAll(evidence("out-of-scope", nil), scope))
+	})
+
+	t.Run("finding with reserved status is dropped", func(t *testing.T) {
+		assert.Empty(t, m.MapAll(evidence("exempt", nil), scope))
+	})
+
+	t.Run("no findings", func(t *testing.T) {
+		compliance := m.Map(evidence("unmatched", nil), scope)
+		assert.Equal(t, api.ComplianceEnrichmentStatusUnmapped, compliance.EnrichmentStatus)
+	})
+}
+
+func TestMapper_Input(t *testing.T) {
+	m := newTestMapper(t, Config{})
+	e := evidence("cve-scan", map[string]interface{}{"image": "quay.io/example/app:1.0"})
+
+	input, err := m.input(e)
+	require.NoError(t, err)
+	expected, err := json.Marshal(Input{Evidence: e, Plans: m.plans})
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(input))
+
+	m.AddEvaluationPlan("OSPS-B", mappertest.Plan("OSPS-B", "OSPS-VM-05", "image-signature"))
+	input, err = m.input(e)
+	require.NoError(t, err)
+	assert.Contains(t, string(input), `"image-signature"`, "plans are encoded again when added")
+}
+
+func TestMapper_Limits(t *testing.T) {
+	m := newTestMapper(t, Config{MaxMemoryMB: 32, Timeout: "200ms"})
+	scope := testScope()
+
+	t.Run("time", func(t *testing.T) {
+		start := time.Now()
+		compliance := m.Map(evidence("loop", nil), scope)
+		assert.Equal(t, api.ComplianceEnrichmentStatusUnmapped, compliance.EnrichmentStatus)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		compliance := m.Map(evidence("grow", nil), scope)
+		assert.Equal(t, api.ComplianceEnrichmentStatusUnmapped, compliance.EnrichmentStatus)
+	})
+
+	t.Run("mapper still works", func(t *testing.T) {
+		compliance := m.Map(evidence("cve-scan", nil), scope)
+		assert.Equal(t, "OSPS-VM-05.01", compliance.Control.Id)
+	})
+}
+
+func TestNew(t *testing.T) {
+	notWasm := filepath.Join(t.TempDir(), "mapper.wasm")
+	require.NoError(t, os.WriteFile(notWasm, []byte("not a module"), 0o600))
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{
+			name:    "missing module",
+			config:  Config{},
+			wantErr: "module is required",
+		},
+		{
+			name:    "module not found",
+			config:  Config{Module: filepath.Join(t.TempDir(), "missing.wasm")},
+			wantErr: "no such file",
+		},
+		{
+			name:    "invalid module",
+			config:  Config{Module: notWasm},
+			wantErr: "module " + notWasm,
+		},
+		{
+			name:    "memory limit too large",
+			config:  Config{Module: guestModule, MaxMemoryMB: 8192},
+			wantErr: "exceeds the 4096 MiB",
+		},
+		{
+			name:    "memory limit below module minimum",
+			config:  Config{Module: guestModule, MaxMemoryMB: 1},
+			wantErr: "module " + guestModule,
+		},
+		{
+			name:    "invalid timeout",
+			config:  Config{Module: guestModule, Timeout: "-1s"},
+			wantErr: "timeout: -1s is not positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("scanner", tt.config)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package mapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Type names a mapper implementation. Several configured plugins may share
+// a type, each with its own ID and evaluation plans.
+type Type string
+
+// Config holds the settings of a configured plugin that are specific to its
+// type.
+type Config map[string]any
+
+// Decode decodes c into v, which must be a pointer to a struct with json
+// tags. Settings that v does not define are rejected.
+func (c Config) Decode(v any) error {
+	if c == nil {
+		c = Config{}
+	}
+	content, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	return nil
+}
+
+// Constructor returns a new mapper for the plugin configured with id and
+// config. It must reject invalid settings.
+type Constructor func(id ID, config Config) (Mapper, error)
+
+var (
+	registryMu   sync.RWMutex
+	constructors = make(map[Type]Constructor)
+)
+
+// Register makes a mapper implementation available under pluginType. It is
+// meant to be called from the init function of plugin packages and panics
+// if pluginType is empty, constructor is nil, or pluginType is registered
+// twice.
+func Register(pluginType Type, constructor Constructor) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if pluginType == "" {
+		panic("mapper: Register with empty plugin type")
+	}
+	if constructor == nil {
+		panic("mapper: Register constructor is nil for " + string(pluginType))
+	}
+	if _, dup := constructors[pluginType]; dup {
+		panic("mapper: Register called twice for " + string(pluginType))
+	}
+	constructors[pluginType] = constructor
+}
+
+// Registered reports whether a mapper implementation is registered under
+// pluginType.
+func Registered(pluginType Type) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	_, ok := constructors[pluginType]
+	return ok
+}
+
+// Types returns the registered plugin types in sorted order.
+func Types() []Type {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]Type, 0, len(constructors))
+	for pluginType := range constructors {
+		types = append(types, pluginType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// New returns a mapper of pluginType for the plugin configured with id and
+// config.
+func New(pluginType Type, id ID, config Config) (Mapper, error) {
+	registryMu.RLock()
+	constructor, ok := constructors[pluginType]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown plugin type %q: must be one of %s", pluginType, typeList())
+	}
+
+	mpr, err := constructor(id, config)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s plugin %s: %w", pluginType, id, err)
+	}
+	return mpr, nil
+}
+
+func typeList() string {
+	types := Types()
+	names := make([]string, len(types))
+	for i, pluginType := range types {
+		names[i] = string(pluginType)
+	}
+	return strings.Join(names, ", ")
+}
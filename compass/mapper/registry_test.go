@@ -0,0 +1,61 @@
+package mapper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	Register("test-mock", func(id ID, config Config) (Mapper, error) {
+		var settings struct {
+			Name string `json:"name"`
+		}
+		if err := config.Decode(&settings); err != nil {
+			return nil, err
+		}
+		return &mockMapper{id: id}, nil
+	})
+	Register("test-broken", func(id ID, _ Config) (Mapper, error) {
+		return nil, errors.New("broken")
+	})
+
+	t.Run("new mapper uses configured id", func(t *testing.T) {
+		mpr, err := New("test-mock", "conforma", Config{"name": "x"})
+		require.NoError(t, err)
+		assert.Equal(t, ID("conforma"), mpr.PluginName())
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := New("missing", "conforma", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown plugin type "missing"`)
+		assert.Contains(t, err.Error(), "test-mock")
+	})
+
+	t.Run("unknown setting", func(t *testing.T) {
+		_, err := New("test-mock", "conforma", Config{"nmae": "x"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown field "nmae"`)
+	})
+
+	t.Run("constructor error", func(t *testing.T) {
+		_, err := New("test-broken", "conforma", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "broken")
+	})
+
+	t.Run("registered types", func(t *testing.T) {
+		assert.True(t, Registered("test-mock"))
+		assert.False(t, Registered("missing"))
+		assert.Subset(t, Types(), []Type{"test-broken", "test-mock"})
+	})
+
+	t.Run("register panics", func(t *testing.T) {
+		assert.Panics(t, func() { Register("", func(ID, Config) (Mapper, error) { return nil, nil }) })
+		assert.Panics(t, func() { Register("test-nil", nil) })
+		assert.Panics(t, func() { Register("test-mock", func(ID, Config) (Mapper, error) { return nil, nil }) })
+	})
+}
@@ -0,0 +1,154 @@
+package mapper
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/complytime/complybeacon/compass/api"
+)
+
+// StatusMapping translates policy evaluation results to compliance statuses
+// for one plugin. An override for a control applies before an override for
+// its catalog, which applies before Results. Results that none of them list
+// keep the status given by StatusFromEvaluation.
+type StatusMapping struct {
+	// Results maps evaluation results, such as "Not Run", to compliance
+	// statuses, such as "Non-Compliant".
+	Results StatusTable `json:"results"`
+	// Overrides replace entries of Results for a catalog or for a single
+	// control.
+	Overrides []StatusOverride `json:"overrides"`
+}
+
+// StatusOverride maps evaluation results for the findings of one catalog,
+// or of one control or assessment requirement when Control is set.
+type StatusOverride struct {
+	Catalog string `json:"catalog"`
+	// Control is a control ID or an assessment requirement ID.
+	Control string      `json:"control"`
+	Results StatusTable `json:"results"`
+}
+
+// StatusTable maps evaluation results to compliance statuses.
+type StatusTable map[api.EvidencePolicyEvaluationStatus]api.ComplianceStatus
+
+// evaluationStatuses lists the values of api.EvidencePolicyEvaluationStatus.
+var evaluationStatuses = []api.EvidencePolicyEvaluationStatus{
+	api.Passed,
+	api.Failed,
+	api.NeedsReview,
+	api.NotRun,
+	api.NotApplicable,
+	api.Unknown,
+}
+
+// ParseEvaluationStatus returns the api.EvidencePolicyEvaluationStatus named
+// by status.
+func ParseEvaluationStatus(status string) (api.EvidencePolicyEvaluationStatus, error) {
+	for _, valid := range evaluationStatuses {
+		if string(valid) == status {
+			return valid, nil
+		}
+	}
+	names := make([]string, len(evaluationStatuses))
+	for i, valid := range evaluationStatuses {
+		names[i] = fmt.Sprintf("%q", valid)
+	}
+	return "", fmt.Errorf("unknown evaluation result %q: must be one of %s", status, strings.Join(names, ", "))
+}
+
+// Validate checks that every table maps known evaluation results to known
+// compliance statuses, and that each catalog or control is overridden once.
+// Exempt is rejected as a target, as it is reserved for exceptions.
+func (s StatusMapping) Validate() error {
+	if err := s.Results.validate(); err != nil {
+		return fmt.Errorf("results: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, override := range s.Overrides {
+		if override.Catalog == "" {
+			return fmt.Errorf("overrides[%d]: catalog is required", i)
+		}
+		key := override.Catalog + "/" + override.Control
+		if seen[key] {
+			if override.Control == "" {
+				return fmt.Errorf("overrides[%d]: catalog %s is overridden more than once", i, override.Catalog)
+			}
+			return fmt.Errorf("overrides[%d]: control %s of catalog %s is overridden more than once", i, override.Control, override.Catalog)
+		}
+		seen[key] = true
+		if len(override.Results) == 0 {
+			return fmt.Errorf("overrides[%d]: results are required", i)
+		}
+		if err := override.Results.validate(); err != nil {
+			return fmt.Errorf("overrides[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (t StatusTable) validate() error {
+	results := make([]api.EvidencePolicyEvaluationStatus, 0, len(t))
+	for result := range t {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+
+	var errs []error
+	for _, result := range results {
+		status := t[result]
+		if _, err := ParseEvaluationStatus(string(result)); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, err := ParseMappedStatus(string(status)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithDefaults returns s with the entries of defaults added to Results for
+// the evaluation results that Results does not list. Plugins use it to keep
+// their own defaults when their status mapping is replaced.
+func (s StatusMapping) WithDefaults(defaults StatusTable) StatusMapping {
+	results := make(StatusTable, len(defaults)+len(s.Results))
+	maps.Copy(results, defaults)
+	maps.Copy(results, s.Results)
+	s.Results = results
+	return s
+}
+
+// Status returns the compliance status for an evaluation result on a finding
+// for the given catalog. controlIDs are the IDs of the finding's control and
+// assessment requirement; an override matching either applies.
+func (s StatusMapping) Status(result api.EvidencePolicyEvaluationStatus, catalogID string, controlIDs ...string) api.ComplianceStatus {
+	var catalogOverride StatusTable
+	for _, override := range s.Overrides {
+		if override.Catalog != catalogID {
+			continue
+		}
+		if override.Control == "" {
+			catalogOverride = override.Results
+			continue
+		}
+		for _, id := range controlIDs {
+			if id != "" && id == override.Control {
+				if status, ok := override.Results[result]; ok {
+					return status
+				}
+			}
+		}
+	}
+	if status, ok := catalogOverride[result]; ok {
+		return status
+	}
+	if status, ok := s.Results[result]; ok {
+		return status
+	}
+	return StatusFromEvaluation(result)
+}
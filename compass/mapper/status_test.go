@@ -0,0 +1,162 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+)
+
+func TestStatusMappingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping StatusMapping
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			mapping: StatusMapping{},
+		},
+		{
+			name: "valid",
+			mapping: StatusMapping{
+				Results: StatusTable{api.NotRun: api.ComplianceStatusNonCompliant},
+				Overrides: []StatusOverride{
+					{Catalog: "CIS", Results: StatusTable{api.NotRun: api.ComplianceStatusNotApplicable}},
+					{Catalog: "CIS", Control: "CIS-1", Results: StatusTable{api.NeedsReview: api.ComplianceStatusNonCompliant}},
+				},
+			},
+		},
+		{
+			name:    "unknown result",
+			mapping: StatusMapping{Results: StatusTable{"Skipped": api.ComplianceStatusNotApplicable}},
+			wantErr: `results: unknown evaluation result "Skipped"`,
+		},
+		{
+			name:    "unknown status",
+			mapping: StatusMapping{Results: StatusTable{api.NotRun: "Failing"}},
+			wantErr: `results: Not Run: unknown compliance status "Failing"`,
+		},
+		{
+			name:    "exempt",
+			mapping: StatusMapping{Results: StatusTable{api.NotApplicable: api.ComplianceStatusExempt}},
+			wantErr: `results: Not Applicable: "Exempt" is reserved for exceptions`,
+		},
+		{
+			name:    "override without catalog",
+			mapping: StatusMapping{Overrides: []StatusOverride{{Control: "CIS-1", Results: StatusTable{api.NotRun: api.ComplianceStatusNonCompliant}}}},
+			wantErr: "overrides[0]: catalog is required",
+		},
+		{
+			name:    "override without results",
+			mapping: StatusMapping{Overrides: []StatusOverride{{Catalog: "CIS"}}},
+			wantErr: "overrides[0]: results are required",
+		},
+		{
+			name: "duplicate control override",
+			mapping: StatusMapping{Overrides: []StatusOverride{
+				{Catalog: "CIS", Control: "CIS-1", Results: StatusTable{api.NotRun: api.ComplianceStatusNonCompliant}},
+				{Catalog: "CIS", Control: "CIS-1", Results: StatusTable{api.Failed: api.ComplianceStatusNotApplicable}},
+			}},
+			wantErr: "overrides[1]: control CIS-1 of catalog CIS is overridden more than once",
+		},
+		{
+			name: "invalid override",
+			mapping: StatusMapping{Overrides: []StatusOverride{
+				{Catalog: "CIS", Results: StatusTable{api.Passed: "Green"}},
+			}},
+			wantErr: `overrides[0]: Passed: unknown compliance status "Green"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mapping.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestStatusMappingStatus(t *testing.T) {
+	mapping := StatusMapping{
+		Results: StatusTable{
+			api.NotRun:      api.ComplianceStatusNonCompliant,
+			api.NeedsReview: api.ComplianceStatusNonCompliant,
+		},
+		Overrides: []StatusOverride{
+			{Catalog: "CIS", Results: StatusTable{api.NotRun: api.ComplianceStatusNotApplicable}},
+			{Catalog: "CIS", Control: "CIS-1.01", Results: StatusTable{api.NotRun: api.ComplianceStatusNeedsReview}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		result     api.EvidencePolicyEvaluationStatus
+		catalogID  string
+		controlIDs []string
+		want       api.ComplianceStatus
+	}{
+		{
+			name:      "default",
+			result:    api.Failed,
+			catalogID: "OSPS-B",
+			want:      api.ComplianceStatusNonCompliant,
+		},
+		{
+			name:      "results",
+			result:    api.NotRun,
+			catalogID: "OSPS-B",
+			want:      api.ComplianceStatusNonCompliant,
+		},
+		{
+			name:       "catalog override",
+			result:     api.NotRun,
+			catalogID:  "CIS",
+			controlIDs: []string{"CIS-2", "CIS-2.01"},
+			want:       api.ComplianceStatusNotApplicable,
+		},
+		{
+			name:       "control override",
+			result:     api.NotRun,
+			catalogID:  "CIS",
+			controlIDs: []string{"CIS-1", "CIS-1.01"},
+			want:       api.ComplianceStatusNeedsReview,
+		},
+		{
+			name:       "result not overridden",
+			result:     api.NeedsReview,
+			catalogID:  "CIS",
+			controlIDs: []string{"CIS-1", "CIS-1.01"},
+			want:       api.ComplianceStatusNonCompliant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mapping.Status(tt.result, tt.catalogID, tt.controlIDs...))
+		})
+	}
+}
+
+func TestStatusMappingWithDefaults(t *testing.T) {
+	mapping := StatusMapping{Results: StatusTable{api.NotRun: api.ComplianceStatusNonCompliant}}
+	defaults := StatusTable{
+		api.NotRun:      api.ComplianceStatusNotApplicable,
+		api.NeedsReview: api.ComplianceStatusNeedsReview,
+	}
+
+	withDefaults := mapping.WithDefaults(defaults)
+	assert.Equal(t, StatusTable{
+		api.NotRun:      api.ComplianceStatusNonCompliant,
+		api.NeedsReview: api.ComplianceStatusNeedsReview,
+	}, withDefaults.Results)
+	assert.Equal(t, StatusTable{api.NotRun: api.ComplianceStatusNonCompliant}, mapping.Results, "the mapping is not modified")
+	assert.Equal(t, api.ComplianceStatusUnknown, StatusMapping{}.Status(api.NeedsReview, "CIS"))
+}
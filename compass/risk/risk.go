@@ -0,0 +1,126 @@
+// Package risk derives the risk level of an enriched compliance finding from
+// the Layer 2 threat and capability data linked to its control, with
+// per-control and per-family overrides.
+package risk
+
+import (
+	"fmt"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// severity orders risk levels from least to most severe.
+var severity = map[api.ComplianceRiskLevel]int{
+	api.Informational: 1,
+	api.Low:           2,
+	api.Medium:        3,
+	api.High:          4,
+	api.Critical:      5,
+}
+
+// Config defines the rules used to assign a risk level to a control.
+//
+// A level is chosen by the first rule that applies, in this order: the
+// control ID in Controls, the family ID or title in Families, the most severe
+// level of the threats linked to the control through its threat mappings and
+// of the capabilities those threats target, and finally Default.
+type Config struct {
+	// Default is the level for mapped controls that no other rule covers.
+	// When empty, such controls have no risk level.
+	Default api.ComplianceRiskLevel `json:"default"`
+	// Threats assigns levels by Layer 2 threat ID.
+	Threats map[string]api.ComplianceRiskLevel `json:"threats"`
+	// Capabilities assigns levels by Layer 2 capability ID.
+	Capabilities map[string]api.ComplianceRiskLevel `json:"capabilities"`
+	// Families assigns levels by control family ID or title.
+	Families map[string]api.ComplianceRiskLevel `json:"families"`
+	// Controls assigns levels by control ID.
+	Controls map[string]api.ComplianceRiskLevel `json:"controls"`
+}
+
+// Validate checks that every configured level is a known risk level.
+func (c Config) Validate() error {
+	if c.Default != "" {
+		if err := validateLevel(c.Default); err != nil {
+			return fmt.Errorf("risk default: %w", err)
+		}
+	}
+	rules := []struct {
+		name   string
+		levels map[string]api.ComplianceRiskLevel
+	}{
+		{"threats", c.Threats},
+		{"capabilities", c.Capabilities},
+		{"families", c.Families},
+		{"controls", c.Controls},
+	}
+	for _, rule := range rules {
+		for id, level := range rule.levels {
+			if err := validateLevel(level); err != nil {
+				return fmt.Errorf("risk %s %s: %w", rule.name, id, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Assess returns the risk level for the control in entry, or nil when no rule
+// applies. index is the catalog the control belongs to and is used to resolve
+// threats to their capabilities.
+func (c Config) Assess(entry mapper.ControlEntry, index *mapper.CatalogIndex) *api.ComplianceRiskLevel {
+	if level, ok := c.Controls[entry.Control.Id]; ok {
+		return &level
+	}
+	if level, ok := c.Families[entry.Family.Id]; ok && entry.Family.Id != "" {
+		return &level
+	}
+	if level, ok := c.Families[entry.Family.Title]; ok {
+		return &level
+	}
+
+	var derived api.ComplianceRiskLevel
+	for _, mapping := range entry.Control.ThreatMappings {
+		for _, threatRef := range mapping.Entries {
+			derived = mostSevere(derived, c.Threats[threatRef.ReferenceId])
+
+			if index == nil {
+				continue
+			}
+			threat, ok := index.Threat(threatRef.ReferenceId)
+			if !ok {
+				continue
+			}
+			for _, capabilityMapping := range threat.Capabilities {
+				for _, capabilityRef := range capabilityMapping.Entries {
+					derived = mostSevere(derived, c.Capabilities[capabilityRef.ReferenceId])
+				}
+			}
+		}
+	}
+	if derived != "" {
+		return &derived
+	}
+
+	if c.Default != "" {
+		level := c.Default
+		return &level
+	}
+	return nil
+}
+
+// mostSevere returns the more severe of two levels. Unknown or empty levels
+// lose to any known level.
+func mostSevere(a, b api.ComplianceRiskLevel) api.ComplianceRiskLevel {
+	if severity[b] > severity[a] {
+		return b
+	}
+	return a
+}
+
+func validateLevel(level api.ComplianceRiskLevel) error {
+	if _, ok := severity[level]; !ok {
+		return fmt.Errorf("unknown risk level %q", level)
+	}
+	return nil
+}
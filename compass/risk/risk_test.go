@@ -0,0 +1,121 @@
+package risk
+
+import (
+	"testing"
+
+	"github.com/ossf/gemara/layer2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+func testIndex() *mapper.CatalogIndex {
+	return mapper.NewCatalogIndex(layer2.Catalog{
+		Metadata: layer2.Metadata{Id: "test-catalog"},
+		ControlFamilies: []layer2.ControlFamily{
+			{
+				Id:    "AC",
+				Title: "Access Control",
+				Controls: []layer2.Control{
+					{
+						Id: "AC-1",
+						ThreatMappings: []layer2.Mapping{
+							{ReferenceId: "test-catalog", Entries: []layer2.MappingEntry{{ReferenceId: "TH-1"}, {ReferenceId: "TH-2"}}},
+						},
+					},
+					{Id: "AC-2"},
+				},
+			},
+		},
+		Threats: []layer2.Threat{
+			{Id: "TH-1"},
+			{
+				Id: "TH-2",
+				Capabilities: []layer2.Mapping{
+					{ReferenceId: "test-catalog", Entries: []layer2.MappingEntry{{ReferenceId: "CP-1"}}},
+				},
+			},
+		},
+	})
+}
+
+func TestConfigAssess(t *testing.T) {
+	index := testIndex()
+
+	tests := []struct {
+		name      string
+		config    Config
+		controlID string
+		expected  *api.ComplianceRiskLevel
+	}{
+		{
+			name:      "no rules",
+			config:    Config{},
+			controlID: "AC-1",
+			expected:  nil,
+		},
+		{
+			name:      "default",
+			config:    Config{Default: api.Low},
+			controlID: "AC-2",
+			expected:  levelPtr(api.Low),
+		},
+		{
+			name:      "most severe linked threat",
+			config:    Config{Default: api.Low, Threats: map[string]api.ComplianceRiskLevel{"TH-1": api.Medium, "TH-2": api.High}},
+			controlID: "AC-1",
+			expected:  levelPtr(api.High),
+		},
+		{
+			name:      "capability of linked threat",
+			config:    Config{Threats: map[string]api.ComplianceRiskLevel{"TH-1": api.Medium}, Capabilities: map[string]api.ComplianceRiskLevel{"CP-1": api.Critical}},
+			controlID: "AC-1",
+			expected:  levelPtr(api.Critical),
+		},
+		{
+			name:      "family title override",
+			config:    Config{Threats: map[string]api.ComplianceRiskLevel{"TH-1": api.Critical}, Families: map[string]api.ComplianceRiskLevel{"Access Control": api.Medium}},
+			controlID: "AC-1",
+			expected:  levelPtr(api.Medium),
+		},
+		{
+			name:      "family id override",
+			config:    Config{Families: map[string]api.ComplianceRiskLevel{"AC": api.Informational}},
+			controlID: "AC-2",
+			expected:  levelPtr(api.Informational),
+		},
+		{
+			name:      "control override wins",
+			config:    Config{Families: map[string]api.ComplianceRiskLevel{"AC": api.Low}, Controls: map[string]api.ComplianceRiskLevel{"AC-1": api.Critical}},
+			controlID: "AC-1",
+			expected:  levelPtr(api.Critical),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, ok := index.Control(tt.controlID)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, tt.config.Assess(entry, index))
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, Config{}.Validate())
+	assert.NoError(t, Config{Default: api.Medium, Controls: map[string]api.ComplianceRiskLevel{"AC-1": api.High}}.Validate())
+
+	err := Config{Default: "Severe"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "risk default")
+
+	err = Config{Families: map[string]api.ComplianceRiskLevel{"AC": "high"}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "risk families AC")
+}
+
+func levelPtr(level api.ComplianceRiskLevel) *api.ComplianceRiskLevel {
+	return &level
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/ossf/gemara/layer2"
+
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// applicabilityOf returns the environments or contexts the mapped control
+// applies to. For an assessment requirement this is its own applicability;
+// for a control it is the combined applicability of its requirements.
+func applicabilityOf(entry mapper.RequirementEntry) []string {
+	if entry.Requirement != nil {
+		return append([]string(nil), entry.Requirement.Applicability...)
+	}
+
+	var applicability []string
+	seen := make(map[string]bool)
+	for _, requirement := range entry.Control.AssessmentRequirements {
+		for _, value := range requirement.Applicability {
+			if !seen[value] {
+				seen[value] = true
+				applicability = append(applicability, value)
+			}
+		}
+	}
+	return applicability
+}
+
+// environmentCategories returns the catalog applicability categories that
+// name target environments, as listed by ID or title in names. Categories such
+// as maturity levels are not listed, so they never restrict the environment.
+func environmentCategories(categories []layer2.Category, names []string) []layer2.Category {
+	var environments []layer2.Category
+	for _, category := range categories {
+		for _, name := range names {
+			if matchesCategory(name, category) {
+				environments = append(environments, category)
+				break
+			}
+		}
+	}
+	return environments
+}
+
+// appliesTo reports whether a control with the given applicability values
+// applies to environment. Only values naming one of the environment categories
+// restrict the environment; a control with none of them applies everywhere.
+// Matching ignores case, and a category matches either its ID or its title.
+func appliesTo(applicability []string, environment string, environments []layer2.Category) bool {
+	environment = strings.TrimSpace(environment)
+	if environment == "" {
+		return true
+	}
+
+	restricted := false
+	for _, value := range applicability {
+		for _, category := range environments {
+			if !matchesCategory(value, category) {
+				continue
+			}
+			restricted = true
+			if matchesCategory(environment, category) {
+				return true
+			}
+		}
+	}
+	return !restricted
+}
+
+// matchesCategory reports whether value names category by ID or title,
+// ignoring case.
+func matchesCategory(value string, category layer2.Category) bool {
+	value = strings.TrimSpace(value)
+	return strings.EqualFold(value, category.Id) || strings.EqualFold(value, category.Title)
+}
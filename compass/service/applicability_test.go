@@ -0,0 +1,114 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ossf/gemara/layer2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+func TestEnvironmentCategories(t *testing.T) {
+	categories := []layer2.Category{
+		{Id: "Maturity1", Title: "Maturity Level 1"},
+		{Id: "prod", Title: "Production"},
+		{Id: "Staging"},
+	}
+	assert.Equal(t, []layer2.Category{{Id: "prod", Title: "Production"}, {Id: "Staging"}},
+		environmentCategories(categories, []string{"production", "Staging", "Development"}))
+	assert.Empty(t, environmentCategories(categories, nil))
+}
+
+func TestAppliesTo(t *testing.T) {
+	environments := []layer2.Category{
+		{Id: "prod", Title: "Production"},
+		{Id: "Staging"},
+	}
+
+	tests := []struct {
+		name          string
+		applicability []string
+		environment   string
+		environments  []layer2.Category
+		expected      bool
+	}{
+		{name: "exact match", applicability: []string{"Production", "Staging"}, environment: "Staging", environments: environments, expected: true},
+		{name: "case-insensitive match", applicability: []string{"Production"}, environment: "production", environments: environments, expected: true},
+		{name: "category title matches id", applicability: []string{"Production"}, environment: "PROD", environments: environments, expected: true},
+		{name: "category id matches title", applicability: []string{"prod"}, environment: "production", environments: environments, expected: true},
+		{name: "other environment", applicability: []string{"Production"}, environment: "Staging", environments: environments, expected: false},
+		{name: "empty environment applies", applicability: []string{"Production"}, environment: " ", environments: environments, expected: true},
+		{name: "no environment applicability", applicability: []string{"Maturity Level 3"}, environment: "Staging", environments: environments, expected: true},
+		{name: "no environment categories", applicability: []string{"Maturity Level 3"}, environment: "production", expected: true},
+		{name: "undeclared value is ignored", applicability: []string{"Production"}, environment: "Staging", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, appliesTo(tt.applicability, tt.environment, tt.environments))
+		})
+	}
+}
+
+func TestServiceProcessApplicability(t *testing.T) {
+	mapperPlugin, scope := newProcessFixture()
+
+	tests := []struct {
+		name           string
+		environments   []string
+		environment    *string
+		expectedStatus api.ComplianceStatus
+	}{
+		{name: "no target environment", environments: []string{"Production", "Staging"}, environment: nil, expectedStatus: api.ComplianceStatusNonCompliant},
+		{name: "applicable environment", environments: []string{"Production", "Staging"}, environment: stringPtr("Production"), expectedStatus: api.ComplianceStatusNonCompliant},
+		{name: "other environment", environments: []string{"Production", "Staging"}, environment: stringPtr("Staging"), expectedStatus: api.ComplianceStatusNotApplicable},
+		{name: "no environment categories configured", environment: stringPtr("Staging"), expectedStatus: api.ComplianceStatusNonCompliant},
+		{name: "environment categories not in catalog", environments: []string{"development"}, environment: stringPtr("Staging"), expectedStatus: api.ComplianceStatusNonCompliant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(mapper.Set{}, scope, WithEnvironmentCategories(tt.environments...))
+			evidence := api.Evidence{
+				PolicyEngineName:        "test-policy-engine",
+				PolicyRuleId:            "AC-1",
+				PolicyEvaluationStatus:  api.Failed,
+				PolicyTargetEnvironment: tt.environment,
+				Timestamp:               time.Now(),
+			}
+			response := service.process(evidence, mapperPlugin, scope)
+			assert.Equal(t, tt.expectedStatus, response.Compliance.Status)
+			require.NotNil(t, response.Compliance.Control.Applicability)
+			assert.Equal(t, []string{"Production"}, *response.Compliance.Control.Applicability)
+		})
+	}
+}
+
+func TestServiceProcessMaturityApplicability(t *testing.T) {
+	mapperPlugin, scope := newProcessFixture()
+	catalog := scope["test-catalog"]
+	catalog.Metadata.ApplicabilityCategories = []layer2.Category{{Id: "Maturity1", Title: "Maturity Level 1"}}
+	catalog.ControlFamilies[0].Controls[0].AssessmentRequirements[0].Applicability = []string{"Maturity1"}
+	scope["test-catalog"] = catalog
+	service := NewService(mapper.Set{}, scope, WithEnvironmentCategories("Production", "Staging"))
+
+	evidence := api.Evidence{
+		PolicyEngineName:        "test-policy-engine",
+		PolicyRuleId:            "AC-1",
+		PolicyEvaluationStatus:  api.Failed,
+		PolicyTargetEnvironment: stringPtr("production"),
+		Timestamp:               time.Now(),
+	}
+	response := service.process(evidence, mapperPlugin, scope)
+	assert.Equal(t, api.ComplianceStatusNonCompliant, response.Compliance.Status)
+	require.NotNil(t, response.Compliance.Control.Applicability)
+	assert.Equal(t, []string{"Maturity1"}, *response.Compliance.Control.Applicability)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
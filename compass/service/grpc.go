@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/api/compassv1"
+	"github.com/complytime/complybeacon/compass/internal/protoconv"
+)
+
+// GRPCServer serves the enrichment API over gRPC. It enriches evidence with
+// the same Service logic as the HTTP handlers.
+type GRPCServer struct {
+	compassv1.UnimplementedEnrichmentServiceServer
+	service *Service
+}
+
+var _ compassv1.EnrichmentServiceServer = (*GRPCServer)(nil)
+
+// NewGRPCServer returns a gRPC enrichment server backed by service.
+func NewGRPCServer(service *Service) *GRPCServer {
+	return &GRPCServer{service: service}
+}
+
+// Enrich handles a single enrichment request.
+func (g *GRPCServer) Enrich(ctx context.Context, req *compassv1.EnrichmentRequest) (*compassv1.EnrichmentResponse, error) {
+	evidence, mode, err := fromProtoRequest(req)
+	if err != nil {
+		slog.Warn("invalid grpc enrichment request", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response := g.service.enrichEvidence(ctx, evidence, mode)
+	return toProtoResponse(response), nil
+}
+
+// EnrichStream handles a stream of enrichment requests, sending one result per
+// request in order.
+func (g *GRPCServer) EnrichStream(stream compassv1.EnrichmentService_EnrichStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		result := &compassv1.EnrichmentResult{}
+		evidence, mode, err := fromProtoRequest(req)
+		if err != nil {
+			result.Result = &compassv1.EnrichmentResult_Error{
+				Error: &compassv1.Error{Code: http.StatusBadRequest, Message: err.Error()},
+			}
+		} else {
+			result.Result = &compassv1.EnrichmentResult_Response{
+				Response: toProtoResponse(g.service.enrichEvidence(stream.Context(), evidence, mode)),
+			}
+		}
+
+		if err := stream.Send(result); err != nil {
+			return err
+		}
+	}
+}
+
+// fromProtoRequest converts a gRPC request to the evidence and mode used by
+// the HTTP API, applying the same validation.
+func fromProtoRequest(req *compassv1.EnrichmentRequest) (api.Evidence, *api.EnrichmentMode, error) {
+	evidence, err := protoconv.EvidenceFromProto(req.GetEvidence())
+	if err != nil {
+		return api.Evidence{}, nil, err
+	}
+	if err := validateEvidence(evidence); err != nil {
+		return api.Evidence{}, nil, err
+	}
+
+	var mode *api.EnrichmentMode
+	if req.GetMode() == compassv1.EnrichmentMode_ENRICHMENT_MODE_ALL {
+		all := api.All
+		mode = &all
+	}
+	return evidence, mode, nil
+}
+
+func toProtoResponse(response api.EnrichmentResponse) *compassv1.EnrichmentResponse {
+	out := &compassv1.EnrichmentResponse{
+		Compliance: protoconv.ComplianceToProto(response.Compliance),
+	}
+	if response.Matches != nil {
+		for _, match := range *response.Matches {
+			out.Matches = append(out.Matches, protoconv.ComplianceToProto(match))
+		}
+	}
+	return out
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/complytime/complybeacon/compass/api/compassv1"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// newGRPCClient serves service over an in-memory connection and returns a
+// client for it.
+func newGRPCClient(t *testing.T, service *Service) compassv1.EnrichmentServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	compassv1.RegisterEnrichmentServiceServer(server, NewGRPCServer(service))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return compassv1.NewEnrichmentServiceClient(conn)
+}
+
+func TestGRPCServerEnrich(t *testing.T) {
+	mapperPlugin, scope := newProcessFixture()
+	client := newGRPCClient(t, NewService(mapper.Set{"test-policy-engine": mapperPlugin}, scope))
+
+	evidence := &compassv1.Evidence{
+		Timestamp:              timestamppb.New(time.Now()),
+		PolicyEngineName:       "test-policy-engine",
+		PolicyRuleId:           "AC-1",
+		PolicyEvaluationStatus: compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_FAILED,
+	}
+
+	t.Run("mapped evidence", func(t *testing.T) {
+		response, err := client.Enrich(context.Background(), &compassv1.EnrichmentRequest{Evidence: evidence})
+		require.NoError(t, err)
+
+		compliance := response.GetCompliance()
+		assert.Equal(t, compassv1.EnrichmentStatus_ENRICHMENT_STATUS_SUCCESS, compliance.GetEnrichmentStatus())
+		assert.Equal(t, compassv1.ComplianceStatus_COMPLIANCE_STATUS_NON_COMPLIANT, compliance.GetStatus())
+		assert.Equal(t, "test-catalog", compliance.GetControl().GetCatalogId())
+		assert.Equal(t, "AC-1.01", compliance.GetControl().GetId())
+		assert.Equal(t, "Access Control", compliance.GetControl().GetCategory())
+		assert.Equal(t, []string{"Production"}, compliance.GetControl().GetApplicability())
+		assert.Empty(t, response.GetMatches())
+	})
+
+	t.Run("all mode returns matches", func(t *testing.T) {
+		response, err := client.Enrich(context.Background(), &compassv1.EnrichmentRequest{
+			Evidence: evidence,
+			Mode:     compassv1.EnrichmentMode_ENRICHMENT_MODE_ALL,
+		})
+		require.NoError(t, err)
+		require.Len(t, response.GetMatches(), 1)
+		assert.Equal(t, "AC-1.01", response.GetMatches()[0].GetControl().GetId())
+	})
+
+	t.Run("invalid evidence", func(t *testing.T) {
+		_, err := client.Enrich(context.Background(), &compassv1.EnrichmentRequest{
+			Evidence: &compassv1.Evidence{
+				Timestamp:              timestamppb.New(time.Now()),
+				PolicyEngineName:       "test-policy-engine",
+				PolicyEvaluationStatus: compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_PASSED,
+			},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGRPCServerEnrichStream(t *testing.T) {
+	mapperPlugin, scope := newProcessFixture()
+	client := newGRPCClient(t, NewService(mapper.Set{"test-policy-engine": mapperPlugin}, scope))
+
+	stream, err := client.EnrichStream(context.Background())
+	require.NoError(t, err)
+
+	requests := []*compassv1.EnrichmentRequest{
+		{Evidence: &compassv1.Evidence{
+			Timestamp:              timestamppb.New(time.Now()),
+			PolicyEngineName:       "test-policy-engine",
+			PolicyRuleId:           "AC-1",
+			PolicyEvaluationStatus: compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_PASSED,
+		}},
+		{Evidence: &compassv1.Evidence{
+			Timestamp:        timestamppb.New(time.Now()),
+			PolicyEngineName: "test-policy-engine",
+			PolicyRuleId:     "AC-1",
+		}},
+		{Evidence: &compassv1.Evidence{
+			Timestamp:              timestamppb.New(time.Now()),
+			PolicyEngineName:       "test-policy-engine",
+			PolicyRuleId:           "unknown",
+			PolicyEvaluationStatus: compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_FAILED,
+		}},
+	}
+	for _, request := range requests {
+		require.NoError(t, stream.Send(request))
+	}
+	require.NoError(t, stream.CloseSend())
+
+	var results []*compassv1.EnrichmentResult
+	for range requests {
+		result, err := stream.Recv()
+		require.NoError(t, err)
+		results = append(results, result)
+	}
+
+	// Results keep request order.
+	assert.Equal(t, compassv1.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT, results[0].GetResponse().GetCompliance().GetStatus())
+	require.NotNil(t, results[1].GetError())
+	assert.Equal(t, int32(400), results[1].GetError().GetCode())
+	assert.Contains(t, results[1].GetError().GetMessage(), "policyEvaluationStatus")
+	assert.Equal(t, compassv1.EnrichmentStatus_ENRICHMENT_STATUS_UNMAPPED, results[2].GetResponse().GetCompliance().GetEnrichmentStatus())
+}
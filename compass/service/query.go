@@ -0,0 +1,246 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ossf/gemara/layer2"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/coverage"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+// GetV1Catalogs handles the GET /v1/catalogs endpoint.
+func (s *Service) GetV1Catalogs(c *gin.Context) {
+	_, scope, release := s.snapshot()
+	defer release()
+
+	catalogs := make([]api.CatalogSummary, 0, len(scope))
+	for _, catalogID := range sortedCatalogIDs(scope) {
+		catalog := scope[catalogID]
+		summary := api.CatalogSummary{
+			Id:    catalogID,
+			Title: catalog.Metadata.Title,
+		}
+		if catalog.Metadata.Version != "" {
+			summary.Version = &catalog.Metadata.Version
+		}
+		for _, family := range catalog.ControlFamilies {
+			summary.ControlCount += len(family.Controls)
+		}
+		catalogs = append(catalogs, summary)
+	}
+
+	c.JSON(http.StatusOK, api.CatalogList{Catalogs: catalogs})
+}
+
+// GetV1CatalogsCatalogIdControlsControlId handles the
+// GET /v1/catalogs/{catalogId}/controls/{controlId} endpoint.
+func (s *Service) GetV1CatalogsCatalogIdControlsControlId(c *gin.Context, catalogId string, controlId string) {
+	_, scope, release := s.snapshot()
+	defer release()
+
+	catalogIndex, ok := s.index.Get(scope)[catalogId]
+	if !ok {
+		sendCompassError(c, http.StatusNotFound, fmt.Sprintf("catalog %s is not loaded", catalogId))
+		return
+	}
+	entry, ok := catalogIndex.Control(controlId)
+	if !ok {
+		sendCompassError(c, http.StatusNotFound, fmt.Sprintf("control %s not found in catalog %s", controlId, catalogId))
+		return
+	}
+
+	c.JSON(http.StatusOK, catalogControl(entry))
+}
+
+// GetV1PluginsPluginIdProcedures handles the GET /v1/plugins/{pluginId}/procedures
+// endpoint.
+func (s *Service) GetV1PluginsPluginIdProcedures(c *gin.Context, pluginId string) {
+	set, _, release := s.snapshot()
+	defer release()
+
+	mapperPlugin, ok := set[mapper.ID(pluginId)]
+	if !ok {
+		sendCompassError(c, http.StatusNotFound, fmt.Sprintf("plugin %s is not configured", pluginId))
+		return
+	}
+	lister, ok := mapperPlugin.(mapper.ProcedureLister)
+	if !ok {
+		sendCompassError(c, http.StatusNotImplemented, fmt.Sprintf("plugin %s cannot list its procedures", pluginId))
+		return
+	}
+
+	procedures := []api.Procedure{}
+	for _, procedure := range lister.Procedures() {
+		item := api.Procedure{
+			PolicyRuleId:  procedure.PolicyRuleID,
+			CatalogId:     procedure.CatalogID,
+			ControlId:     procedure.ControlID,
+			RequirementId: procedure.RequirementID,
+		}
+		if procedure.Documentation != "" {
+			item.Documentation = &procedure.Documentation
+		}
+		procedures = append(procedures, item)
+	}
+
+	c.JSON(http.StatusOK, api.ProcedureList{PluginId: pluginId, Procedures: procedures})
+}
+
+// GetV1FrameworksFrameworkRequirementsRequirementId handles the
+// GET /v1/frameworks/{framework}/requirements/{requirementId} endpoint.
+func (s *Service) GetV1FrameworksFrameworkRequirementsRequirementId(c *gin.Context, framework string, requirementId string) {
+	set, scope, release := s.snapshot()
+	defer release()
+
+	coverage := api.RequirementCoverage{
+		Framework:     framework,
+		RequirementId: requirementId,
+		Controls:      []api.ControlReference{},
+		PolicyRules:   []api.PolicyRuleReference{},
+	}
+
+	type controlKey struct{ catalogID, controlID string }
+	covered := make(map[controlKey]bool)
+	for _, catalogID := range sortedCatalogIDs(scope) {
+		for _, family := range scope[catalogID].ControlFamilies {
+			for _, control := range family.Controls {
+				if !mapsTo(control.GuidelineMappings, framework, requirementId) {
+					continue
+				}
+				covered[controlKey{catalogID, control.Id}] = true
+				coverage.Controls = append(coverage.Controls, api.ControlReference{
+					CatalogId: catalogID,
+					ControlId: control.Id,
+					Title:     control.Title,
+				})
+			}
+		}
+	}
+
+	pluginIDs := make([]string, 0, len(set))
+	for pluginID := range set {
+		pluginIDs = append(pluginIDs, string(pluginID))
+	}
+	sort.Strings(pluginIDs)
+
+	for _, pluginID := range pluginIDs {
+		lister, ok := set[mapper.ID(pluginID)].(mapper.ProcedureLister)
+		if !ok {
+			continue
+		}
+		for _, procedure := range lister.Procedures() {
+			if !covered[controlKey{procedure.CatalogID, procedure.ControlID}] {
+				continue
+			}
+			coverage.PolicyRules = append(coverage.PolicyRules, api.PolicyRuleReference{
+				PluginId:      pluginID,
+				PolicyRuleId:  procedure.PolicyRuleID,
+				CatalogId:     procedure.CatalogID,
+				ControlId:     procedure.ControlID,
+				RequirementId: procedure.RequirementID,
+			})
+		}
+	}
+
+	c.JSON(http.StatusOK, coverage)
+}
+
+// GetV1Coverage handles the GET /v1/coverage endpoint.
+func (s *Service) GetV1Coverage(c *gin.Context, params api.GetV1CoverageParams) {
+	format := api.Json
+	if params.Format != nil {
+		format = *params.Format
+	}
+	if format != api.Json && format != api.Markdown {
+		sendCompassError(c, http.StatusBadRequest, fmt.Sprintf("unsupported format %q: must be json or markdown", format))
+		return
+	}
+
+	set, scope, release := s.snapshot()
+	defer release()
+	report := coverage.Report(set, scope)
+	if format == api.Json {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	var markdown bytes.Buffer
+	if err := coverage.WriteMarkdown(&markdown, report); err != nil {
+		sendCompassError(c, http.StatusInternalServerError, "failed to render coverage report")
+		return
+	}
+	c.Data(http.StatusOK, "text/markdown; charset=utf-8", markdown.Bytes())
+}
+
+// catalogControl converts a catalog control into its API representation.
+func catalogControl(entry mapper.ControlEntry) api.CatalogControl {
+	control := api.CatalogControl{
+		Id:        entry.Control.Id,
+		CatalogId: entry.CatalogID,
+		Title:     entry.Control.Title,
+		Family: api.ControlFamily{
+			Id:    entry.Family.Id,
+			Title: entry.Family.Title,
+		},
+		Requirements:      []api.ControlRequirement{},
+		GuidelineMappings: []api.GuidelineMapping{},
+	}
+	if entry.Control.Objective != "" {
+		control.Objective = &entry.Control.Objective
+	}
+
+	for _, requirement := range entry.Control.AssessmentRequirements {
+		applicability := requirement.Applicability
+		if applicability == nil {
+			applicability = []string{}
+		}
+		control.Requirements = append(control.Requirements, api.ControlRequirement{
+			Id:            requirement.Id,
+			Text:          requirement.Text,
+			Applicability: applicability,
+		})
+	}
+
+	for _, mapping := range entry.Control.GuidelineMappings {
+		requirements := make([]string, 0, len(mapping.Entries))
+		for _, mappingEntry := range mapping.Entries {
+			requirements = append(requirements, mappingEntry.ReferenceId)
+		}
+		control.GuidelineMappings = append(control.GuidelineMappings, api.GuidelineMapping{
+			Framework:    mapping.ReferenceId,
+			Requirements: requirements,
+		})
+	}
+	return control
+}
+
+// mapsTo reports whether mappings reference requirementID of framework.
+func mapsTo(mappings []layer2.Mapping, framework, requirementID string) bool {
+	for _, mapping := range mappings {
+		if mapping.ReferenceId != framework {
+			continue
+		}
+		for _, entry := range mapping.Entries {
+			if entry.ReferenceId == requirementID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sortedCatalogIDs returns the catalog IDs in scope in order.
+func sortedCatalogIDs(scope mapper.Scope) []string {
+	catalogIDs := make([]string, 0, len(scope))
+	for catalogID := range scope {
+		catalogIDs = append(catalogIDs, catalogID)
+	}
+	sort.Strings(catalogIDs)
+	return catalogIDs
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ossf/gemara/layer2"
+	"github.com/ossf/gemara/layer4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+	"github.com/complytime/complybeacon/compass/mapper/plugins/basic"
+)
+
+// stubMapper is a mapper that cannot list its procedures.
+type stubMapper struct{}
+
+func (stubMapper) PluginName() mapper.ID { return "stub" }
+
+func (stubMapper) Map(api.Evidence, mapper.Scope) api.Compliance { return api.Compliance{} }
+
+func (stubMapper) AddEvaluationPlan(string, ...layer4.AssessmentPlan) {}
+
+// newQueryRouter serves a service whose "test-policy-engine" plugin maps
+// policy rule AC-1 to control AC-1, which maps to NIST-800-53 AC-1.
+func newQueryRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	mapperPlugin, scope := newProcessFixture()
+	catalog := scope["test-catalog"]
+	catalog.Metadata.Title = "Test Catalog"
+	catalog.Metadata.Version = "1.0"
+	catalog.ControlFamilies[0].Id = "AC"
+	control := &catalog.ControlFamilies[0].Controls[0]
+	control.Title = "Access Control Policy"
+	control.Objective = "Limit access"
+	control.AssessmentRequirements[0].Text = "Access is reviewed"
+	control.GuidelineMappings = []layer2.Mapping{
+		{ReferenceId: "NIST-800-53", Entries: []layer2.MappingEntry{{ReferenceId: "AC-1"}, {ReferenceId: "AC-2"}}},
+	}
+	catalog.ControlFamilies[0].Controls = append(catalog.ControlFamilies[0].Controls, layer2.Control{Id: "AC-3"})
+	scope["test-catalog"] = catalog
+
+	otherMapper := basic.NewBasicMapper()
+	otherMapper.AddEvaluationPlan("test-catalog", layer4.AssessmentPlan{
+		Control: layer4.Mapping{EntryId: "AC-1", ReferenceId: "test-catalog"},
+		Assessments: []layer4.Assessment{
+			{
+				Requirement: layer4.Mapping{EntryId: "AC-1.01", ReferenceId: "test-catalog"},
+				Procedures:  []layer4.AssessmentProcedure{{Id: "ac_review", Documentation: "Review access"}},
+			},
+		},
+	})
+
+	service := NewService(mapper.Set{
+		"test-policy-engine": mapperPlugin,
+		"other-engine":       otherMapper,
+		"stub-engine":        stubMapper{},
+	}, scope)
+
+	r := gin.New()
+	api.RegisterHandlers(r, service)
+	return r
+}
+
+// get serves a GET request for path and checks a successful response body
+// against the schema of schemaPath.
+func get(t *testing.T, r *gin.Engine, path, schemaPath string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	if w.Code == http.StatusOK {
+		swagger, err := api.GetSwagger()
+		require.NoError(t, err)
+		var responseBody interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+		assert.NoError(t, responseSchema(t, swagger, schemaPath).VisitJSON(responseBody))
+	}
+	return w
+}
+
+func TestGetV1Catalogs(t *testing.T) {
+	w := get(t, newQueryRouter(t), "/v1/catalogs", "/v1/catalogs")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response api.CatalogList
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	version := "1.0"
+	assert.Equal(t, []api.CatalogSummary{
+		{Id: "test-catalog", Title: "Test Catalog", Version: &version, ControlCount: 2},
+	}, response.Catalogs)
+}
+
+func TestGetV1CatalogControl(t *testing.T) {
+	r := newQueryRouter(t)
+	const schemaPath = "/v1/catalogs/{catalogId}/controls/{controlId}"
+
+	t.Run("control", func(t *testing.T) {
+		w := get(t, r, "/v1/catalogs/test-catalog/controls/AC-1", schemaPath)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response api.CatalogControl
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		objective := "Limit access"
+		assert.Equal(t, api.CatalogControl{
+			Id:        "AC-1",
+			CatalogId: "test-catalog",
+			Title:     "Access Control Policy",
+			Objective: &objective,
+			Family:    api.ControlFamily{Id: "AC", Title: "Access Control"},
+			Requirements: []api.ControlRequirement{
+				{Id: "AC-1.01", Text: "Access is reviewed", Applicability: []string{"Production"}},
+			},
+			GuidelineMappings: []api.GuidelineMapping{
+				{Framework: "NIST-800-53", Requirements: []string{"AC-1", "AC-2"}},
+			},
+		}, response)
+	})
+
+	t.Run("control without requirements", func(t *testing.T) {
+		w := get(t, r, "/v1/catalogs/test-catalog/controls/AC-3", schemaPath)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("unknown catalog", func(t *testing.T) {
+		w := get(t, r, "/v1/catalogs/unknown/controls/AC-1", schemaPath)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("unknown control", func(t *testing.T) {
+		w := get(t, r, "/v1/catalogs/test-catalog/controls/AC-9", schemaPath)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestGetV1PluginProcedures(t *testing.T) {
+	r := newQueryRouter(t)
+	const schemaPath = "/v1/plugins/{pluginId}/procedures"
+
+	w := get(t, r, "/v1/plugins/other-engine/procedures", schemaPath)
+	require.Equal(t, http.StatusOK, w.Code)
+	var response api.ProcedureList
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	documentation := "Review access"
+	assert.Equal(t, api.ProcedureList{
+		PluginId: "other-engine",
+		Procedures: []api.Procedure{
+			{PolicyRuleId: "ac_review", CatalogId: "test-catalog", ControlId: "AC-1", RequirementId: "AC-1.01", Documentation: &documentation},
+		},
+	}, response)
+
+	w = get(t, r, "/v1/plugins/unknown-engine/procedures", schemaPath)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = get(t, r, "/v1/plugins/stub-engine/procedures", schemaPath)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+}
+
+func TestGetV1FrameworkRequirement(t *testing.T) {
+	r := newQueryRouter(t)
+	const schemaPath = "/v1/frameworks/{framework}/requirements/{requirementId}"
+
+	w := get(t, r, "/v1/frameworks/NIST-800-53/requirements/AC-2", schemaPath)
+	require.Equal(t, http.StatusOK, w.Code)
+	var response api.RequirementCoverage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, api.RequirementCoverage{
+		Framework:     "NIST-800-53",
+		RequirementId: "AC-2",
+		Controls: []api.ControlReference{
+			{CatalogId: "test-catalog", ControlId: "AC-1", Title: "Access Control Policy"},
+		},
+		PolicyRules: []api.PolicyRuleReference{
+			{PluginId: "other-engine", PolicyRuleId: "ac_review", CatalogId: "test-catalog", ControlId: "AC-1", RequirementId: "AC-1.01"},
+			{PluginId: "test-policy-engine", PolicyRuleId: "AC-1", CatalogId: "test-catalog", ControlId: "AC-1", RequirementId: "AC-1.01"},
+		},
+	}, response)
+
+	// An uncovered requirement returns empty lists.
+	w = get(t, r, "/v1/frameworks/NIST-800-53/requirements/AC-9", schemaPath)
+	require.Equal(t, http.StatusOK, w.Code)
+	response = api.RequirementCoverage{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Empty(t, response.Controls)
+	assert.Empty(t, response.PolicyRules)
+}
+
+func TestGetV1Coverage(t *testing.T) {
+	r := newQueryRouter(t)
+
+	w := get(t, r, "/v1/coverage", "/v1/coverage")
+	require.Equal(t, http.StatusOK, w.Code)
+	var response api.CoverageReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Catalogs, 1)
+	catalog := response.Catalogs[0]
+	assert.Equal(t, "test-catalog", catalog.CatalogId)
+	assert.Equal(t, 2, catalog.ControlCount)
+	assert.Equal(t, 1, catalog.CoveredControlCount)
+	require.Len(t, catalog.Families, 1)
+	controls := catalog.Families[0].Controls
+	require.Len(t, controls, 2)
+	assert.True(t, controls[0].Covered)
+	assert.Len(t, controls[0].PolicyRules, 2)
+	assert.False(t, controls[1].Covered)
+	assert.Empty(t, response.UnresolvedProcedures)
+	assert.Equal(t, []string{"stub-engine"}, response.ExcludedPlugins)
+
+	t.Run("markdown", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/coverage?format=markdown", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/markdown; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "## test-catalog: Test Catalog")
+		assert.Contains(t, w.Body.String(), "| AC-3 |  | - | - |")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		w := get(t, r, "/v1/coverage?format=csv", "/v1/coverage")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -1,28 +1,197 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"net/http"
+	"slices"
+	"sync"
 
 	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/exception"
 	"github.com/complytime/complybeacon/compass/mapper"
 	"github.com/complytime/complybeacon/compass/mapper/plugins/basic"
+	"github.com/complytime/complybeacon/compass/risk"
 )
 
 // Service struct to hold dependencies if needed
 type Service struct {
+	// mu guards current and retired so the mapper set and scope can be
+	// replaced together while requests are in flight.
+	mu      sync.Mutex
+	current *generation
+	// retired holds replaced generations that requests are still using. Each
+	// is closed when its last request finishes, or by Close.
+	retired []*generation
+	// fallback maps evidence from policy engines without a mapper in the
+	// set. It has no plans, so it is shared by all requests.
+	fallback *basic.Mapper
+
+	// index resolves mapped controls against the current scope.
+	index      mapper.IndexCache
+	risk       risk.Config
+	exceptions *exception.Registry
+	// environments names the catalog applicability categories that are
+	// target environments.
+	environments []string
+
+	meterProvider  metric.MeterProvider
+	tracerProvider trace.TracerProvider
+	instruments    instruments
+}
+
+// generation is a mapper set and scope that are used together, with the
+// number of requests using them.
+type generation struct {
 	set   mapper.Set
 	scope mapper.Scope
+	users int
+}
+
+// Option configures optional Service behaviour.
+type Option func(*Service)
+
+// WithRiskConfig assigns risk levels to enriched findings using cfg.
+func WithRiskConfig(cfg risk.Config) Option {
+	return func(s *Service) {
+		s.risk = cfg
+	}
+}
+
+// WithExceptions marks findings covered by an active exception in registry
+// as Exempt.
+func WithExceptions(registry *exception.Registry) Option {
+	return func(s *Service) {
+		s.exceptions = registry
+	}
+}
+
+// WithEnvironmentCategories marks findings Not Applicable when the evidence
+// targets an environment that the control does not apply to. names lists, by
+// ID or title, the catalog applicability categories that are environments;
+// without any, the target environment is not checked.
+func WithEnvironmentCategories(names ...string) Option {
+	return func(s *Service) {
+		s.environments = names
+	}
+}
+
+// WithMeterProvider records enrichment metrics with provider instead of the
+// global meter provider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(s *Service) {
+		s.meterProvider = provider
+	}
+}
+
+// WithTracerProvider records enrichment spans with provider instead of the
+// global tracer provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(s *Service) {
+		s.tracerProvider = provider
+	}
 }
 
 // NewService initializes a new Service instance.
-func NewService(transformers mapper.Set, scope mapper.Scope) *Service {
-	return &Service{
-		set:   transformers,
-		scope: scope,
+func NewService(transformers mapper.Set, scope mapper.Scope, opts ...Option) *Service {
+	s := &Service{
+		current:  &generation{set: transformers, scope: scope},
+		fallback: basic.NewBasicMapper(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.instruments = newInstruments(s.meterProvider, s.tracerProvider)
+	return s
+}
+
+// Update atomically replaces the mapper set and scope used for enrichment.
+// Requests already in progress finish with the values they started with; the
+// replaced set is closed once the last of them has finished.
+func (s *Service) Update(transformers mapper.Set, scope mapper.Scope) {
+	s.mu.Lock()
+	replaced := s.current
+	s.current = &generation{set: transformers, scope: scope}
+	if replaced.users > 0 {
+		s.retired = append(s.retired, replaced)
+		replaced = nil
+	}
+	s.mu.Unlock()
+
+	if replaced != nil {
+		closeReplaced(replaced.set)
+	}
+}
+
+// Close closes the current mapper set and any replaced sets that have not
+// been closed yet. It is called on shutdown, after the servers have stopped.
+func (s *Service) Close() error {
+	s.mu.Lock()
+	sets := []mapper.Set{s.current.set}
+	for _, retired := range s.retired {
+		sets = append(sets, retired.set)
+	}
+	s.retired = nil
+	s.mu.Unlock()
+
+	var errs []error
+	for _, set := range sets {
+		errs = append(errs, set.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Ready returns an error until at least one catalog has been loaded, as
+// enrichment cannot map evidence before then.
+func (s *Service) Ready() error {
+	_, scope, release := s.snapshot()
+	defer release()
+	if len(scope) == 0 {
+		return errors.New("no catalogs loaded")
+	}
+	return nil
+}
+
+// snapshot returns the mapper set and scope to use for a single request. The
+// request must call release when it has finished with them, so that a set
+// replaced in the meantime can be closed.
+func (s *Service) snapshot() (set mapper.Set, scope mapper.Scope, release func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := s.current
+	current.users++
+	return current.set, current.scope, func() { s.release(current) }
+}
+
+// release records that a request has finished with g, and closes g if it has
+// been replaced and was the last request using it.
+func (s *Service) release(g *generation) {
+	s.mu.Lock()
+	g.users--
+	idx := -1
+	if g.users == 0 {
+		idx = slices.Index(s.retired, g)
+	}
+	if idx >= 0 {
+		s.retired = slices.Delete(s.retired, idx, idx+1)
+	}
+	s.mu.Unlock()
+
+	if idx >= 0 {
+		closeReplaced(g.set)
+	}
+}
+
+// closeReplaced closes a mapper set that is no longer used for enrichment.
+func closeReplaced(set mapper.Set) {
+	if err := set.Close(); err != nil {
+		slog.Warn("failed to close replaced plugins", slog.String("err", err.Error()))
 	}
 }
 
@@ -39,6 +208,14 @@ func (s *Service) PostV1Enrich(c *gin.Context) {
 		sendCompassError(c, http.StatusBadRequest, "Invalid format for enrichment")
 		return
 	}
+	if err := validateEvidence(req.Evidence); err != nil {
+		slog.Warn("invalid enrichment request",
+			slog.String("request_id", requestid.Get(c)),
+			slog.String("error", err.Error()),
+		)
+		sendCompassError(c, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	slog.Debug("enrich request received",
 		slog.String("request_id", requestid.Get(c)),
@@ -47,14 +224,10 @@ func (s *Service) PostV1Enrich(c *gin.Context) {
 		slog.String("timestamp", req.Evidence.Timestamp.String()),
 	)
 
-	mapperPlugin, ok := s.set[mapper.ID(req.Evidence.PolicyEngineName)]
-	if !ok {
-		// Use fallback
-		slog.Warn("mapper not found; using basic mapper fallback",
-			slog.String("policy_engine_name", req.Evidence.PolicyEngineName),
-		)
-		mapperPlugin = basic.NewBasicMapper()
-	}
+	ctx, span := s.startEnrichSpan(c.Request.Context(), req.Evidence)
+	set, scope, release := s.snapshot()
+	defer release()
+	mapperPlugin, ok := s.mapperFor(set, req.Evidence.PolicyEngineName)
 
 	slog.Debug("mapper selected",
 		slog.String("request_id", requestid.Get(c)),
@@ -62,18 +235,116 @@ func (s *Service) PostV1Enrich(c *gin.Context) {
 		slog.Bool("fallback_used", !ok),
 	)
 
-	enrichedResponse := enrich(req.Evidence, mapperPlugin, s.scope)
+	enrichedResponse := s.enrichWith(ctx, req.Evidence, mapperPlugin, !ok, scope, req.Mode)
+	endEnrichSpan(span, mapperPlugin, !ok, enrichedResponse)
 
 	slog.Debug("enrich result",
 		slog.String("request_id", requestid.Get(c)),
+		slog.Int("matches", matchCount(enrichedResponse)),
 		slog.String("compliance_status", string(enrichedResponse.Compliance.Status)),
 		slog.String("compliance_catalog", enrichedResponse.Compliance.Control.CatalogId),
 		slog.String("compliance_control", enrichedResponse.Compliance.Control.Id),
+		slog.Any("compliance_risk", enrichedResponse.Compliance.Risk),
 	)
 
 	c.JSON(http.StatusOK, enrichedResponse)
 }
 
+// PostV1EnrichBatch handles the POST /v1/enrich/batch endpoint.
+// Results are returned in request order. Evidence that cannot be enriched
+// produces a per-item error rather than failing the whole batch.
+func (s *Service) PostV1EnrichBatch(c *gin.Context) {
+	var req api.BatchEnrichmentRequest
+	err := c.Bind(&req)
+	if err != nil {
+		slog.Warn("invalid batch enrichment request",
+			slog.String("request_id", requestid.Get(c)),
+			slog.String("error", err.Error()),
+		)
+		sendCompassError(c, http.StatusBadRequest, "Invalid format for batch enrichment")
+		return
+	}
+
+	slog.Debug("batch enrich request received",
+		slog.String("request_id", requestid.Get(c)),
+		slog.Int("count", len(req.Evidence)),
+	)
+
+	ctx, span := s.instruments.tracer.Start(c.Request.Context(), "compass.enrich.batch",
+		trace.WithAttributes(attrBatchSize.Int(len(req.Evidence))),
+	)
+	defer span.End()
+
+	set, scope, release := s.snapshot()
+	defer release()
+	results := make([]api.BatchEnrichmentResult, len(req.Evidence))
+	var failed, fallbacks int
+	for i, evidence := range req.Evidence {
+		if err := validateEvidence(evidence); err != nil {
+			failed++
+			results[i] = api.BatchEnrichmentResult{
+				Error: &api.Error{
+					Code:    http.StatusBadRequest,
+					Message: err.Error(),
+				},
+			}
+			continue
+		}
+
+		mapperPlugin, ok := s.mapperFor(set, evidence.PolicyEngineName)
+		if !ok {
+			fallbacks++
+		}
+		enrichedResponse := s.enrichWith(ctx, evidence, mapperPlugin, !ok, scope, req.Mode)
+		results[i] = api.BatchEnrichmentResult{
+			Compliance: &enrichedResponse.Compliance,
+			Matches:    enrichedResponse.Matches,
+		}
+	}
+
+	slog.Debug("batch enrich result",
+		slog.String("request_id", requestid.Get(c)),
+		slog.Int("count", len(results)),
+		slog.Int("failed", failed),
+	)
+	if fallbacks > 0 {
+		slog.Warn("mapper not found for some batch evidence; used basic mapper fallback",
+			slog.String("request_id", requestid.Get(c)),
+			slog.Int("count", fallbacks),
+		)
+	}
+
+	c.JSON(http.StatusOK, api.BatchEnrichmentResponse{Results: results})
+}
+
+// mapperFor returns the mapper in set registered for the given policy engine. When none
+// is registered, the shared basic mapper is returned as a fallback and ok is false.
+// Fallbacks are counted by the enrichment outcome metric, so they are only
+// logged at debug level here.
+func (s *Service) mapperFor(set mapper.Set, policyEngineName string) (mapperPlugin mapper.Mapper, ok bool) {
+	mapperPlugin, ok = set[mapper.ID(policyEngineName)]
+	if !ok {
+		// Use fallback
+		slog.Debug("mapper not found; using basic mapper fallback",
+			slog.String("policy_engine_name", policyEngineName),
+		)
+		mapperPlugin = s.fallback
+	}
+	return mapperPlugin, ok
+}
+
+// validateEvidence checks evidence for values the API schema cannot express,
+// such as empty identifiers.
+func validateEvidence(evidence api.Evidence) error {
+	if evidence.PolicyEngineName == "" {
+		return errors.New("evidence policyEngineName must not be empty")
+	}
+	if evidence.PolicyRuleId == "" {
+		return errors.New("evidence policyRuleId must not be empty")
+	}
+	return nil
+}
+
 // sendCompassError wraps sending of an error in the Error format, and
 // handling the failure to marshal that.
 func sendCompassError(c *gin.Context, code int32, message string) {
@@ -84,6 +355,160 @@ func sendCompassError(c *gin.Context, code int32, message string) {
 	c.JSON(int(code), compassErr)
 }
 
+// Enrich validates and enriches a single piece of evidence with the current
+// mapper set and scope, as the HTTP and gRPC handlers do. It lets evidence be
+// enriched without serving the API.
+func (s *Service) Enrich(ctx context.Context, evidence api.Evidence, mode *api.EnrichmentMode) (api.EnrichmentResponse, error) {
+	if err := validateEvidence(evidence); err != nil {
+		return api.EnrichmentResponse{}, err
+	}
+	return s.enrichEvidence(ctx, evidence, mode), nil
+}
+
+// enrichEvidence enriches a single piece of validated evidence with the
+// current mapper set and scope.
+func (s *Service) enrichEvidence(ctx context.Context, evidence api.Evidence, mode *api.EnrichmentMode) api.EnrichmentResponse {
+	ctx, span := s.startEnrichSpan(ctx, evidence)
+	set, scope, release := s.snapshot()
+	defer release()
+	mapperPlugin, ok := s.mapperFor(set, evidence.PolicyEngineName)
+	response := s.enrichWith(ctx, evidence, mapperPlugin, !ok, scope, mode)
+	endEnrichSpan(span, mapperPlugin, !ok, response)
+	return response
+}
+
+// processMode enriches evidence according to the requested mode. A nil mode
+// is treated as the default, first.
+func (s *Service) processMode(evidence api.Evidence, mapperPlugin mapper.Mapper, scope mapper.Scope, mode *api.EnrichmentMode) api.EnrichmentResponse {
+	if mode != nil && *mode == api.All {
+		return s.processAll(evidence, mapperPlugin, scope)
+	}
+	return s.process(evidence, mapperPlugin, scope)
+}
+
+// process enriches evidence with the given mapper, then applies the
+// service-wide rules that do not depend on which mapper was used.
+func (s *Service) process(evidence api.Evidence, mapperPlugin mapper.Mapper, scope mapper.Scope) api.EnrichmentResponse {
+	response := enrich(evidence, mapperPlugin, scope)
+	s.complete(evidence, scope, &response.Compliance)
+	return response
+}
+
+// processAll enriches evidence against every control it maps to. The first
+// match is also returned as the response compliance, so clients that ignore
+// matches see the same finding as with process.
+func (s *Service) processAll(evidence api.Evidence, mapperPlugin mapper.Mapper, scope mapper.Scope) api.EnrichmentResponse {
+	var matches []api.Compliance
+	if multiMapper, ok := mapperPlugin.(mapper.MultiMapper); ok {
+		matches = multiMapper.MapAll(evidence, scope)
+	} else if compliance := mapperPlugin.Map(evidence, scope); compliance.EnrichmentStatus == api.ComplianceEnrichmentStatusSuccess {
+		matches = []api.Compliance{compliance}
+	}
+
+	if len(matches) == 0 {
+		response := s.process(evidence, mapperPlugin, scope)
+		response.Matches = &[]api.Compliance{}
+		return response
+	}
+
+	for i := range matches {
+		s.complete(evidence, scope, &matches[i])
+	}
+	return api.EnrichmentResponse{
+		Compliance: matches[0],
+		Matches:    &matches,
+	}
+}
+
+// complete applies risk, applicability, and exceptions to a successfully
+// mapped compliance finding.
+func (s *Service) complete(evidence api.Evidence, scope mapper.Scope, compliance *api.Compliance) {
+	if compliance.EnrichmentStatus != api.ComplianceEnrichmentStatusSuccess {
+		return
+	}
+
+	catalogIndex, ok := s.index.Get(scope)[compliance.Control.CatalogId]
+	if !ok {
+		return
+	}
+	entry, ok := lookupControl(catalogIndex, compliance.Control.Id)
+	if !ok {
+		return
+	}
+
+	if compliance.Risk == nil {
+		if level := s.risk.Assess(entry.ControlEntry, catalogIndex); level != nil {
+			compliance.Risk = &api.ComplianceRisk{Level: level}
+		}
+	}
+
+	if compliance.Control.Applicability == nil {
+		if applicability := applicabilityOf(entry); len(applicability) > 0 {
+			compliance.Control.Applicability = &applicability
+		}
+	}
+	if evidence.PolicyTargetEnvironment != nil && compliance.Control.Applicability != nil {
+		environments := environmentCategories(catalogIndex.Catalog.Metadata.ApplicabilityCategories, s.environments)
+		if !appliesTo(*compliance.Control.Applicability, *evidence.PolicyTargetEnvironment, environments) {
+			compliance.Status = api.ComplianceStatusNotApplicable
+		}
+	}
+
+	s.applyException(evidence, entry, compliance)
+}
+
+// matchCount returns the number of matches in response, or 1 when the
+// response carries only a single finding.
+func matchCount(response api.EnrichmentResponse) int {
+	if response.Matches == nil {
+		return 1
+	}
+	return len(*response.Matches)
+}
+
+// applyException records the exception matching the finding, if any, and
+// marks the finding Exempt while the exception is active. The finding's own
+// target, when set, is matched instead of the evidence target. Findings that do
+// not apply to the target environment are left Not Applicable.
+func (s *Service) applyException(evidence api.Evidence, entry mapper.RequirementEntry, compliance *api.Compliance) {
+	subject := exception.Subject{
+		CatalogID:    entry.CatalogID,
+		ControlID:    entry.Control.Id,
+		PolicyRuleID: evidence.PolicyRuleId,
+	}
+	if entry.Requirement != nil {
+		subject.RequirementID = entry.Requirement.Id
+	}
+	if compliance.TargetId != nil {
+		subject.TargetID = *compliance.TargetId
+	} else if evidence.PolicyTargetId != nil {
+		subject.TargetID = *evidence.PolicyTargetId
+	}
+
+	match, active, found := s.exceptions.Match(subject)
+	if !found {
+		return
+	}
+	compliance.Exception = &api.ComplianceException{
+		Id:     match.ID,
+		Active: active,
+	}
+	if active && compliance.Status != api.ComplianceStatusNotApplicable {
+		compliance.Status = api.ComplianceStatusExempt
+	}
+}
+
+// lookupControl resolves the ID of a mapped compliance control, which is
+// either an assessment requirement ID or a control ID. For a control ID, the
+// returned entry has no Requirement.
+func lookupControl(catalogIndex *mapper.CatalogIndex, id string) (mapper.RequirementEntry, bool) {
+	if requirement, ok := catalogIndex.Requirement(id); ok {
+		return requirement, true
+	}
+	control, ok := catalogIndex.Control(id)
+	return mapper.RequirementEntry{ControlEntry: control}, ok
+}
+
 // Enrich the raw evidence with risk attributes based on `gemara` semantics.
 func enrich(rawEnv api.Evidence, attributeMapper mapper.Mapper, scope mapper.Scope) api.EnrichmentResponse {
 	compliance := attributeMapper.Map(rawEnv, scope)
@@ -1,19 +1,26 @@
 package service
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
 	"github.com/ossf/gemara/layer2"
 	"github.com/ossf/gemara/layer4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/exception"
 	"github.com/complytime/complybeacon/compass/mapper"
 	"github.com/complytime/complybeacon/compass/mapper/plugins/basic"
+	"github.com/complytime/complybeacon/compass/risk"
 )
 
 func TestNewService(t *testing.T) {
@@ -23,8 +30,81 @@ func TestNewService(t *testing.T) {
 	service := NewService(mappers, scope)
 
 	assert.NotNil(t, service)
-	assert.Equal(t, mappers, service.set)
-	assert.Equal(t, scope, service.scope)
+	assert.Equal(t, mappers, service.current.set)
+	assert.Equal(t, scope, service.current.scope)
+}
+
+func TestServiceUpdate(t *testing.T) {
+	service := NewService(make(mapper.Set), make(mapper.Scope))
+
+	mappers := mapper.Set{"basic": basic.NewBasicMapper()}
+	scope := mapper.Scope{"test-catalog": layer2.Catalog{Metadata: layer2.Metadata{Id: "test-catalog"}}}
+	service.Update(mappers, scope)
+
+	set, gotScope, release := service.snapshot()
+	defer release()
+	assert.Equal(t, mappers, set)
+	assert.Equal(t, scope, gotScope)
+}
+
+// closingMapper records whether it was closed.
+type closingMapper struct {
+	*basic.Mapper
+	closed bool
+}
+
+func (m *closingMapper) Close() error {
+	m.closed = true
+	return nil
+}
+
+func TestServiceUpdateClosesReplacedSet(t *testing.T) {
+	t.Run("unused set is closed on update", func(t *testing.T) {
+		first := &closingMapper{Mapper: basic.NewBasicMapper()}
+		service := NewService(mapper.Set{"basic": first}, mapper.Scope{})
+
+		service.Update(mapper.Set{}, mapper.Scope{})
+		assert.True(t, first.closed)
+	})
+
+	t.Run("set in use is closed by its last request", func(t *testing.T) {
+		first := &closingMapper{Mapper: basic.NewBasicMapper()}
+		service := NewService(mapper.Set{"basic": first}, mapper.Scope{})
+		_, _, releaseFirst := service.snapshot()
+		_, _, releaseSecond := service.snapshot()
+
+		service.Update(mapper.Set{}, mapper.Scope{})
+		assert.False(t, first.closed)
+		releaseFirst()
+		assert.False(t, first.closed)
+		releaseSecond()
+		assert.True(t, first.closed)
+		assert.Empty(t, service.retired)
+	})
+
+	t.Run("set in use is closed by Close", func(t *testing.T) {
+		first := &closingMapper{Mapper: basic.NewBasicMapper()}
+		second := &closingMapper{Mapper: basic.NewBasicMapper()}
+		service := NewService(mapper.Set{"basic": first}, mapper.Scope{})
+		_, _, release := service.snapshot()
+
+		service.Update(mapper.Set{"basic": second}, mapper.Scope{})
+		require.NoError(t, service.Close())
+		assert.True(t, first.closed)
+		assert.True(t, second.closed)
+
+		first.closed = false
+		release()
+		assert.False(t, first.closed, "a set closed by Close is not closed again")
+	})
+}
+
+func TestServiceReady(t *testing.T) {
+	service := NewService(nil, nil)
+	assert.Error(t, service.Ready())
+
+	service.Update(mapper.Set{}, mapper.Scope{"test-catalog": layer2.Catalog{Metadata: layer2.Metadata{Id: "test-catalog"}}})
+	assert.NoError(t, service.Ready())
 }
 
 func TestEnrich(t *testing.T) {
@@ -122,6 +202,453 @@ func TestEnrich(t *testing.T) {
 	})
 }
 
+func TestPostV1EnrichBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	swagger, err := api.GetSwagger()
+	require.NoError(t, err)
+
+	mapperPlugin := basic.NewBasicMapper()
+	mapperPlugin.AddEvaluationPlan("test-catalog", layer4.AssessmentPlan{
+		Control: layer4.Mapping{EntryId: "AC-1", ReferenceId: "test-catalog"},
+		Assessments: []layer4.Assessment{
+			{
+				Requirement: layer4.Mapping{EntryId: "AC-1-REQ", ReferenceId: "test-catalog"},
+				Procedures:  []layer4.AssessmentProcedure{{Id: "AC-1"}},
+			},
+		},
+	})
+	scope := mapper.Scope{
+		"test-catalog": layer2.Catalog{
+			Metadata: layer2.Metadata{Id: "test-catalog"},
+			ControlFamilies: []layer2.ControlFamily{
+				{
+					Title: "Access Control",
+					Controls: []layer2.Control{
+						{
+							Id: "AC-1",
+							GuidelineMappings: []layer2.Mapping{
+								{ReferenceId: "NIST-800-53", Entries: []layer2.MappingEntry{{ReferenceId: "AC-1"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	service := NewService(mapper.Set{"test-policy-engine": mapperPlugin}, scope)
+
+	r := gin.New()
+	api.RegisterHandlers(r, service)
+
+	now := time.Now()
+	body, err := json.Marshal(api.BatchEnrichmentRequest{
+		Evidence: []api.Evidence{
+			{PolicyEngineName: "test-policy-engine", PolicyRuleId: "AC-1", PolicyEvaluationStatus: api.Failed, Timestamp: now},
+			{PolicyEngineName: "test-policy-engine", PolicyRuleId: "", PolicyEvaluationStatus: api.Passed, Timestamp: now},
+			{PolicyEngineName: "unknown-engine", PolicyRuleId: "AC-1", PolicyEvaluationStatus: api.Passed, Timestamp: now},
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/enrich/batch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response api.BatchEnrichmentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Results, 3)
+
+	// Results keep request order.
+	require.NotNil(t, response.Results[0].Compliance)
+	assert.Nil(t, response.Results[0].Error)
+	assert.Equal(t, api.ComplianceEnrichmentStatusSuccess, response.Results[0].Compliance.EnrichmentStatus)
+	assert.Equal(t, api.ComplianceStatusNonCompliant, response.Results[0].Compliance.Status)
+	assert.Equal(t, "AC-1-REQ", response.Results[0].Compliance.Control.Id)
+
+	require.NotNil(t, response.Results[1].Error)
+	assert.Nil(t, response.Results[1].Compliance)
+	assert.Equal(t, int32(http.StatusBadRequest), response.Results[1].Error.Code)
+
+	require.NotNil(t, response.Results[2].Compliance)
+	assert.Equal(t, api.ComplianceEnrichmentStatusUnmapped, response.Results[2].Compliance.EnrichmentStatus)
+
+	schema := responseSchema(t, swagger, "/v1/enrich/batch")
+	var responseBody interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+	assert.NoError(t, schema.VisitJSON(responseBody))
+}
+
+func TestPostV1EnrichInvalidEvidence(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	service := NewService(mapper.Set{}, mapper.Scope{})
+	r := gin.New()
+	api.RegisterHandlers(r, service)
+
+	body, err := json.Marshal(api.EnrichmentRequest{Evidence: api.Evidence{
+		PolicyEngineName:       "test-policy-engine",
+		PolicyEvaluationStatus: api.Failed,
+		Timestamp:              time.Now(),
+	}})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/v1/enrich", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response api.Error
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "evidence policyRuleId must not be empty", response.Message)
+}
+
+func TestPostV1EnrichModeAll(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	swagger, err := api.GetSwagger()
+	require.NoError(t, err)
+
+	mapperPlugin, scope := newProcessFixture()
+	mapperPlugin.AddEvaluationPlan("other-catalog", layer4.AssessmentPlan{
+		Control: layer4.Mapping{EntryId: "BP-1", ReferenceId: "other-catalog"},
+		Assessments: []layer4.Assessment{
+			{
+				Requirement: layer4.Mapping{EntryId: "BP-1.01", ReferenceId: "other-catalog"},
+				Procedures:  []layer4.AssessmentProcedure{{Id: "AC-1"}},
+			},
+		},
+	})
+	scope["other-catalog"] = layer2.Catalog{
+		Metadata: layer2.Metadata{Id: "other-catalog"},
+		ControlFamilies: []layer2.ControlFamily{
+			{
+				Title: "Branch Protection",
+				Controls: []layer2.Control{
+					{Id: "BP-1", AssessmentRequirements: []layer2.AssessmentRequirement{{Id: "BP-1.01"}}},
+				},
+			},
+		},
+	}
+	registry, err := exception.NewRegistry(exception.Exception{
+		ID:            "EX-1",
+		Catalog:       "other-catalog",
+		Control:       "BP-1",
+		Justification: "accepted risk",
+		Approver:      "ciso",
+		Expires:       time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+	service := NewService(mapper.Set{"test-policy-engine": mapperPlugin}, scope, WithExceptions(registry))
+
+	r := gin.New()
+	api.RegisterHandlers(r, service)
+
+	post := func(t *testing.T, request api.EnrichmentRequest) api.EnrichmentResponse {
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v1/enrich", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var responseBody interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+		assert.NoError(t, responseSchema(t, swagger, "/v1/enrich").VisitJSON(responseBody))
+
+		var response api.EnrichmentResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	evidence := api.Evidence{
+		PolicyEngineName:       "test-policy-engine",
+		PolicyRuleId:           "AC-1",
+		PolicyEvaluationStatus: api.Failed,
+		Timestamp:              time.Now(),
+	}
+
+	t.Run("all returns every match", func(t *testing.T) {
+		mode := api.All
+		response := post(t, api.EnrichmentRequest{Evidence: evidence, Mode: &mode})
+		require.NotNil(t, response.Matches)
+		matches := *response.Matches
+		require.Len(t, matches, 2)
+		assert.Equal(t, matches[0], response.Compliance)
+
+		assert.Equal(t, "other-catalog", matches[0].Control.CatalogId)
+		assert.Equal(t, "BP-1.01", matches[0].Control.Id)
+		assert.Equal(t, api.ComplianceStatusExempt, matches[0].Status)
+
+		assert.Equal(t, "test-catalog", matches[1].Control.CatalogId)
+		assert.Equal(t, "AC-1.01", matches[1].Control.Id)
+		assert.Equal(t, api.ComplianceStatusNonCompliant, matches[1].Status)
+		assert.Nil(t, matches[1].Exception)
+	})
+
+	t.Run("first omits matches", func(t *testing.T) {
+		response := post(t, api.EnrichmentRequest{Evidence: evidence})
+		assert.Nil(t, response.Matches)
+		assert.Equal(t, "other-catalog", response.Compliance.Control.CatalogId)
+	})
+
+	t.Run("all with no match", func(t *testing.T) {
+		mode := api.All
+		unmapped := evidence
+		unmapped.PolicyRuleId = "unknown"
+		response := post(t, api.EnrichmentRequest{Evidence: unmapped, Mode: &mode})
+		require.NotNil(t, response.Matches)
+		assert.Empty(t, *response.Matches)
+		assert.Equal(t, api.ComplianceEnrichmentStatusUnmapped, response.Compliance.EnrichmentStatus)
+	})
+}
+
+// newProcessFixture returns a basic mapper that maps the "AC-1" policy rule to
+// requirement AC-1.01 of control AC-1, with the scope holding its catalog.
+// The requirement applies to Production and the control is linked to threat
+// TH-1.
+func newProcessFixture() (*basic.Mapper, mapper.Scope) {
+	mapperPlugin := basic.NewBasicMapper()
+	mapperPlugin.AddEvaluationPlan("test-catalog", layer4.AssessmentPlan{
+		Control: layer4.Mapping{EntryId: "AC-1", ReferenceId: "test-catalog"},
+		Assessments: []layer4.Assessment{
+			{
+				Requirement: layer4.Mapping{EntryId: "AC-1.01", ReferenceId: "test-catalog"},
+				Procedures:  []layer4.AssessmentProcedure{{Id: "AC-1"}},
+			},
+		},
+	})
+	scope := mapper.Scope{
+		"test-catalog": layer2.Catalog{
+			Metadata: layer2.Metadata{
+				Id: "test-catalog",
+				ApplicabilityCategories: []layer2.Category{
+					{Id: "Production"},
+					{Id: "Staging"},
+				},
+			},
+			ControlFamilies: []layer2.ControlFamily{
+				{
+					Title: "Access Control",
+					Controls: []layer2.Control{
+						{
+							Id: "AC-1",
+							AssessmentRequirements: []layer2.AssessmentRequirement{
+								{Id: "AC-1.01", Applicability: []string{"Production"}},
+							},
+							ThreatMappings: []layer2.Mapping{
+								{ReferenceId: "test-catalog", Entries: []layer2.MappingEntry{{ReferenceId: "TH-1"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return mapperPlugin, scope
+}
+
+func TestServiceProcessRisk(t *testing.T) {
+	mapperPlugin, scope := newProcessFixture()
+	evidence := api.Evidence{
+		PolicyEngineName:       "test-policy-engine",
+		PolicyRuleId:           "AC-1",
+		PolicyEvaluationStatus: api.Failed,
+		Timestamp:              time.Now(),
+	}
+
+	t.Run("risk derived from threat", func(t *testing.T) {
+		service := NewService(mapper.Set{}, scope, WithRiskConfig(risk.Config{
+			Threats: map[string]api.ComplianceRiskLevel{"TH-1": api.High},
+		}))
+		response := service.process(evidence, mapperPlugin, scope)
+		require.NotNil(t, response.Compliance.Risk)
+		require.NotNil(t, response.Compliance.Risk.Level)
+		assert.Equal(t, api.High, *response.Compliance.Risk.Level)
+	})
+
+	t.Run("no risk without rules", func(t *testing.T) {
+		service := NewService(mapper.Set{}, scope)
+		response := service.process(evidence, mapperPlugin, scope)
+		assert.Nil(t, response.Compliance.Risk)
+	})
+
+	t.Run("no risk for unmapped evidence", func(t *testing.T) {
+		service := NewService(mapper.Set{}, scope, WithRiskConfig(risk.Config{Default: api.Low}))
+		unmapped := evidence
+		unmapped.PolicyRuleId = "unknown"
+		response := service.process(unmapped, mapperPlugin, scope)
+		assert.Nil(t, response.Compliance.Risk)
+	})
+}
+
+func TestServiceEnrich(t *testing.T) {
+	mapperPlugin, scope := newProcessFixture()
+	service := NewService(mapper.Set{"test-policy-engine": mapperPlugin}, scope)
+	evidence := api.Evidence{
+		PolicyEngineName:       "test-policy-engine",
+		PolicyRuleId:           "AC-1",
+		PolicyEvaluationStatus: api.Failed,
+		Timestamp:              time.Now(),
+	}
+
+	response, err := service.Enrich(context.Background(), evidence, nil)
+	require.NoError(t, err)
+	assert.Equal(t, api.ComplianceEnrichmentStatusSuccess, response.Compliance.EnrichmentStatus)
+	assert.Equal(t, "AC-1.01", response.Compliance.Control.Id)
+	assert.Nil(t, response.Matches)
+
+	all := api.All
+	response, err = service.Enrich(context.Background(), evidence, &all)
+	require.NoError(t, err)
+	require.NotNil(t, response.Matches)
+	assert.Len(t, *response.Matches, 1)
+
+	evidence.PolicyRuleId = ""
+	_, err = service.Enrich(context.Background(), evidence, nil)
+	assert.EqualError(t, err, "evidence policyRuleId must not be empty")
+}
+
+func TestServiceProcessException(t *testing.T) {
+	mapperPlugin, scope := newProcessFixture()
+	registry, err := exception.NewRegistry(
+		exception.Exception{
+			ID:            "EX-ACTIVE",
+			Control:       "AC-1",
+			Target:        "repo-a",
+			Justification: "accepted risk",
+			Approver:      "ciso",
+			Expires:       time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+		},
+		exception.Exception{
+			ID:            "EX-EXPIRED",
+			Control:       "AC-1.01",
+			Target:        "repo-b",
+			Justification: "accepted risk",
+			Approver:      "ciso",
+			Expires:       time.Now().Add(-24 * time.Hour).Format(time.RFC3339),
+		},
+	)
+	require.NoError(t, err)
+	service := NewService(mapper.Set{}, scope, WithExceptions(registry), WithEnvironmentCategories("Production", "Staging"))
+
+	tests := []struct {
+		name              string
+		target            *string
+		environment       *string
+		expectedStatus    api.ComplianceStatus
+		expectedException *api.ComplianceException
+	}{
+		{
+			name:              "active exception exempts finding",
+			target:            stringPtr("repo-a"),
+			expectedStatus:    api.ComplianceStatusExempt,
+			expectedException: &api.ComplianceException{Id: "EX-ACTIVE", Active: true},
+		},
+		{
+			name:              "expired exception is reported but not applied",
+			target:            stringPtr("repo-b"),
+			expectedStatus:    api.ComplianceStatusNonCompliant,
+			expectedException: &api.ComplianceException{Id: "EX-EXPIRED", Active: false},
+		},
+		{
+			name:           "no matching exception",
+			target:         stringPtr("repo-c"),
+			expectedStatus: api.ComplianceStatusNonCompliant,
+		},
+		{
+			name:              "not applicable wins over exception",
+			target:            stringPtr("repo-a"),
+			environment:       stringPtr("Staging"),
+			expectedStatus:    api.ComplianceStatusNotApplicable,
+			expectedException: &api.ComplianceException{Id: "EX-ACTIVE", Active: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evidence := api.Evidence{
+				PolicyEngineName:        "test-policy-engine",
+				PolicyRuleId:            "AC-1",
+				PolicyEvaluationStatus:  api.Failed,
+				PolicyTargetId:          tt.target,
+				PolicyTargetEnvironment: tt.environment,
+				Timestamp:               time.Now(),
+			}
+			response := service.process(evidence, mapperPlugin, scope)
+			assert.Equal(t, tt.expectedStatus, response.Compliance.Status)
+			assert.Equal(t, tt.expectedException, response.Compliance.Exception)
+		})
+	}
+}
+
+// targetMapper reports every finding of the wrapped mapper for target.
+type targetMapper struct {
+	mapper.Mapper
+	target string
+}
+
+func (m targetMapper) Map(evidence api.Evidence, scope mapper.Scope) api.Compliance {
+	compliance := m.Mapper.Map(evidence, scope)
+	compliance.TargetId = &m.target
+	return compliance
+}
+
+func TestServiceProcessExceptionFindingTarget(t *testing.T) {
+	basicMapper, scope := newProcessFixture()
+	registry, err := exception.NewRegistry(exception.Exception{
+		ID:            "EX-COMPONENT",
+		Control:       "AC-1",
+		Target:        "component-a",
+		Justification: "accepted risk",
+		Approver:      "ciso",
+		Expires:       time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+	service := NewService(mapper.Set{}, scope, WithExceptions(registry), WithEnvironmentCategories("Production", "Staging"))
+
+	evidence := api.Evidence{
+		PolicyEngineName:       "test-policy-engine",
+		PolicyRuleId:           "AC-1",
+		PolicyEvaluationStatus: api.Failed,
+		PolicyTargetId:         stringPtr("report"),
+		Timestamp:              time.Now(),
+	}
+
+	response := service.process(evidence, targetMapper{Mapper: basicMapper, target: "component-a"}, scope)
+	assert.Equal(t, api.ComplianceStatusExempt, response.Compliance.Status)
+
+	response = service.process(evidence, targetMapper{Mapper: basicMapper, target: "component-b"}, scope)
+	assert.Equal(t, api.ComplianceStatusNonCompliant, response.Compliance.Status)
+	assert.Nil(t, response.Compliance.Exception)
+}
+
+// responseSchema returns the 200 response schema for the POST operation at
+// path, or the GET operation for read-only paths.
+func responseSchema(t *testing.T, swagger *openapi3.T, path string) *openapi3.Schema {
+	t.Helper()
+	pathItem := swagger.Paths.Find(path)
+	require.NotNil(t, pathItem)
+	operation := pathItem.Post
+	if operation == nil {
+		operation = pathItem.Get
+	}
+	require.NotNil(t, operation)
+
+	responseRef, ok := operation.Responses.Map()["200"]
+	require.True(t, ok)
+	require.NotNil(t, responseRef.Value)
+
+	mediaType := responseRef.Value.Content.Get("application/json")
+	require.NotNil(t, mediaType)
+	require.NotNil(t, mediaType.Schema)
+	require.NotNil(t, mediaType.Schema.Value)
+	return mediaType.Schema.Value
+}
+
 // validateEnrichmentResponse validates an EnrichmentResponse against the OpenAPI schema
 func validateEnrichmentResponse(t *testing.T, response api.EnrichmentResponse, swagger *openapi3.T) error {
 	t.Helper()
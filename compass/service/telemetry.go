@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+const instrumentationName = "github.com/complytime/complybeacon/compass/service"
+
+// Attribute keys recorded on enrichment metrics and spans.
+const (
+	attrEnrichmentStatus = attribute.Key("compliance.enrichment.status")
+	attrComplianceStatus = attribute.Key("compliance.status")
+	attrMapperID         = attribute.Key("compass.mapper.id")
+	attrMapperFallback   = attribute.Key("compass.mapper.fallback")
+	attrPolicyEngineName = attribute.Key("policy.engine.name")
+	attrPolicyRuleID     = attribute.Key("policy.rule.id")
+	attrBatchSize        = attribute.Key("compass.batch.size")
+)
+
+// instruments records the service's own metrics and spans.
+type instruments struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	outcomes metric.Int64Counter
+}
+
+func newInstruments(meterProvider metric.MeterProvider, tracerProvider trace.TracerProvider) instruments {
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	meter := meterProvider.Meter(instrumentationName)
+
+	// Instrument creation only fails on invalid names; the no-op instruments
+	// returned alongside the error are safe to use.
+	duration, _ := meter.Float64Histogram("compass.enrichment.duration",
+		metric.WithDescription("Duration of enriching a single piece of evidence."),
+		metric.WithUnit("s"),
+	)
+	outcomes, _ := meter.Int64Counter("compass.enrichment.outcomes",
+		metric.WithDescription("Number of enriched evidence records by outcome."),
+		metric.WithUnit("{evidence}"),
+	)
+	return instruments{
+		tracer:   tracerProvider.Tracer(instrumentationName),
+		duration: duration,
+		outcomes: outcomes,
+	}
+}
+
+// enrichWith enriches evidence with mapperPlugin and records the outcome.
+// fallback reports whether mapperPlugin is the fallback for an unknown policy
+// engine.
+func (s *Service) enrichWith(ctx context.Context, evidence api.Evidence, mapperPlugin mapper.Mapper, fallback bool, scope mapper.Scope, mode *api.EnrichmentMode) api.EnrichmentResponse {
+	start := time.Now()
+	response := s.processMode(evidence, mapperPlugin, scope, mode)
+
+	attrs := metric.WithAttributes(
+		attrEnrichmentStatus.String(string(response.Compliance.EnrichmentStatus)),
+		attrComplianceStatus.String(string(response.Compliance.Status)),
+		attrMapperID.String(string(mapperPlugin.PluginName())),
+		attrMapperFallback.Bool(fallback),
+	)
+	s.instruments.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+	s.instruments.outcomes.Add(ctx, 1, attrs)
+	return response
+}
+
+// startEnrichSpan starts the span for enriching a single piece of evidence.
+func (s *Service) startEnrichSpan(ctx context.Context, evidence api.Evidence) (context.Context, trace.Span) {
+	return s.instruments.tracer.Start(ctx, "compass.enrich", trace.WithAttributes(
+		attrPolicyEngineName.String(evidence.PolicyEngineName),
+		attrPolicyRuleID.String(evidence.PolicyRuleId),
+	))
+}
+
+// endEnrichSpan records the enrichment outcome on span and ends it.
+func endEnrichSpan(span trace.Span, mapperPlugin mapper.Mapper, fallback bool, response api.EnrichmentResponse) {
+	span.SetAttributes(
+		attrMapperID.String(string(mapperPlugin.PluginName())),
+		attrMapperFallback.Bool(fallback),
+		attrEnrichmentStatus.String(string(response.Compliance.EnrichmentStatus)),
+		attrComplianceStatus.String(string(response.Compliance.Status)),
+	)
+	span.End()
+}
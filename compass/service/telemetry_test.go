@@ -0,0 +1,119 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/complytime/complybeacon/compass/api"
+	"github.com/complytime/complybeacon/compass/mapper"
+)
+
+func TestPostV1EnrichTelemetry(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+
+	mapperPlugin, scope := newProcessFixture()
+	service := NewService(mapper.Set{"test-policy-engine": mapperPlugin}, scope,
+		WithMeterProvider(meterProvider),
+		WithTracerProvider(tracerProvider),
+	)
+
+	r := gin.New()
+	r.Use(otelgin.Middleware("compass",
+		otelgin.WithTracerProvider(tracerProvider),
+		otelgin.WithPropagators(propagation.TraceContext{}),
+	))
+	api.RegisterHandlers(r, service)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	for _, engine := range []string{"test-policy-engine", "unknown-engine"} {
+		body, err := json.Marshal(api.EnrichmentRequest{Evidence: api.Evidence{
+			PolicyEngineName:       engine,
+			PolicyRuleId:           "AC-1",
+			PolicyEvaluationStatus: api.Failed,
+			Timestamp:              time.Now(),
+		}})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v1/enrich", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	t.Run("enrich spans join the incoming trace", func(t *testing.T) {
+		var enrichSpans []sdktrace.ReadOnlySpan
+		for _, span := range spans.Ended() {
+			if span.Name() == "compass.enrich" {
+				enrichSpans = append(enrichSpans, span)
+			}
+		}
+		require.Len(t, enrichSpans, 2)
+		for _, span := range enrichSpans {
+			assert.Equal(t, traceID, span.SpanContext().TraceID().String())
+		}
+		assert.Contains(t, enrichSpans[0].Attributes(), attrMapperID.String("basic"))
+		assert.Contains(t, enrichSpans[0].Attributes(), attrComplianceStatus.String(string(api.ComplianceStatusNonCompliant)))
+	})
+
+	t.Run("outcomes are counted by status, mapper, and fallback", func(t *testing.T) {
+		var data metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &data))
+
+		outcomes := map[attribute.Distinct]int64{}
+		var durations uint64
+		for _, scopeMetrics := range data.ScopeMetrics {
+			for _, m := range scopeMetrics.Metrics {
+				switch m.Name {
+				case "compass.enrichment.outcomes":
+					for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+						outcomes[point.Attributes.Equivalent()] = point.Value
+					}
+				case "compass.enrichment.duration":
+					for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+						durations += point.Count
+					}
+				}
+			}
+		}
+
+		mapped := attribute.NewSet(
+			attrEnrichmentStatus.String(string(api.ComplianceEnrichmentStatusSuccess)),
+			attrComplianceStatus.String(string(api.ComplianceStatusNonCompliant)),
+			attrMapperID.String("basic"),
+			attrMapperFallback.Bool(false),
+		)
+		fallback := attribute.NewSet(
+			attrEnrichmentStatus.String(string(api.ComplianceEnrichmentStatusUnmapped)),
+			attrComplianceStatus.String(string(api.ComplianceStatusUnknown)),
+			attrMapperID.String("basic"),
+			attrMapperFallback.Bool(true),
+		)
+		assert.Equal(t, map[attribute.Distinct]int64{
+			mapped.Equivalent():   1,
+			fallback.Equivalent(): 1,
+		}, outcomes)
+		assert.Equal(t, uint64(2), durations)
+	})
+}
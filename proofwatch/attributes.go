@@ -11,12 +11,18 @@ const COMPLIANCE_CONTROL_APPLICABILITY = "compliance.control.applicability"
 // Unique identifier for the security control catalog or framework
 const COMPLIANCE_CONTROL_CATALOG_ID = "compliance.control.catalog.id"
 
+// Catalog identifiers for each entry of compliance.control.ids, in the same order
+const COMPLIANCE_CONTROL_CATALOG_IDS = "compliance.control.catalog.ids"
+
 // Category or family that the security control belongs to
 const COMPLIANCE_CONTROL_CATEGORY = "compliance.control.category"
 
 // Unique identifier for the security control and assessment requirement being assessed
 const COMPLIANCE_CONTROL_ID = "compliance.control.id"
 
+// Identifiers of every security control and assessment requirement the policy rule maps to, when a single evaluation satisfies several controls
+const COMPLIANCE_CONTROL_IDS = "compliance.control.ids"
+
 // Result of the compliance framework mapping and enrichment process, indicating whether compliance context was successfully added to the event
 const COMPLIANCE_ENRICHMENT_STATUS = "compliance.enrichment.status"
 
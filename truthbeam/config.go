@@ -2,22 +2,70 @@ package truthbeam
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 )
 
+// MatchMode selects how log records whose policy rule maps to controls in
+// several catalogs are enriched.
+type MatchMode string
+
+const (
+	// MatchModeFirst enriches the record with the first matching control only.
+	MatchModeFirst MatchMode = "first"
+	// MatchModeAttributes enriches the record with the first matching control
+	// and lists every matching control in array attributes.
+	MatchModeAttributes MatchMode = "attributes"
+	// MatchModeFanout emits one record per matching control.
+	MatchModeFanout MatchMode = "fanout"
+)
+
+// Transport selects the protocol used to call Compass.
+type Transport string
+
+const (
+	// TransportHTTP calls the Compass HTTP API configured by the top-level
+	// client settings.
+	TransportHTTP Transport = "http"
+	// TransportGRPC calls the Compass gRPC API configured by the grpc
+	// settings.
+	TransportGRPC Transport = "grpc"
+)
+
 // Config defines configuration for the truthbeam processor.
 type Config struct {
 	ClientConfig confighttp.ClientConfig `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	// MatchMode defaults to MatchModeFirst.
+	MatchMode MatchMode `mapstructure:"match_mode"`
+	// Transport defaults to TransportHTTP.
+	Transport Transport `mapstructure:"transport"`
+	// GRPC configures the client used when Transport is TransportGRPC.
+	GRPC configgrpc.ClientConfig `mapstructure:"grpc"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
-	if cfg.ClientConfig.Endpoint == "" {
-		return errors.New("endpoint must be specified")
+	switch cfg.Transport {
+	case "", TransportHTTP:
+		if cfg.ClientConfig.Endpoint == "" {
+			return errors.New("endpoint must be specified")
+		}
+	case TransportGRPC:
+		if cfg.GRPC.Endpoint == "" {
+			return errors.New("grpc endpoint must be specified")
+		}
+	default:
+		return fmt.Errorf("unknown transport %q: must be %s or %s", cfg.Transport, TransportHTTP, TransportGRPC)
+	}
+	switch cfg.MatchMode {
+	case "", MatchModeFirst, MatchModeAttributes, MatchModeFanout:
+	default:
+		return fmt.Errorf("unknown match_mode %q: must be %s, %s, or %s", cfg.MatchMode, MatchModeFirst, MatchModeAttributes, MatchModeFanout)
 	}
 	return nil
 }
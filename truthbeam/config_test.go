@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 )
 
@@ -51,6 +52,57 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "known match mode should pass",
+			config: &Config{
+				ClientConfig: confighttp.ClientConfig{
+					Endpoint: "http://localhost:8081",
+				},
+				MatchMode: MatchModeFanout,
+			},
+			expectError: false,
+		},
+		{
+			name: "unknown match mode should fail",
+			config: &Config{
+				ClientConfig: confighttp.ClientConfig{
+					Endpoint: "http://localhost:8081",
+				},
+				MatchMode: "every",
+			},
+			expectError: true,
+			errorMsg:    "unknown match_mode",
+		},
+		{
+			name: "grpc transport with grpc endpoint should pass",
+			config: &Config{
+				Transport: TransportGRPC,
+				GRPC:      configgrpc.ClientConfig{Endpoint: "localhost:9090"},
+			},
+			expectError: false,
+		},
+		{
+			name: "grpc transport without grpc endpoint should fail",
+			config: &Config{
+				ClientConfig: confighttp.ClientConfig{
+					Endpoint: "http://localhost:8081",
+				},
+				Transport: TransportGRPC,
+			},
+			expectError: true,
+			errorMsg:    "grpc endpoint must be specified",
+		},
+		{
+			name: "unknown transport should fail",
+			config: &Config{
+				ClientConfig: confighttp.ClientConfig{
+					Endpoint: "http://localhost:8081",
+				},
+				Transport: "websocket",
+			},
+			expectError: true,
+			errorMsg:    "unknown transport",
+		},
 		{
 			name: "empty string endpoint should fail",
 			config: &Config{
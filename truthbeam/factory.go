@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/processor"
@@ -34,6 +35,9 @@ func createDefaultConfig() component.Config {
 
 	return &Config{
 		ClientConfig: clientConfig,
+		MatchMode:    MatchModeFirst,
+		Transport:    TransportHTTP,
+		GRPC:         configgrpc.NewDefaultClientConfig(),
 	}
 }
 
@@ -55,5 +59,6 @@ func createLogsProcessor(
 		beamProcessor.processLogs,
 		processorhelper.WithCapabilities(processorCapabilities),
 		processorhelper.WithStart(beamProcessor.start),
+		processorhelper.WithShutdown(beamProcessor.shutdown),
 	)
 }
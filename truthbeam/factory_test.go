@@ -27,6 +27,7 @@ func TestCreateDefaultConfig(t *testing.T) {
 	assert.Equal(t, 30*time.Second, cfg.ClientConfig.Timeout, "Expected timeout 30s")
 	assert.Empty(t, cfg.ClientConfig.Compression, "Expected compression to be disabled by default for small payloads")
 	assert.Equal(t, 512*1024, cfg.ClientConfig.WriteBufferSize, "Expected write buffer size 512KB")
+	assert.Equal(t, MatchModeFirst, cfg.MatchMode, "Expected only the first matching control by default")
 }
 
 func TestCreateLogsProcessor(t *testing.T) {
@@ -12,8 +12,105 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
+// Enricher sends enrichment requests to Compass.
+type Enricher interface {
+	Enrich(ctx context.Context, req EnrichmentRequest) (*EnrichmentResponse, error)
+}
+
+// HTTPEnricher calls the Compass HTTP API.
+type HTTPEnricher struct {
+	client    *Client
+	serverURL string
+}
+
+var _ Enricher = (*HTTPEnricher)(nil)
+
+// NewHTTPEnricher returns an Enricher that posts requests to serverURL.
+func NewHTTPEnricher(client *Client, serverURL string) *HTTPEnricher {
+	return &HTTPEnricher{client: client, serverURL: serverURL}
+}
+
+// Enrich posts req to the /v1/enrich endpoint.
+func (e *HTTPEnricher) Enrich(ctx context.Context, req EnrichmentRequest) (*EnrichmentResponse, error) {
+	return callEnrichAPI(ctx, e.client, e.serverURL, req)
+}
+
 // ApplyAttributes enriches attributes in the log record with compliance impact data.
-func ApplyAttributes(ctx context.Context, client *Client, serverURL string, _ pcommon.Resource, logRecord plog.LogRecord) error {
+func ApplyAttributes(ctx context.Context, client *Client, serverURL string, resource pcommon.Resource, logRecord plog.LogRecord) error {
+	return ApplyEnrichment(ctx, NewHTTPEnricher(client, serverURL), resource, logRecord)
+}
+
+// ApplyEnrichment enriches attributes in the log record with compliance impact
+// data obtained from enricher.
+func ApplyEnrichment(ctx context.Context, enricher Enricher, _ pcommon.Resource, logRecord plog.LogRecord) error {
+	enrichReq, err := newEnrichmentRequest(logRecord)
+	if err != nil {
+		return err
+	}
+
+	enrichRes, err := enricher.Enrich(ctx, enrichReq)
+	if err != nil {
+		return err
+	}
+
+	applyCompliance(logRecord.Attributes(), enrichRes.Compliance)
+	return nil
+}
+
+// ApplyAllAttributes enriches the log record with every control its policy rule
+// maps to. The first control is applied to logRecord. When newRecord is nil, the
+// controls are also listed in the compliance.control.ids and
+// compliance.control.catalog.ids attributes. Otherwise, newRecord is called once
+// for each further control and the returned record is filled with a copy of the
+// original log record enriched with that control.
+func ApplyAllAttributes(ctx context.Context, enricher Enricher, _ pcommon.Resource, logRecord plog.LogRecord, newRecord func() plog.LogRecord) error {
+	enrichReq, err := newEnrichmentRequest(logRecord)
+	if err != nil {
+		return err
+	}
+	mode := All
+	enrichReq.Mode = &mode
+
+	enrichRes, err := enricher.Enrich(ctx, enrichReq)
+	if err != nil {
+		return err
+	}
+
+	var matches []Compliance
+	if enrichRes.Matches != nil {
+		matches = *enrichRes.Matches
+	}
+	if len(matches) == 0 {
+		applyCompliance(logRecord.Attributes(), enrichRes.Compliance)
+		return nil
+	}
+
+	if newRecord == nil {
+		applyCompliance(logRecord.Attributes(), matches[0])
+		controlIDs := logRecord.Attributes().PutEmptySlice(COMPLIANCE_CONTROL_IDS)
+		catalogIDs := logRecord.Attributes().PutEmptySlice(COMPLIANCE_CONTROL_CATALOG_IDS)
+		for _, match := range matches {
+			controlIDs.AppendEmpty().SetStr(match.Control.Id)
+			catalogIDs.AppendEmpty().SetStr(match.Control.CatalogId)
+		}
+		return nil
+	}
+
+	original := plog.NewLogRecord()
+	logRecord.CopyTo(original)
+	applyCompliance(logRecord.Attributes(), matches[0])
+	for _, match := range matches[1:] {
+		record := newRecord()
+		original.CopyTo(record)
+		applyCompliance(record.Attributes(), match)
+	}
+	return nil
+}
+
+// newEnrichmentRequest builds the enrichment request for the log record. When
+// required attributes are missing, the record is marked as skipped and an
+// error is returned.
+func newEnrichmentRequest(logRecord plog.LogRecord) (EnrichmentRequest, error) {
 	attrs := logRecord.Attributes()
 
 	// Retrieve lookup attributes
@@ -36,7 +133,7 @@ func ApplyAttributes(ctx context.Context, client *Client, serverURL string, _ pc
 
 	if len(missingAttrs) > 0 {
 		attrs.PutStr(COMPLIANCE_ENRICHMENT_STATUS, string(ComplianceEnrichmentStatusSkipped))
-		return fmt.Errorf("missing required attributes: %s", strings.Join(missingAttrs, ", "))
+		return EnrichmentRequest{}, fmt.Errorf("missing required attributes: %s", strings.Join(missingAttrs, ", "))
 	}
 
 	enrichReq := EnrichmentRequest{
@@ -48,38 +145,71 @@ func ApplyAttributes(ctx context.Context, client *Client, serverURL string, _ pc
 		},
 	}
 
-	enrichRes, err := callEnrichAPI(ctx, client, serverURL, enrichReq)
-	if err != nil {
-		return err
+	if targetEnvVal, ok := attrs.Get(POLICY_TARGET_ENVIRONMENT); ok && targetEnvVal.Str() != "" {
+		targetEnv := targetEnvVal.Str()
+		enrichReq.Evidence.PolicyTargetEnvironment = &targetEnv
 	}
 
+	if targetIDVal, ok := attrs.Get(POLICY_TARGET_ID); ok && targetIDVal.Str() != "" {
+		targetID := targetIDVal.Str()
+		enrichReq.Evidence.PolicyTargetId = &targetID
+	}
+
+	return enrichReq, nil
+}
+
+// applyCompliance adds the enrichment status and, when enrichment succeeded,
+// the compliance attributes to attrs.
+func applyCompliance(attrs pcommon.Map, compliance Compliance) {
 	// Add enrichment status
-	attrs.PutStr(COMPLIANCE_ENRICHMENT_STATUS, string(enrichRes.Compliance.EnrichmentStatus))
+	attrs.PutStr(COMPLIANCE_ENRICHMENT_STATUS, string(compliance.EnrichmentStatus))
 
 	// Only add compliance attributes if enrichment was successful
-	if enrichRes.Compliance.EnrichmentStatus == ComplianceEnrichmentStatusSuccess {
-		attrs.PutStr(COMPLIANCE_STATUS, string(enrichRes.Compliance.Status))
-		attrs.PutStr(COMPLIANCE_CONTROL_ID, enrichRes.Compliance.Control.Id)
-		attrs.PutStr(COMPLIANCE_CONTROL_CATALOG_ID, enrichRes.Compliance.Control.CatalogId)
-		attrs.PutStr(COMPLIANCE_CONTROL_CATEGORY, enrichRes.Compliance.Control.Category)
-		requirements := attrs.PutEmptySlice(COMPLIANCE_REQUIREMENTS)
-		standards := attrs.PutEmptySlice(COMPLIANCE_FRAMEWORKS)
-
-		if enrichRes.Compliance.Control.RemediationDescription != nil {
-			attrs.PutStr(COMPLIANCE_REMEDIATION_DESCRIPTION, *enrichRes.Compliance.Control.RemediationDescription)
-		}
+	if compliance.EnrichmentStatus != ComplianceEnrichmentStatusSuccess {
+		return
+	}
 
-		for _, req := range enrichRes.Compliance.Frameworks.Requirements {
-			newReq := requirements.AppendEmpty()
-			newReq.SetStr(req)
-		}
-		for _, std := range enrichRes.Compliance.Frameworks.Frameworks {
-			newStd := standards.AppendEmpty()
-			newStd.SetStr(std)
+	attrs.PutStr(COMPLIANCE_STATUS, string(compliance.Status))
+	attrs.PutStr(COMPLIANCE_CONTROL_ID, compliance.Control.Id)
+	attrs.PutStr(COMPLIANCE_CONTROL_CATALOG_ID, compliance.Control.CatalogId)
+	attrs.PutStr(COMPLIANCE_CONTROL_CATEGORY, compliance.Control.Category)
+	requirements := attrs.PutEmptySlice(COMPLIANCE_REQUIREMENTS)
+	standards := attrs.PutEmptySlice(COMPLIANCE_FRAMEWORKS)
+
+	if compliance.Control.RemediationDescription != nil {
+		attrs.PutStr(COMPLIANCE_REMEDIATION_DESCRIPTION, *compliance.Control.RemediationDescription)
+	}
+
+	if compliance.Control.Applicability != nil {
+		applicability := attrs.PutEmptySlice(COMPLIANCE_CONTROL_APPLICABILITY)
+		for _, env := range *compliance.Control.Applicability {
+			applicability.AppendEmpty().SetStr(env)
 		}
 	}
 
-	return nil
+	if compliance.Risk != nil && compliance.Risk.Level != nil {
+		attrs.PutStr(COMPLIANCE_RISK_LEVEL, string(*compliance.Risk.Level))
+	}
+
+	// Findings for part of the evaluated target, such as one component of
+	// a report, name that part.
+	if compliance.TargetId != nil {
+		attrs.PutStr(POLICY_TARGET_ID, *compliance.TargetId)
+	}
+
+	if compliance.Exception != nil {
+		attrs.PutStr(COMPLIANCE_REMEDIATION_EXCEPTION_ID, compliance.Exception.Id)
+		attrs.PutBool(COMPLIANCE_REMEDIATION_EXCEPTION_ACTIVE, compliance.Exception.Active)
+	}
+
+	for _, req := range compliance.Frameworks.Requirements {
+		newReq := requirements.AppendEmpty()
+		newReq.SetStr(req)
+	}
+	for _, std := range compliance.Frameworks.Frameworks {
+		newStd := standards.AppendEmpty()
+		newStd.SetStr(std)
+	}
 }
 
 // callEnrichAPI is a helper function to perform the actual HTTP request.
@@ -94,6 +94,137 @@ func TestApplyAttributes(t *testing.T) {
 	assert.Contains(t, standards, "ISO-27001")
 }
 
+// TestApplyAttributesTargetEnvironment tests that the target environment is sent
+// and that control applicability is added to the log record.
+func TestApplyAttributesTargetEnvironment(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req EnrichmentRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		require.NoError(t, err)
+
+		require.NotNil(t, req.Evidence.PolicyTargetEnvironment)
+		assert.Equal(t, "Staging", *req.Evidence.PolicyTargetEnvironment)
+
+		applicability := []string{"Production"}
+		response := EnrichmentResponse{
+			Compliance: Compliance{
+				Control: ComplianceControl{
+					CatalogId:     "NIST-800-53",
+					Category:      "Access Control",
+					Id:            "AC-1",
+					Applicability: &applicability,
+				},
+				Frameworks: ComplianceFrameworks{
+					Requirements: []string{},
+					Frameworks:   []string{},
+				},
+				Status:           ComplianceStatusNotApplicable,
+				EnrichmentStatus: ComplianceEnrichmentStatusSuccess,
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(mockServer.URL)
+	require.NoError(t, err)
+
+	logRecord, resource := createTestLogRecord()
+	logRecord.Attributes().PutStr(POLICY_TARGET_ENVIRONMENT, "Staging")
+
+	err = ApplyAttributes(context.Background(), client, mockServer.URL, resource, logRecord)
+	require.NoError(t, err)
+
+	attrs := logRecord.Attributes().AsRaw()
+	assert.Equal(t, string(ComplianceStatusNotApplicable), attrs[COMPLIANCE_STATUS])
+	assert.Equal(t, []interface{}{"Production"}, attrs[COMPLIANCE_CONTROL_APPLICABILITY])
+}
+
+// TestApplyAttributesException tests that the target ID is sent and that a
+// matched exception is added to the log record.
+func TestApplyAttributesException(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req EnrichmentRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		require.NoError(t, err)
+
+		require.NotNil(t, req.Evidence.PolicyTargetId)
+		assert.Equal(t, "repo-a", *req.Evidence.PolicyTargetId)
+
+		response := EnrichmentResponse{
+			Compliance: Compliance{
+				Control: ComplianceControl{
+					CatalogId: "NIST-800-53",
+					Category:  "Access Control",
+					Id:        "AC-1",
+				},
+				Frameworks: ComplianceFrameworks{
+					Requirements: []string{},
+					Frameworks:   []string{},
+				},
+				Exception:        &ComplianceException{Id: "EX-1", Active: true},
+				Status:           ComplianceStatusExempt,
+				EnrichmentStatus: ComplianceEnrichmentStatusSuccess,
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(mockServer.URL)
+	require.NoError(t, err)
+
+	logRecord, resource := createTestLogRecord()
+	logRecord.Attributes().PutStr(POLICY_TARGET_ID, "repo-a")
+
+	err = ApplyAttributes(context.Background(), client, mockServer.URL, resource, logRecord)
+	require.NoError(t, err)
+
+	attrs := logRecord.Attributes().AsRaw()
+	assert.Equal(t, string(ComplianceStatusExempt), attrs[COMPLIANCE_STATUS])
+	assert.Equal(t, "EX-1", attrs[COMPLIANCE_REMEDIATION_EXCEPTION_ID])
+	assert.Equal(t, true, attrs[COMPLIANCE_REMEDIATION_EXCEPTION_ACTIVE])
+}
+
+func TestApplyAttributesFindingTarget(t *testing.T) {
+	target := "quay.io/example/checkout@sha256:1f0e"
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := EnrichmentResponse{
+			Compliance: Compliance{
+				Control: ComplianceControl{
+					CatalogId: "OSPS-B",
+					Category:  "Build and Release",
+					Id:        "OSPS-BR-01.01",
+				},
+				Frameworks: ComplianceFrameworks{
+					Requirements: []string{},
+					Frameworks:   []string{},
+				},
+				TargetId:         &target,
+				Status:           ComplianceStatusNonCompliant,
+				EnrichmentStatus: ComplianceEnrichmentStatusSuccess,
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(mockServer.URL)
+	require.NoError(t, err)
+
+	logRecord, resource := createTestLogRecord()
+	logRecord.Attributes().PutStr(POLICY_TARGET_ID, "report")
+
+	err = ApplyAttributes(context.Background(), client, mockServer.URL, resource, logRecord)
+	require.NoError(t, err)
+
+	attrs := logRecord.Attributes().AsRaw()
+	assert.Equal(t, target, attrs[POLICY_TARGET_ID])
+}
+
 // Table-driven coverage for missing required attributes
 func TestApplyAttributesMissingRequiredAttributes(t *testing.T) {
 	client, err := NewClient("http://localhost:8081")
@@ -239,6 +370,59 @@ func TestApplyAttributes_ServerResponses(t *testing.T) {
 				assert.False(t, exists, "Remediation attribute should not exist when nil")
 			},
 		},
+		{
+			name: "adds risk level when present",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				level := High
+				_ = json.NewEncoder(w).Encode(EnrichmentResponse{
+					Compliance: Compliance{
+						Control: ComplianceControl{
+							CatalogId: "NIST-800-53",
+							Category:  "Access Control",
+							Id:        "AC-1",
+						},
+						Frameworks: ComplianceFrameworks{
+							Requirements: []string{"req-1"},
+							Frameworks:   []string{"NIST-800-53"},
+						},
+						Risk:             &ComplianceRisk{Level: &level},
+						Status:           ComplianceStatusNonCompliant,
+						EnrichmentStatus: ComplianceEnrichmentStatusSuccess,
+					},
+				})
+			},
+			expectErr: false,
+			assertFunc: func(t *testing.T, attrs map[string]interface{}, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "High", attrs[COMPLIANCE_RISK_LEVEL])
+			},
+		},
+		{
+			name: "omits risk level when nil",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(EnrichmentResponse{
+					Compliance: Compliance{
+						Control: ComplianceControl{
+							CatalogId: "NIST-800-53",
+							Category:  "Access Control",
+							Id:        "AC-1",
+						},
+						Frameworks: ComplianceFrameworks{
+							Requirements: []string{"req-1"},
+							Frameworks:   []string{"NIST-800-53"},
+						},
+						Status:           ComplianceStatusCompliant,
+						EnrichmentStatus: ComplianceEnrichmentStatusSuccess,
+					},
+				})
+			},
+			expectErr: false,
+			assertFunc: func(t *testing.T, attrs map[string]interface{}, err error) {
+				assert.NoError(t, err)
+				_, exists := attrs[COMPLIANCE_RISK_LEVEL]
+				assert.False(t, exists, "Risk level attribute should not exist when nil")
+			},
+		},
 	}
 
 	for _, tt := range tests {
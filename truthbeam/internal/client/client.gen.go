@@ -13,6 +13,8 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/oapi-codegen/runtime"
 )
 
 // Defines values for ComplianceEnrichmentStatus.
@@ -28,6 +30,7 @@ const (
 const (
 	ComplianceStatusCompliant     ComplianceStatus = "Compliant"
 	ComplianceStatusExempt        ComplianceStatus = "Exempt"
+	ComplianceStatusNeedsReview   ComplianceStatus = "Needs Review"
 	ComplianceStatusNonCompliant  ComplianceStatus = "Non-Compliant"
 	ComplianceStatusNotApplicable ComplianceStatus = "Not Applicable"
 	ComplianceStatusUnknown       ComplianceStatus = "Unknown"
@@ -42,6 +45,12 @@ const (
 	Medium        ComplianceRiskLevel = "Medium"
 )
 
+// Defines values for EnrichmentMode.
+const (
+	All   EnrichmentMode = "all"
+	First EnrichmentMode = "first"
+)
+
 // Defines values for EvidencePolicyEvaluationStatus.
 const (
 	Failed        EvidencePolicyEvaluationStatus = "Failed"
@@ -52,6 +61,98 @@ const (
 	Unknown       EvidencePolicyEvaluationStatus = "Unknown"
 )
 
+// Defines values for GetV1CoverageParamsFormat.
+const (
+	Json     GetV1CoverageParamsFormat = "json"
+	Markdown GetV1CoverageParamsFormat = "markdown"
+)
+
+// BatchEnrichmentRequest Request payload for enriching several evidence records in one call
+type BatchEnrichmentRequest struct {
+	Evidence []Evidence `json:"evidence"`
+
+	// Mode How evidence whose policy rule maps to controls in several catalogs is enriched. With "first", only the
+	// first matching control is returned. With "all", every matching control is returned in matches.
+	Mode *EnrichmentMode `json:"mode,omitempty"`
+}
+
+// BatchEnrichmentResponse Enrichment results for a batch, in the same order as the request evidence.
+type BatchEnrichmentResponse struct {
+	Results []BatchEnrichmentResult `json:"results"`
+}
+
+// BatchEnrichmentResult Outcome for a single evidence record in a batch. Exactly one of compliance or error is set.
+type BatchEnrichmentResult struct {
+	// Compliance Compliance details from OCSF Security Control Profile.
+	Compliance *Compliance `json:"compliance,omitempty"`
+	Error      *Error      `json:"error,omitempty"`
+
+	// Matches Every control the evidence maps to when the request mode is "all".
+	Matches *[]Compliance `json:"matches,omitempty"`
+}
+
+// CatalogControl Control from a loaded catalog
+type CatalogControl struct {
+	// CatalogId Unique identifier for the catalog the control belongs to
+	CatalogId string `json:"catalogId"`
+
+	// Family Family a control belongs to
+	Family ControlFamily `json:"family"`
+
+	// GuidelineMappings Framework requirements the control maps to
+	GuidelineMappings []GuidelineMapping `json:"guidelineMappings"`
+
+	// Id Unique identifier for the control
+	Id string `json:"id"`
+
+	// Objective Objective of the control
+	Objective *string `json:"objective,omitempty"`
+
+	// Requirements Assessment requirements of the control
+	Requirements []ControlRequirement `json:"requirements"`
+
+	// Title Title of the control
+	Title string `json:"title"`
+}
+
+// CatalogCoverage Coverage of a loaded catalog
+type CatalogCoverage struct {
+	// CatalogId Unique identifier for the catalog
+	CatalogId string `json:"catalogId"`
+
+	// ControlCount Number of controls in the catalog
+	ControlCount int `json:"controlCount"`
+
+	// CoveredControlCount Number of controls with at least one procedure
+	CoveredControlCount int `json:"coveredControlCount"`
+
+	// Families Coverage of each control family, in catalog order
+	Families []FamilyCoverage `json:"families"`
+
+	// Title Title of the catalog
+	Title string `json:"title"`
+}
+
+// CatalogList Catalogs loaded into the Compass scope
+type CatalogList struct {
+	Catalogs []CatalogSummary `json:"catalogs"`
+}
+
+// CatalogSummary Summary of a loaded Layer 2 catalog
+type CatalogSummary struct {
+	// ControlCount Number of controls in the catalog
+	ControlCount int `json:"controlCount"`
+
+	// Id Unique identifier for the catalog
+	Id string `json:"id"`
+
+	// Title Title of the catalog
+	Title string `json:"title"`
+
+	// Version Version of the catalog
+	Version *string `json:"version,omitempty"`
+}
+
 // Compliance Compliance details from OCSF Security Control Profile.
 type Compliance struct {
 	// Control Security control information for compliance assessment
@@ -60,6 +161,9 @@ type Compliance struct {
 	// EnrichmentStatus Status of the compliance enrichment process: Success, Unmapped, Partial, Unknown, or Skipped.
 	EnrichmentStatus ComplianceEnrichmentStatus `json:"enrichmentStatus"`
 
+	// Exception Approved exception or waiver that matched the finding
+	Exception *ComplianceException `json:"exception,omitempty"`
+
 	// Frameworks Compliance framework and requirement information
 	Frameworks ComplianceFrameworks `json:"frameworks"`
 
@@ -68,6 +172,9 @@ type Compliance struct {
 
 	// Status Compliance status
 	Status ComplianceStatus `json:"status"`
+
+	// TargetId Target the finding applies to, when it is narrower than the evidence policyTargetId. Set by mappers for evidence that covers several targets, such as the components of a Conforma report.
+	TargetId *string `json:"targetId,omitempty"`
 }
 
 // ComplianceEnrichmentStatus Status of the compliance enrichment process: Success, Unmapped, Partial, Unknown, or Skipped.
@@ -94,6 +201,15 @@ type ComplianceControl struct {
 	RemediationDescription *string `json:"remediationDescription,omitempty"`
 }
 
+// ComplianceException Approved exception or waiver that matched the finding
+type ComplianceException struct {
+	// Active Whether the exception is active for this finding. Expired exceptions are reported as inactive and do not change the status.
+	Active bool `json:"active"`
+
+	// Id Unique identifier for the approved exception
+	Id string `json:"id"`
+}
+
 // ComplianceFrameworks Compliance framework and requirement information
 type ComplianceFrameworks struct {
 	// Frameworks Regulatory or industry standards being evaluated for compliance
@@ -112,16 +228,91 @@ type ComplianceRisk struct {
 // ComplianceRiskLevel Risk level associated with non-compliance
 type ComplianceRiskLevel string
 
+// ControlCoverage Coverage of a control
+type ControlCoverage struct {
+	// ControlId Unique identifier for the control
+	ControlId string `json:"controlId"`
+
+	// Covered Whether any procedure maps to the control or one of its assessment requirements
+	Covered bool `json:"covered"`
+
+	// PolicyRules Procedures of every plugin that map to the control
+	PolicyRules []PolicyRuleReference `json:"policyRules"`
+
+	// Title Title of the control
+	Title string `json:"title"`
+
+	// UncoveredRequirements Assessment requirements of the control that no procedure maps to
+	UncoveredRequirements []string `json:"uncoveredRequirements"`
+}
+
+// ControlFamily Family a control belongs to
+type ControlFamily struct {
+	// Id Unique identifier for the control family
+	Id string `json:"id"`
+
+	// Title Title of the control family
+	Title string `json:"title"`
+}
+
+// ControlReference Reference to a control in a loaded catalog
+type ControlReference struct {
+	// CatalogId Unique identifier for the catalog
+	CatalogId string `json:"catalogId"`
+
+	// ControlId Unique identifier for the control
+	ControlId string `json:"controlId"`
+
+	// Title Title of the control
+	Title string `json:"title"`
+}
+
+// ControlRequirement Assessment requirement of a control
+type ControlRequirement struct {
+	// Applicability Environments or contexts where the requirement applies
+	Applicability []string `json:"applicability"`
+
+	// Id Unique identifier for the assessment requirement
+	Id string `json:"id"`
+
+	// Text Text of the assessment requirement
+	Text string `json:"text"`
+}
+
+// CoverageReport Coverage of the loaded catalogs by the assessment procedures of the configured plugins
+type CoverageReport struct {
+	// Catalogs Coverage of each loaded catalog, ordered by catalog ID
+	Catalogs []CatalogCoverage `json:"catalogs"`
+
+	// ExcludedPlugins Plugins that cannot list their procedures and so are not part of the report
+	ExcludedPlugins []string `json:"excludedPlugins"`
+
+	// UnresolvedProcedures Procedures referencing a catalog, control, or assessment requirement that is not loaded
+	UnresolvedProcedures []UnresolvedProcedure `json:"unresolvedProcedures"`
+}
+
+// EnrichmentMode How evidence whose policy rule maps to controls in several catalogs is enriched. With "first", only the
+// first matching control is returned. With "all", every matching control is returned in matches.
+type EnrichmentMode string
+
 // EnrichmentRequest Request payload for telemetry attribute enrichment
 type EnrichmentRequest struct {
 	// Evidence Complete evidence log from policy engines and compliance assessment tools
 	Evidence Evidence `json:"evidence"`
+
+	// Mode How evidence whose policy rule maps to controls in several catalogs is enriched. With "first", only the
+	// first matching control is returned. With "all", every matching control is returned in matches.
+	Mode *EnrichmentMode `json:"mode,omitempty"`
 }
 
 // EnrichmentResponse Enriched compliance finding with risk attributes and threat mappings.
 type EnrichmentResponse struct {
 	// Compliance Compliance details from OCSF Security Control Profile.
 	Compliance Compliance `json:"compliance"`
+
+	// Matches Every control the evidence maps to, in catalog order, when the request mode is "all". The first
+	// entry is the same finding as compliance. Empty when the evidence could not be mapped.
+	Matches *[]Compliance `json:"matches,omitempty"`
 }
 
 // Error defines model for Error.
@@ -144,6 +335,13 @@ type Evidence struct {
 	// PolicyRuleId Unique identifier for the policy rule being evaluated or enforced
 	PolicyRuleId string `json:"policyRuleId"`
 
+	// PolicyTargetEnvironment Environment where the target resource or entity exists. When the mapped control does not apply
+	// to this environment, the compliance status is reported as Not Applicable.
+	PolicyTargetEnvironment *string `json:"policyTargetEnvironment,omitempty"`
+
+	// PolicyTargetId Unique identifier for the resource or entity being evaluated or enforced against
+	PolicyTargetId *string `json:"policyTargetId,omitempty"`
+
 	// RawData Raw JSON output from the policy engine
 	RawData *map[string]interface{} `json:"rawData,omitempty"`
 
@@ -154,9 +352,115 @@ type Evidence struct {
 // EvidencePolicyEvaluationStatus Result of the policy evaluation
 type EvidencePolicyEvaluationStatus string
 
+// FamilyCoverage Coverage of a control family
+type FamilyCoverage struct {
+	// ControlCount Number of controls in the family
+	ControlCount int `json:"controlCount"`
+
+	// Controls Coverage of each control, in catalog order
+	Controls []ControlCoverage `json:"controls"`
+
+	// CoveredControlCount Number of controls with at least one procedure
+	CoveredControlCount int `json:"coveredControlCount"`
+
+	// Id Unique identifier for the control family
+	Id string `json:"id"`
+
+	// Title Title of the control family
+	Title string `json:"title"`
+}
+
+// GuidelineMapping Framework requirements a control maps to
+type GuidelineMapping struct {
+	// Framework Framework or guideline the requirements belong to
+	Framework string `json:"framework"`
+
+	// Requirements Requirement identifiers in the framework
+	Requirements []string `json:"requirements"`
+}
+
+// PolicyRuleReference Policy rule of a mapper plugin
+type PolicyRuleReference struct {
+	// CatalogId Unique identifier for the catalog of the control
+	CatalogId string `json:"catalogId"`
+
+	// ControlId Unique identifier for the control
+	ControlId string `json:"controlId"`
+
+	// PluginId Unique identifier for the plugin
+	PluginId string `json:"pluginId"`
+
+	// PolicyRuleId Policy rule identifier that evidence carries for this procedure
+	PolicyRuleId string `json:"policyRuleId"`
+
+	// RequirementId Unique identifier for the assessment requirement
+	RequirementId string `json:"requirementId"`
+}
+
+// Procedure Assessment procedure mapping a policy rule to a control
+type Procedure struct {
+	// CatalogId Unique identifier for the catalog of the control
+	CatalogId string `json:"catalogId"`
+
+	// ControlId Unique identifier for the control
+	ControlId string `json:"controlId"`
+
+	// Documentation Documentation for the procedure
+	Documentation *string `json:"documentation,omitempty"`
+
+	// PolicyRuleId Policy rule identifier that evidence carries for this procedure
+	PolicyRuleId string `json:"policyRuleId"`
+
+	// RequirementId Unique identifier for the assessment requirement
+	RequirementId string `json:"requirementId"`
+}
+
+// ProcedureList Assessment procedures known to a mapper plugin
+type ProcedureList struct {
+	// PluginId Unique identifier for the plugin
+	PluginId   string      `json:"pluginId"`
+	Procedures []Procedure `json:"procedures"`
+}
+
+// RequirementCoverage Controls and policy rules covering a framework requirement
+type RequirementCoverage struct {
+	// Controls Controls mapping to the requirement, ordered by catalog and control ID
+	Controls []ControlReference `json:"controls"`
+
+	// Framework Framework or guideline the requirement belongs to
+	Framework string `json:"framework"`
+
+	// PolicyRules Procedures of every plugin that map to one of the controls
+	PolicyRules []PolicyRuleReference `json:"policyRules"`
+
+	// RequirementId Requirement identifier in the framework
+	RequirementId string `json:"requirementId"`
+}
+
+// UnresolvedProcedure Procedure that references something missing from the loaded catalogs
+type UnresolvedProcedure struct {
+	// Procedure Policy rule of a mapper plugin
+	Procedure PolicyRuleReference `json:"procedure"`
+
+	// Reason What the procedure references that is not loaded
+	Reason string `json:"reason"`
+}
+
+// GetV1CoverageParams defines parameters for GetV1Coverage.
+type GetV1CoverageParams struct {
+	// Format Response format; markdown returns the report as a Markdown document
+	Format *GetV1CoverageParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetV1CoverageParamsFormat defines parameters for GetV1Coverage.
+type GetV1CoverageParamsFormat string
+
 // PostV1EnrichJSONRequestBody defines body for PostV1Enrich for application/json ContentType.
 type PostV1EnrichJSONRequestBody = EnrichmentRequest
 
+// PostV1EnrichBatchJSONRequestBody defines body for PostV1EnrichBatch for application/json ContentType.
+type PostV1EnrichBatchJSONRequestBody = BatchEnrichmentRequest
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -230,10 +534,66 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetV1Catalogs request
+	GetV1Catalogs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetV1CatalogsCatalogIdControlsControlId request
+	GetV1CatalogsCatalogIdControlsControlId(ctx context.Context, catalogId string, controlId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetV1Coverage request
+	GetV1Coverage(ctx context.Context, params *GetV1CoverageParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostV1EnrichWithBody request with any body
 	PostV1EnrichWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PostV1Enrich(ctx context.Context, body PostV1EnrichJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostV1EnrichBatchWithBody request with any body
+	PostV1EnrichBatchWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostV1EnrichBatch(ctx context.Context, body PostV1EnrichBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetV1FrameworksFrameworkRequirementsRequirementId request
+	GetV1FrameworksFrameworkRequirementsRequirementId(ctx context.Context, framework string, requirementId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetV1PluginsPluginIdProcedures request
+	GetV1PluginsPluginIdProcedures(ctx context.Context, pluginId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetV1Catalogs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetV1CatalogsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetV1CatalogsCatalogIdControlsControlId(ctx context.Context, catalogId string, controlId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetV1CatalogsCatalogIdControlsControlIdRequest(c.Server, catalogId, controlId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetV1Coverage(ctx context.Context, params *GetV1CoverageParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetV1CoverageRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) PostV1EnrichWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -260,19 +620,56 @@ func (c *Client) PostV1Enrich(ctx context.Context, body PostV1EnrichJSONRequestB
 	return c.Client.Do(req)
 }
 
-// NewPostV1EnrichRequest calls the generic PostV1Enrich builder with application/json body
-func NewPostV1EnrichRequest(server string, body PostV1EnrichJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) PostV1EnrichBatchWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostV1EnrichBatchRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostV1EnrichRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostV1EnrichRequestWithBody generates requests for PostV1Enrich with any type of body
-func NewPostV1EnrichRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+func (c *Client) PostV1EnrichBatch(ctx context.Context, body PostV1EnrichBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostV1EnrichBatchRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetV1FrameworksFrameworkRequirementsRequirementId(ctx context.Context, framework string, requirementId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetV1FrameworksFrameworkRequirementsRequirementIdRequest(c.Server, framework, requirementId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetV1PluginsPluginIdProcedures(ctx context.Context, pluginId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetV1PluginsPluginIdProceduresRequest(c.Server, pluginId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetV1CatalogsRequest generates requests for GetV1Catalogs
+func NewGetV1CatalogsRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -280,7 +677,7 @@ func NewPostV1EnrichRequestWithBody(server string, contentType string, body io.R
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/v1/enrich")
+	operationPath := fmt.Sprintf("/v1/catalogs")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -290,74 +687,454 @@ func NewPostV1EnrichRequestWithBody(server string, contentType string, body io.R
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewGetV1CatalogsCatalogIdControlsControlIdRequest generates requests for GetV1CatalogsCatalogIdControlsControlId
+func NewGetV1CatalogsCatalogIdControlsControlIdRequest(server string, catalogId string, controlId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "catalogId", runtime.ParamLocationPath, catalogId)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	var pathParam1 string
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "controlId", runtime.ParamLocationPath, controlId)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// PostV1EnrichWithBodyWithResponse request with any body
-	PostV1EnrichWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV1EnrichResponse, error)
+	operationPath := fmt.Sprintf("/v1/catalogs/%s/controls/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	PostV1EnrichWithResponse(ctx context.Context, body PostV1EnrichJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV1EnrichResponse, error)
-}
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-type PostV1EnrichResponse struct {
-	Body         []byte
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetV1CoverageRequest generates requests for GetV1Coverage
+func NewGetV1CoverageRequest(server string, params *GetV1CoverageParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/coverage")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostV1EnrichRequest calls the generic PostV1Enrich builder with application/json body
+func NewPostV1EnrichRequest(server string, body PostV1EnrichJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostV1EnrichRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostV1EnrichRequestWithBody generates requests for PostV1Enrich with any type of body
+func NewPostV1EnrichRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/enrich")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostV1EnrichBatchRequest calls the generic PostV1EnrichBatch builder with application/json body
+func NewPostV1EnrichBatchRequest(server string, body PostV1EnrichBatchJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostV1EnrichBatchRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostV1EnrichBatchRequestWithBody generates requests for PostV1EnrichBatch with any type of body
+func NewPostV1EnrichBatchRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/enrich/batch")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetV1FrameworksFrameworkRequirementsRequirementIdRequest generates requests for GetV1FrameworksFrameworkRequirementsRequirementId
+func NewGetV1FrameworksFrameworkRequirementsRequirementIdRequest(server string, framework string, requirementId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "framework", runtime.ParamLocationPath, framework)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "requirementId", runtime.ParamLocationPath, requirementId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/frameworks/%s/requirements/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetV1PluginsPluginIdProceduresRequest generates requests for GetV1PluginsPluginIdProcedures
+func NewGetV1PluginsPluginIdProceduresRequest(server string, pluginId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "pluginId", runtime.ParamLocationPath, pluginId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/plugins/%s/procedures", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetV1CatalogsWithResponse request
+	GetV1CatalogsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetV1CatalogsResponse, error)
+
+	// GetV1CatalogsCatalogIdControlsControlIdWithResponse request
+	GetV1CatalogsCatalogIdControlsControlIdWithResponse(ctx context.Context, catalogId string, controlId string, reqEditors ...RequestEditorFn) (*GetV1CatalogsCatalogIdControlsControlIdResponse, error)
+
+	// GetV1CoverageWithResponse request
+	GetV1CoverageWithResponse(ctx context.Context, params *GetV1CoverageParams, reqEditors ...RequestEditorFn) (*GetV1CoverageResponse, error)
+
+	// PostV1EnrichWithBodyWithResponse request with any body
+	PostV1EnrichWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV1EnrichResponse, error)
+
+	PostV1EnrichWithResponse(ctx context.Context, body PostV1EnrichJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV1EnrichResponse, error)
+
+	// PostV1EnrichBatchWithBodyWithResponse request with any body
+	PostV1EnrichBatchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV1EnrichBatchResponse, error)
+
+	PostV1EnrichBatchWithResponse(ctx context.Context, body PostV1EnrichBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV1EnrichBatchResponse, error)
+
+	// GetV1FrameworksFrameworkRequirementsRequirementIdWithResponse request
+	GetV1FrameworksFrameworkRequirementsRequirementIdWithResponse(ctx context.Context, framework string, requirementId string, reqEditors ...RequestEditorFn) (*GetV1FrameworksFrameworkRequirementsRequirementIdResponse, error)
+
+	// GetV1PluginsPluginIdProceduresWithResponse request
+	GetV1PluginsPluginIdProceduresWithResponse(ctx context.Context, pluginId string, reqEditors ...RequestEditorFn) (*GetV1PluginsPluginIdProceduresResponse, error)
+}
+
+type GetV1CatalogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CatalogList
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetV1CatalogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetV1CatalogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetV1CatalogsCatalogIdControlsControlIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CatalogControl
+	JSON404      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetV1CatalogsCatalogIdControlsControlIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetV1CatalogsCatalogIdControlsControlIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetV1CoverageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CoverageReport
+	JSON400      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetV1CoverageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetV1CoverageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostV1EnrichResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EnrichmentResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostV1EnrichResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostV1EnrichResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostV1EnrichBatchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BatchEnrichmentResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostV1EnrichBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostV1EnrichBatchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetV1FrameworksFrameworkRequirementsRequirementIdResponse struct {
+	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *EnrichmentResponse
+	JSON200      *RequirementCoverage
 	JSONDefault  *Error
 }
 
 // Status returns HTTPResponse.Status
-func (r PostV1EnrichResponse) Status() string {
+func (r GetV1FrameworksFrameworkRequirementsRequirementIdResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -365,13 +1142,65 @@ func (r PostV1EnrichResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostV1EnrichResponse) StatusCode() int {
+func (r GetV1FrameworksFrameworkRequirementsRequirementIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetV1PluginsPluginIdProceduresResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ProcedureList
+	JSON404      *Error
+	JSON501      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetV1PluginsPluginIdProceduresResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetV1PluginsPluginIdProceduresResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
+// GetV1CatalogsWithResponse request returning *GetV1CatalogsResponse
+func (c *ClientWithResponses) GetV1CatalogsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetV1CatalogsResponse, error) {
+	rsp, err := c.GetV1Catalogs(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetV1CatalogsResponse(rsp)
+}
+
+// GetV1CatalogsCatalogIdControlsControlIdWithResponse request returning *GetV1CatalogsCatalogIdControlsControlIdResponse
+func (c *ClientWithResponses) GetV1CatalogsCatalogIdControlsControlIdWithResponse(ctx context.Context, catalogId string, controlId string, reqEditors ...RequestEditorFn) (*GetV1CatalogsCatalogIdControlsControlIdResponse, error) {
+	rsp, err := c.GetV1CatalogsCatalogIdControlsControlId(ctx, catalogId, controlId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetV1CatalogsCatalogIdControlsControlIdResponse(rsp)
+}
+
+// GetV1CoverageWithResponse request returning *GetV1CoverageResponse
+func (c *ClientWithResponses) GetV1CoverageWithResponse(ctx context.Context, params *GetV1CoverageParams, reqEditors ...RequestEditorFn) (*GetV1CoverageResponse, error) {
+	rsp, err := c.GetV1Coverage(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetV1CoverageResponse(rsp)
+}
+
 // PostV1EnrichWithBodyWithResponse request with arbitrary body returning *PostV1EnrichResponse
 func (c *ClientWithResponses) PostV1EnrichWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV1EnrichResponse, error) {
 	rsp, err := c.PostV1EnrichWithBody(ctx, contentType, body, reqEditors...)
@@ -389,6 +1218,157 @@ func (c *ClientWithResponses) PostV1EnrichWithResponse(ctx context.Context, body
 	return ParsePostV1EnrichResponse(rsp)
 }
 
+// PostV1EnrichBatchWithBodyWithResponse request with arbitrary body returning *PostV1EnrichBatchResponse
+func (c *ClientWithResponses) PostV1EnrichBatchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV1EnrichBatchResponse, error) {
+	rsp, err := c.PostV1EnrichBatchWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostV1EnrichBatchResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostV1EnrichBatchWithResponse(ctx context.Context, body PostV1EnrichBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV1EnrichBatchResponse, error) {
+	rsp, err := c.PostV1EnrichBatch(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostV1EnrichBatchResponse(rsp)
+}
+
+// GetV1FrameworksFrameworkRequirementsRequirementIdWithResponse request returning *GetV1FrameworksFrameworkRequirementsRequirementIdResponse
+func (c *ClientWithResponses) GetV1FrameworksFrameworkRequirementsRequirementIdWithResponse(ctx context.Context, framework string, requirementId string, reqEditors ...RequestEditorFn) (*GetV1FrameworksFrameworkRequirementsRequirementIdResponse, error) {
+	rsp, err := c.GetV1FrameworksFrameworkRequirementsRequirementId(ctx, framework, requirementId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetV1FrameworksFrameworkRequirementsRequirementIdResponse(rsp)
+}
+
+// GetV1PluginsPluginIdProceduresWithResponse request returning *GetV1PluginsPluginIdProceduresResponse
+func (c *ClientWithResponses) GetV1PluginsPluginIdProceduresWithResponse(ctx context.Context, pluginId string, reqEditors ...RequestEditorFn) (*GetV1PluginsPluginIdProceduresResponse, error) {
+	rsp, err := c.GetV1PluginsPluginIdProcedures(ctx, pluginId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetV1PluginsPluginIdProceduresResponse(rsp)
+}
+
+// ParseGetV1CatalogsResponse parses an HTTP response from a GetV1CatalogsWithResponse call
+func ParseGetV1CatalogsResponse(rsp *http.Response) (*GetV1CatalogsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetV1CatalogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CatalogList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetV1CatalogsCatalogIdControlsControlIdResponse parses an HTTP response from a GetV1CatalogsCatalogIdControlsControlIdWithResponse call
+func ParseGetV1CatalogsCatalogIdControlsControlIdResponse(rsp *http.Response) (*GetV1CatalogsCatalogIdControlsControlIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetV1CatalogsCatalogIdControlsControlIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CatalogControl
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetV1CoverageResponse parses an HTTP response from a GetV1CoverageWithResponse call
+func ParseGetV1CoverageResponse(rsp *http.Response) (*GetV1CoverageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetV1CoverageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CoverageReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/markdown) unsupported
+
+	}
+
+	return response, nil
+}
+
 // ParsePostV1EnrichResponse parses an HTTP response from a PostV1EnrichWithResponse call
 func ParsePostV1EnrichResponse(rsp *http.Response) (*PostV1EnrichResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -421,3 +1401,116 @@ func ParsePostV1EnrichResponse(rsp *http.Response) (*PostV1EnrichResponse, error
 
 	return response, nil
 }
+
+// ParsePostV1EnrichBatchResponse parses an HTTP response from a PostV1EnrichBatchWithResponse call
+func ParsePostV1EnrichBatchResponse(rsp *http.Response) (*PostV1EnrichBatchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostV1EnrichBatchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BatchEnrichmentResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetV1FrameworksFrameworkRequirementsRequirementIdResponse parses an HTTP response from a GetV1FrameworksFrameworkRequirementsRequirementIdWithResponse call
+func ParseGetV1FrameworksFrameworkRequirementsRequirementIdResponse(rsp *http.Response) (*GetV1FrameworksFrameworkRequirementsRequirementIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetV1FrameworksFrameworkRequirementsRequirementIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RequirementCoverage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetV1PluginsPluginIdProceduresResponse parses an HTTP response from a GetV1PluginsPluginIdProceduresWithResponse call
+func ParseGetV1PluginsPluginIdProceduresResponse(rsp *http.Response) (*GetV1PluginsPluginIdProceduresResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetV1PluginsPluginIdProceduresResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ProcedureList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
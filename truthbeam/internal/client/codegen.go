@@ -1,3 +1,4 @@
 package client
 
 //go:generate go tool oapi-codegen --config=client-cfg.yaml ../../../api.yaml
+//go:generate go run github.com/bufbuild/buf/cmd/buf@v1.47.2 generate
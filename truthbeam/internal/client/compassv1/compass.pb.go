@@ -0,0 +1,1161 @@
+// Compass enrichment API over gRPC. Messages mirror the schemas in api.yaml;
+// see that file for the meaning of each field.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: compass/v1/compass.proto
+
+package compassv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EnrichmentMode selects how evidence whose policy rule maps to controls in
+// several catalogs is enriched.
+type EnrichmentMode int32
+
+const (
+	// Same as ENRICHMENT_MODE_FIRST.
+	EnrichmentMode_ENRICHMENT_MODE_UNSPECIFIED EnrichmentMode = 0
+	EnrichmentMode_ENRICHMENT_MODE_FIRST       EnrichmentMode = 1
+	EnrichmentMode_ENRICHMENT_MODE_ALL         EnrichmentMode = 2
+)
+
+// Enum value maps for EnrichmentMode.
+var (
+	EnrichmentMode_name = map[int32]string{
+		0: "ENRICHMENT_MODE_UNSPECIFIED",
+		1: "ENRICHMENT_MODE_FIRST",
+		2: "ENRICHMENT_MODE_ALL",
+	}
+	EnrichmentMode_value = map[string]int32{
+		"ENRICHMENT_MODE_UNSPECIFIED": 0,
+		"ENRICHMENT_MODE_FIRST":       1,
+		"ENRICHMENT_MODE_ALL":         2,
+	}
+)
+
+func (x EnrichmentMode) Enum() *EnrichmentMode {
+	p := new(EnrichmentMode)
+	*p = x
+	return p
+}
+
+func (x EnrichmentMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EnrichmentMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_compass_v1_compass_proto_enumTypes[0].Descriptor()
+}
+
+func (EnrichmentMode) Type() protoreflect.EnumType {
+	return &file_compass_v1_compass_proto_enumTypes[0]
+}
+
+func (x EnrichmentMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EnrichmentMode.Descriptor instead.
+func (EnrichmentMode) EnumDescriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{0}
+}
+
+type PolicyEvaluationStatus int32
+
+const (
+	PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_UNSPECIFIED    PolicyEvaluationStatus = 0
+	PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_NOT_RUN        PolicyEvaluationStatus = 1
+	PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_PASSED         PolicyEvaluationStatus = 2
+	PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_FAILED         PolicyEvaluationStatus = 3
+	PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_NEEDS_REVIEW   PolicyEvaluationStatus = 4
+	PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_NOT_APPLICABLE PolicyEvaluationStatus = 5
+	PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_UNKNOWN        PolicyEvaluationStatus = 6
+)
+
+// Enum value maps for PolicyEvaluationStatus.
+var (
+	PolicyEvaluationStatus_name = map[int32]string{
+		0: "POLICY_EVALUATION_STATUS_UNSPECIFIED",
+		1: "POLICY_EVALUATION_STATUS_NOT_RUN",
+		2: "POLICY_EVALUATION_STATUS_PASSED",
+		3: "POLICY_EVALUATION_STATUS_FAILED",
+		4: "POLICY_EVALUATION_STATUS_NEEDS_REVIEW",
+		5: "POLICY_EVALUATION_STATUS_NOT_APPLICABLE",
+		6: "POLICY_EVALUATION_STATUS_UNKNOWN",
+	}
+	PolicyEvaluationStatus_value = map[string]int32{
+		"POLICY_EVALUATION_STATUS_UNSPECIFIED":    0,
+		"POLICY_EVALUATION_STATUS_NOT_RUN":        1,
+		"POLICY_EVALUATION_STATUS_PASSED":         2,
+		"POLICY_EVALUATION_STATUS_FAILED":         3,
+		"POLICY_EVALUATION_STATUS_NEEDS_REVIEW":   4,
+		"POLICY_EVALUATION_STATUS_NOT_APPLICABLE": 5,
+		"POLICY_EVALUATION_STATUS_UNKNOWN":        6,
+	}
+)
+
+func (x PolicyEvaluationStatus) Enum() *PolicyEvaluationStatus {
+	p := new(PolicyEvaluationStatus)
+	*p = x
+	return p
+}
+
+func (x PolicyEvaluationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PolicyEvaluationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_compass_v1_compass_proto_enumTypes[1].Descriptor()
+}
+
+func (PolicyEvaluationStatus) Type() protoreflect.EnumType {
+	return &file_compass_v1_compass_proto_enumTypes[1]
+}
+
+func (x PolicyEvaluationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PolicyEvaluationStatus.Descriptor instead.
+func (PolicyEvaluationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{1}
+}
+
+type RiskLevel int32
+
+const (
+	RiskLevel_RISK_LEVEL_UNSPECIFIED   RiskLevel = 0
+	RiskLevel_RISK_LEVEL_INFORMATIONAL RiskLevel = 1
+	RiskLevel_RISK_LEVEL_LOW           RiskLevel = 2
+	RiskLevel_RISK_LEVEL_MEDIUM        RiskLevel = 3
+	RiskLevel_RISK_LEVEL_HIGH          RiskLevel = 4
+	RiskLevel_RISK_LEVEL_CRITICAL      RiskLevel = 5
+)
+
+// Enum value maps for RiskLevel.
+var (
+	RiskLevel_name = map[int32]string{
+		0: "RISK_LEVEL_UNSPECIFIED",
+		1: "RISK_LEVEL_INFORMATIONAL",
+		2: "RISK_LEVEL_LOW",
+		3: "RISK_LEVEL_MEDIUM",
+		4: "RISK_LEVEL_HIGH",
+		5: "RISK_LEVEL_CRITICAL",
+	}
+	RiskLevel_value = map[string]int32{
+		"RISK_LEVEL_UNSPECIFIED":   0,
+		"RISK_LEVEL_INFORMATIONAL": 1,
+		"RISK_LEVEL_LOW":           2,
+		"RISK_LEVEL_MEDIUM":        3,
+		"RISK_LEVEL_HIGH":          4,
+		"RISK_LEVEL_CRITICAL":      5,
+	}
+)
+
+func (x RiskLevel) Enum() *RiskLevel {
+	p := new(RiskLevel)
+	*p = x
+	return p
+}
+
+func (x RiskLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RiskLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_compass_v1_compass_proto_enumTypes[2].Descriptor()
+}
+
+func (RiskLevel) Type() protoreflect.EnumType {
+	return &file_compass_v1_compass_proto_enumTypes[2]
+}
+
+func (x RiskLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RiskLevel.Descriptor instead.
+func (RiskLevel) EnumDescriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{2}
+}
+
+type ComplianceStatus int32
+
+const (
+	ComplianceStatus_COMPLIANCE_STATUS_UNSPECIFIED    ComplianceStatus = 0
+	ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT      ComplianceStatus = 1
+	ComplianceStatus_COMPLIANCE_STATUS_NON_COMPLIANT  ComplianceStatus = 2
+	ComplianceStatus_COMPLIANCE_STATUS_EXEMPT         ComplianceStatus = 3
+	ComplianceStatus_COMPLIANCE_STATUS_NOT_APPLICABLE ComplianceStatus = 4
+	ComplianceStatus_COMPLIANCE_STATUS_UNKNOWN        ComplianceStatus = 5
+	ComplianceStatus_COMPLIANCE_STATUS_NEEDS_REVIEW   ComplianceStatus = 6
+)
+
+// Enum value maps for ComplianceStatus.
+var (
+	ComplianceStatus_name = map[int32]string{
+		0: "COMPLIANCE_STATUS_UNSPECIFIED",
+		1: "COMPLIANCE_STATUS_COMPLIANT",
+		2: "COMPLIANCE_STATUS_NON_COMPLIANT",
+		3: "COMPLIANCE_STATUS_EXEMPT",
+		4: "COMPLIANCE_STATUS_NOT_APPLICABLE",
+		5: "COMPLIANCE_STATUS_UNKNOWN",
+		6: "COMPLIANCE_STATUS_NEEDS_REVIEW",
+	}
+	ComplianceStatus_value = map[string]int32{
+		"COMPLIANCE_STATUS_UNSPECIFIED":    0,
+		"COMPLIANCE_STATUS_COMPLIANT":      1,
+		"COMPLIANCE_STATUS_NON_COMPLIANT":  2,
+		"COMPLIANCE_STATUS_EXEMPT":         3,
+		"COMPLIANCE_STATUS_NOT_APPLICABLE": 4,
+		"COMPLIANCE_STATUS_UNKNOWN":        5,
+		"COMPLIANCE_STATUS_NEEDS_REVIEW":   6,
+	}
+)
+
+func (x ComplianceStatus) Enum() *ComplianceStatus {
+	p := new(ComplianceStatus)
+	*p = x
+	return p
+}
+
+func (x ComplianceStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ComplianceStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_compass_v1_compass_proto_enumTypes[3].Descriptor()
+}
+
+func (ComplianceStatus) Type() protoreflect.EnumType {
+	return &file_compass_v1_compass_proto_enumTypes[3]
+}
+
+func (x ComplianceStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ComplianceStatus.Descriptor instead.
+func (ComplianceStatus) EnumDescriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{3}
+}
+
+type EnrichmentStatus int32
+
+const (
+	EnrichmentStatus_ENRICHMENT_STATUS_UNSPECIFIED EnrichmentStatus = 0
+	EnrichmentStatus_ENRICHMENT_STATUS_SUCCESS     EnrichmentStatus = 1
+	EnrichmentStatus_ENRICHMENT_STATUS_UNMAPPED    EnrichmentStatus = 2
+	EnrichmentStatus_ENRICHMENT_STATUS_PARTIAL     EnrichmentStatus = 3
+	EnrichmentStatus_ENRICHMENT_STATUS_UNKNOWN     EnrichmentStatus = 4
+	EnrichmentStatus_ENRICHMENT_STATUS_SKIPPED     EnrichmentStatus = 5
+)
+
+// Enum value maps for EnrichmentStatus.
+var (
+	EnrichmentStatus_name = map[int32]string{
+		0: "ENRICHMENT_STATUS_UNSPECIFIED",
+		1: "ENRICHMENT_STATUS_SUCCESS",
+		2: "ENRICHMENT_STATUS_UNMAPPED",
+		3: "ENRICHMENT_STATUS_PARTIAL",
+		4: "ENRICHMENT_STATUS_UNKNOWN",
+		5: "ENRICHMENT_STATUS_SKIPPED",
+	}
+	EnrichmentStatus_value = map[string]int32{
+		"ENRICHMENT_STATUS_UNSPECIFIED": 0,
+		"ENRICHMENT_STATUS_SUCCESS":     1,
+		"ENRICHMENT_STATUS_UNMAPPED":    2,
+		"ENRICHMENT_STATUS_PARTIAL":     3,
+		"ENRICHMENT_STATUS_UNKNOWN":     4,
+		"ENRICHMENT_STATUS_SKIPPED":     5,
+	}
+)
+
+func (x EnrichmentStatus) Enum() *EnrichmentStatus {
+	p := new(EnrichmentStatus)
+	*p = x
+	return p
+}
+
+func (x EnrichmentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EnrichmentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_compass_v1_compass_proto_enumTypes[4].Descriptor()
+}
+
+func (EnrichmentStatus) Type() protoreflect.EnumType {
+	return &file_compass_v1_compass_proto_enumTypes[4]
+}
+
+func (x EnrichmentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EnrichmentStatus.Descriptor instead.
+func (EnrichmentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{4}
+}
+
+type EnrichmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Evidence      *Evidence              `protobuf:"bytes,1,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	Mode          EnrichmentMode         `protobuf:"varint,2,opt,name=mode,proto3,enum=compass.v1.EnrichmentMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrichmentRequest) Reset() {
+	*x = EnrichmentRequest{}
+	mi := &file_compass_v1_compass_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrichmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrichmentRequest) ProtoMessage() {}
+
+func (x *EnrichmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_v1_compass_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrichmentRequest.ProtoReflect.Descriptor instead.
+func (*EnrichmentRequest) Descriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EnrichmentRequest) GetEvidence() *Evidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *EnrichmentRequest) GetMode() EnrichmentMode {
+	if x != nil {
+		return x.Mode
+	}
+	return EnrichmentMode_ENRICHMENT_MODE_UNSPECIFIED
+}
+
+type Evidence struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp               *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	PolicyEngineName        string                 `protobuf:"bytes,2,opt,name=policy_engine_name,json=policyEngineName,proto3" json:"policy_engine_name,omitempty"`
+	PolicyRuleId            string                 `protobuf:"bytes,3,opt,name=policy_rule_id,json=policyRuleId,proto3" json:"policy_rule_id,omitempty"`
+	PolicyEvaluationStatus  PolicyEvaluationStatus `protobuf:"varint,4,opt,name=policy_evaluation_status,json=policyEvaluationStatus,proto3,enum=compass.v1.PolicyEvaluationStatus" json:"policy_evaluation_status,omitempty"`
+	PolicyTargetEnvironment *string                `protobuf:"bytes,5,opt,name=policy_target_environment,json=policyTargetEnvironment,proto3,oneof" json:"policy_target_environment,omitempty"`
+	PolicyTargetId          *string                `protobuf:"bytes,6,opt,name=policy_target_id,json=policyTargetId,proto3,oneof" json:"policy_target_id,omitempty"`
+	RawData                 *structpb.Struct       `protobuf:"bytes,7,opt,name=raw_data,json=rawData,proto3" json:"raw_data,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *Evidence) Reset() {
+	*x = Evidence{}
+	mi := &file_compass_v1_compass_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Evidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Evidence) ProtoMessage() {}
+
+func (x *Evidence) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_v1_compass_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Evidence.ProtoReflect.Descriptor instead.
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Evidence) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Evidence) GetPolicyEngineName() string {
+	if x != nil {
+		return x.PolicyEngineName
+	}
+	return ""
+}
+
+func (x *Evidence) GetPolicyRuleId() string {
+	if x != nil {
+		return x.PolicyRuleId
+	}
+	return ""
+}
+
+func (x *Evidence) GetPolicyEvaluationStatus() PolicyEvaluationStatus {
+	if x != nil {
+		return x.PolicyEvaluationStatus
+	}
+	return PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_UNSPECIFIED
+}
+
+func (x *Evidence) GetPolicyTargetEnvironment() string {
+	if x != nil && x.PolicyTargetEnvironment != nil {
+		return *x.PolicyTargetEnvironment
+	}
+	return ""
+}
+
+func (x *Evidence) GetPolicyTargetId() string {
+	if x != nil && x.PolicyTargetId != nil {
+		return *x.PolicyTargetId
+	}
+	return ""
+}
+
+func (x *Evidence) GetRawData() *structpb.Struct {
+	if x != nil {
+		return x.RawData
+	}
+	return nil
+}
+
+type EnrichmentResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Compliance *Compliance            `protobuf:"bytes,1,opt,name=compliance,proto3" json:"compliance,omitempty"`
+	// Every matched control when the request mode is ENRICHMENT_MODE_ALL.
+	Matches       []*Compliance `protobuf:"bytes,2,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrichmentResponse) Reset() {
+	*x = EnrichmentResponse{}
+	mi := &file_compass_v1_compass_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrichmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrichmentResponse) ProtoMessage() {}
+
+func (x *EnrichmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_v1_compass_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrichmentResponse.ProtoReflect.Descriptor instead.
+func (*EnrichmentResponse) Descriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EnrichmentResponse) GetCompliance() *Compliance {
+	if x != nil {
+		return x.Compliance
+	}
+	return nil
+}
+
+func (x *EnrichmentResponse) GetMatches() []*Compliance {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+// EnrichmentResult is the outcome for one request on a stream.
+type EnrichmentResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*EnrichmentResult_Response
+	//	*EnrichmentResult_Error
+	Result        isEnrichmentResult_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrichmentResult) Reset() {
+	*x = EnrichmentResult{}
+	mi := &file_compass_v1_compass_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrichmentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrichmentResult) ProtoMessage() {}
+
+func (x *EnrichmentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_v1_compass_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrichmentResult.ProtoReflect.Descriptor instead.
+func (*EnrichmentResult) Descriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EnrichmentResult) GetResult() isEnrichmentResult_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *EnrichmentResult) GetResponse() *EnrichmentResponse {
+	if x != nil {
+		if x, ok := x.Result.(*EnrichmentResult_Response); ok {
+			return x.Response
+		}
+	}
+	return nil
+}
+
+func (x *EnrichmentResult) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*EnrichmentResult_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isEnrichmentResult_Result interface {
+	isEnrichmentResult_Result()
+}
+
+type EnrichmentResult_Response struct {
+	Response *EnrichmentResponse `protobuf:"bytes,1,opt,name=response,proto3,oneof"`
+}
+
+type EnrichmentResult_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*EnrichmentResult_Response) isEnrichmentResult_Result() {}
+
+func (*EnrichmentResult_Error) isEnrichmentResult_Result() {}
+
+type Compliance struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Control          *ComplianceControl     `protobuf:"bytes,1,opt,name=control,proto3" json:"control,omitempty"`
+	Frameworks       *ComplianceFrameworks  `protobuf:"bytes,2,opt,name=frameworks,proto3" json:"frameworks,omitempty"`
+	Risk             *ComplianceRisk        `protobuf:"bytes,3,opt,name=risk,proto3" json:"risk,omitempty"`
+	Exception        *ComplianceException   `protobuf:"bytes,4,opt,name=exception,proto3" json:"exception,omitempty"`
+	Status           ComplianceStatus       `protobuf:"varint,5,opt,name=status,proto3,enum=compass.v1.ComplianceStatus" json:"status,omitempty"`
+	EnrichmentStatus EnrichmentStatus       `protobuf:"varint,6,opt,name=enrichment_status,json=enrichmentStatus,proto3,enum=compass.v1.EnrichmentStatus" json:"enrichment_status,omitempty"`
+	TargetId         *string                `protobuf:"bytes,7,opt,name=target_id,json=targetId,proto3,oneof" json:"target_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Compliance) Reset() {
+	*x = Compliance{}
+	mi := &file_compass_v1_compass_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Compliance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Compliance) ProtoMessage() {}
+
+func (x *Compliance) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_v1_compass_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Compliance.ProtoReflect.Descriptor instead.
+func (*Compliance) Descriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Compliance) GetControl() *ComplianceControl {
+	if x != nil {
+		return x.Control
+	}
+	return nil
+}
+
+func (x *Compliance) GetFrameworks() *ComplianceFrameworks {
+	if x != nil {
+		return x.Frameworks
+	}
+	return nil
+}
+
+func (x *Compliance) GetRisk() *ComplianceRisk {
+	if x != nil {
+		return x.Risk
+	}
+	return nil
+}
+
+func (x *Compliance) GetException() *ComplianceException {
+	if x != nil {
+		return x.Exception
+	}
+	return nil
+}
+
+func (x *Compliance) GetStatus() ComplianceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ComplianceStatus_COMPLIANCE_STATUS_UNSPECIFIED
+}
+
+func (x *Compliance) GetEnrichmentStatus() EnrichmentStatus {
+	if x != nil {
+		return x.EnrichmentStatus
+	}
+	return EnrichmentStatus_ENRICHMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Compliance) GetTargetId() string {
+	if x != nil && x.TargetId != nil {
+		return *x.TargetId
+	}
+	return ""
+}
+
+type ComplianceControl struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Id                     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category               string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	CatalogId              string                 `protobuf:"bytes,3,opt,name=catalog_id,json=catalogId,proto3" json:"catalog_id,omitempty"`
+	Applicability          []string               `protobuf:"bytes,4,rep,name=applicability,proto3" json:"applicability,omitempty"`
+	RemediationDescription *string                `protobuf:"bytes,5,opt,name=remediation_description,json=remediationDescription,proto3,oneof" json:"remediation_description,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ComplianceControl) Reset() {
+	*x = ComplianceControl{}
+	mi := &file_compass_v1_compass_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComplianceControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComplianceControl) ProtoMessage() {}
+
+func (x *ComplianceControl) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_v1_compass_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComplianceControl.ProtoReflect.Descriptor instead.
+func (*ComplianceControl) Descriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ComplianceControl) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ComplianceControl) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ComplianceControl) GetCatalogId() string {
+	if x != nil {
+		return x.CatalogId
+	}
+	return ""
+}
+
+func (x *ComplianceControl) GetApplicability() []string {
+	if x != nil {
+		return x.Applicability
+	}
+	return nil
+}
+
+func (x *ComplianceControl) GetRemediationDescription() string {
+	if x != nil && x.RemediationDescription != nil {
+		return *x.RemediationDescription
+	}
+	return ""
+}
+
+type ComplianceFrameworks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Frameworks    []string               `protobuf:"bytes,1,rep,name=frameworks,proto3" json:"frameworks,omitempty"`
+	Requirements  []string               `protobuf:"bytes,2,rep,name=requirements,proto3" json:"requirements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComplianceFrameworks) Reset() {
+	*x = ComplianceFrameworks{}
+	mi := &file_compass_v1_compass_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComplianceFrameworks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComplianceFrameworks) ProtoMessage() {}
+
+func (x *ComplianceFrameworks) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_v1_compass_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComplianceFrameworks.ProtoReflect.Descriptor instead.
+func (*ComplianceFrameworks) Descriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ComplianceFrameworks) GetFrameworks() []string {
+	if x != nil {
+		return x.Frameworks
+	}
+	return nil
+}
+
+func (x *ComplianceFrameworks) GetRequirements() []string {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+type ComplianceRisk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         RiskLevel              `protobuf:"varint,1,opt,name=level,proto3,enum=compass.v1.RiskLevel" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComplianceRisk) Reset() {
+	*x = ComplianceRisk{}
+	mi := &file_compass_v1_compass_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComplianceRisk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComplianceRisk) ProtoMessage() {}
+
+func (x *ComplianceRisk) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_v1_compass_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComplianceRisk.ProtoReflect.Descriptor instead.
+func (*ComplianceRisk) Descriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ComplianceRisk) GetLevel() RiskLevel {
+	if x != nil {
+		return x.Level
+	}
+	return RiskLevel_RISK_LEVEL_UNSPECIFIED
+}
+
+type ComplianceException struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComplianceException) Reset() {
+	*x = ComplianceException{}
+	mi := &file_compass_v1_compass_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComplianceException) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComplianceException) ProtoMessage() {}
+
+func (x *ComplianceException) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_v1_compass_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComplianceException.ProtoReflect.Descriptor instead.
+func (*ComplianceException) Descriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ComplianceException) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ComplianceException) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_compass_v1_compass_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_compass_v1_compass_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_compass_v1_compass_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_compass_v1_compass_proto protoreflect.FileDescriptor
+
+const file_compass_v1_compass_proto_rawDesc = "" +
+	"\n" +
+	"\x18compass/v1/compass.proto\x12\n" +
+	"compass.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"u\n" +
+	"\x11EnrichmentRequest\x120\n" +
+	"\bevidence\x18\x01 \x01(\v2\x14.compass.v1.EvidenceR\bevidence\x12.\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x1a.compass.v1.EnrichmentModeR\x04mode\"\xcd\x03\n" +
+	"\bEvidence\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12,\n" +
+	"\x12policy_engine_name\x18\x02 \x01(\tR\x10policyEngineName\x12$\n" +
+	"\x0epolicy_rule_id\x18\x03 \x01(\tR\fpolicyRuleId\x12\\\n" +
+	"\x18policy_evaluation_status\x18\x04 \x01(\x0e2\".compass.v1.PolicyEvaluationStatusR\x16policyEvaluationStatus\x12?\n" +
+	"\x19policy_target_environment\x18\x05 \x01(\tH\x00R\x17policyTargetEnvironment\x88\x01\x01\x12-\n" +
+	"\x10policy_target_id\x18\x06 \x01(\tH\x01R\x0epolicyTargetId\x88\x01\x01\x122\n" +
+	"\braw_data\x18\a \x01(\v2\x17.google.protobuf.StructR\arawDataB\x1c\n" +
+	"\x1a_policy_target_environmentB\x13\n" +
+	"\x11_policy_target_id\"~\n" +
+	"\x12EnrichmentResponse\x126\n" +
+	"\n" +
+	"compliance\x18\x01 \x01(\v2\x16.compass.v1.ComplianceR\n" +
+	"compliance\x120\n" +
+	"\amatches\x18\x02 \x03(\v2\x16.compass.v1.ComplianceR\amatches\"\x85\x01\n" +
+	"\x10EnrichmentResult\x12<\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1e.compass.v1.EnrichmentResponseH\x00R\bresponse\x12)\n" +
+	"\x05error\x18\x02 \x01(\v2\x11.compass.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"\xa7\x03\n" +
+	"\n" +
+	"Compliance\x127\n" +
+	"\acontrol\x18\x01 \x01(\v2\x1d.compass.v1.ComplianceControlR\acontrol\x12@\n" +
+	"\n" +
+	"frameworks\x18\x02 \x01(\v2 .compass.v1.ComplianceFrameworksR\n" +
+	"frameworks\x12.\n" +
+	"\x04risk\x18\x03 \x01(\v2\x1a.compass.v1.ComplianceRiskR\x04risk\x12=\n" +
+	"\texception\x18\x04 \x01(\v2\x1f.compass.v1.ComplianceExceptionR\texception\x124\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1c.compass.v1.ComplianceStatusR\x06status\x12I\n" +
+	"\x11enrichment_status\x18\x06 \x01(\x0e2\x1c.compass.v1.EnrichmentStatusR\x10enrichmentStatus\x12 \n" +
+	"\ttarget_id\x18\a \x01(\tH\x00R\btargetId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_target_id\"\xde\x01\n" +
+	"\x11ComplianceControl\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x1d\n" +
+	"\n" +
+	"catalog_id\x18\x03 \x01(\tR\tcatalogId\x12$\n" +
+	"\rapplicability\x18\x04 \x03(\tR\rapplicability\x12<\n" +
+	"\x17remediation_description\x18\x05 \x01(\tH\x00R\x16remediationDescription\x88\x01\x01B\x1a\n" +
+	"\x18_remediation_description\"Z\n" +
+	"\x14ComplianceFrameworks\x12\x1e\n" +
+	"\n" +
+	"frameworks\x18\x01 \x03(\tR\n" +
+	"frameworks\x12\"\n" +
+	"\frequirements\x18\x02 \x03(\tR\frequirements\"=\n" +
+	"\x0eComplianceRisk\x12+\n" +
+	"\x05level\x18\x01 \x01(\x0e2\x15.compass.v1.RiskLevelR\x05level\"=\n" +
+	"\x13ComplianceException\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"5\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage*e\n" +
+	"\x0eEnrichmentMode\x12\x1f\n" +
+	"\x1bENRICHMENT_MODE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ENRICHMENT_MODE_FIRST\x10\x01\x12\x17\n" +
+	"\x13ENRICHMENT_MODE_ALL\x10\x02*\xb0\x02\n" +
+	"\x16PolicyEvaluationStatus\x12(\n" +
+	"$POLICY_EVALUATION_STATUS_UNSPECIFIED\x10\x00\x12$\n" +
+	" POLICY_EVALUATION_STATUS_NOT_RUN\x10\x01\x12#\n" +
+	"\x1fPOLICY_EVALUATION_STATUS_PASSED\x10\x02\x12#\n" +
+	"\x1fPOLICY_EVALUATION_STATUS_FAILED\x10\x03\x12)\n" +
+	"%POLICY_EVALUATION_STATUS_NEEDS_REVIEW\x10\x04\x12+\n" +
+	"'POLICY_EVALUATION_STATUS_NOT_APPLICABLE\x10\x05\x12$\n" +
+	" POLICY_EVALUATION_STATUS_UNKNOWN\x10\x06*\x9e\x01\n" +
+	"\tRiskLevel\x12\x1a\n" +
+	"\x16RISK_LEVEL_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18RISK_LEVEL_INFORMATIONAL\x10\x01\x12\x12\n" +
+	"\x0eRISK_LEVEL_LOW\x10\x02\x12\x15\n" +
+	"\x11RISK_LEVEL_MEDIUM\x10\x03\x12\x13\n" +
+	"\x0fRISK_LEVEL_HIGH\x10\x04\x12\x17\n" +
+	"\x13RISK_LEVEL_CRITICAL\x10\x05*\x82\x02\n" +
+	"\x10ComplianceStatus\x12!\n" +
+	"\x1dCOMPLIANCE_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bCOMPLIANCE_STATUS_COMPLIANT\x10\x01\x12#\n" +
+	"\x1fCOMPLIANCE_STATUS_NON_COMPLIANT\x10\x02\x12\x1c\n" +
+	"\x18COMPLIANCE_STATUS_EXEMPT\x10\x03\x12$\n" +
+	" COMPLIANCE_STATUS_NOT_APPLICABLE\x10\x04\x12\x1d\n" +
+	"\x19COMPLIANCE_STATUS_UNKNOWN\x10\x05\x12\"\n" +
+	"\x1eCOMPLIANCE_STATUS_NEEDS_REVIEW\x10\x06*\xd1\x01\n" +
+	"\x10EnrichmentStatus\x12!\n" +
+	"\x1dENRICHMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19ENRICHMENT_STATUS_SUCCESS\x10\x01\x12\x1e\n" +
+	"\x1aENRICHMENT_STATUS_UNMAPPED\x10\x02\x12\x1d\n" +
+	"\x19ENRICHMENT_STATUS_PARTIAL\x10\x03\x12\x1d\n" +
+	"\x19ENRICHMENT_STATUS_UNKNOWN\x10\x04\x12\x1d\n" +
+	"\x19ENRICHMENT_STATUS_SKIPPED\x10\x052\xad\x01\n" +
+	"\x11EnrichmentService\x12G\n" +
+	"\x06Enrich\x12\x1d.compass.v1.EnrichmentRequest\x1a\x1e.compass.v1.EnrichmentResponse\x12O\n" +
+	"\fEnrichStream\x12\x1d.compass.v1.EnrichmentRequest\x1a\x1c.compass.v1.EnrichmentResult(\x010\x01B\xaf\x01\n" +
+	"\x0ecom.compass.v1B\fCompassProtoP\x01ZFgithub.com/complytime/complybeacon/truthbeam/internal/client/compassv1\xa2\x02\x03CXX\xaa\x02\n" +
+	"Compass.V1\xca\x02\n" +
+	"Compass\\V1\xe2\x02\x16Compass\\V1\\GPBMetadata\xea\x02\vCompass::V1b\x06proto3"
+
+var (
+	file_compass_v1_compass_proto_rawDescOnce sync.Once
+	file_compass_v1_compass_proto_rawDescData []byte
+)
+
+func file_compass_v1_compass_proto_rawDescGZIP() []byte {
+	file_compass_v1_compass_proto_rawDescOnce.Do(func() {
+		file_compass_v1_compass_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_compass_v1_compass_proto_rawDesc), len(file_compass_v1_compass_proto_rawDesc)))
+	})
+	return file_compass_v1_compass_proto_rawDescData
+}
+
+var file_compass_v1_compass_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_compass_v1_compass_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_compass_v1_compass_proto_goTypes = []any{
+	(EnrichmentMode)(0),           // 0: compass.v1.EnrichmentMode
+	(PolicyEvaluationStatus)(0),   // 1: compass.v1.PolicyEvaluationStatus
+	(RiskLevel)(0),                // 2: compass.v1.RiskLevel
+	(ComplianceStatus)(0),         // 3: compass.v1.ComplianceStatus
+	(EnrichmentStatus)(0),         // 4: compass.v1.EnrichmentStatus
+	(*EnrichmentRequest)(nil),     // 5: compass.v1.EnrichmentRequest
+	(*Evidence)(nil),              // 6: compass.v1.Evidence
+	(*EnrichmentResponse)(nil),    // 7: compass.v1.EnrichmentResponse
+	(*EnrichmentResult)(nil),      // 8: compass.v1.EnrichmentResult
+	(*Compliance)(nil),            // 9: compass.v1.Compliance
+	(*ComplianceControl)(nil),     // 10: compass.v1.ComplianceControl
+	(*ComplianceFrameworks)(nil),  // 11: compass.v1.ComplianceFrameworks
+	(*ComplianceRisk)(nil),        // 12: compass.v1.ComplianceRisk
+	(*ComplianceException)(nil),   // 13: compass.v1.ComplianceException
+	(*Error)(nil),                 // 14: compass.v1.Error
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 16: google.protobuf.Struct
+}
+var file_compass_v1_compass_proto_depIdxs = []int32{
+	6,  // 0: compass.v1.EnrichmentRequest.evidence:type_name -> compass.v1.Evidence
+	0,  // 1: compass.v1.EnrichmentRequest.mode:type_name -> compass.v1.EnrichmentMode
+	15, // 2: compass.v1.Evidence.timestamp:type_name -> google.protobuf.Timestamp
+	1,  // 3: compass.v1.Evidence.policy_evaluation_status:type_name -> compass.v1.PolicyEvaluationStatus
+	16, // 4: compass.v1.Evidence.raw_data:type_name -> google.protobuf.Struct
+	9,  // 5: compass.v1.EnrichmentResponse.compliance:type_name -> compass.v1.Compliance
+	9,  // 6: compass.v1.EnrichmentResponse.matches:type_name -> compass.v1.Compliance
+	7,  // 7: compass.v1.EnrichmentResult.response:type_name -> compass.v1.EnrichmentResponse
+	14, // 8: compass.v1.EnrichmentResult.error:type_name -> compass.v1.Error
+	10, // 9: compass.v1.Compliance.control:type_name -> compass.v1.ComplianceControl
+	11, // 10: compass.v1.Compliance.frameworks:type_name -> compass.v1.ComplianceFrameworks
+	12, // 11: compass.v1.Compliance.risk:type_name -> compass.v1.ComplianceRisk
+	13, // 12: compass.v1.Compliance.exception:type_name -> compass.v1.ComplianceException
+	3,  // 13: compass.v1.Compliance.status:type_name -> compass.v1.ComplianceStatus
+	4,  // 14: compass.v1.Compliance.enrichment_status:type_name -> compass.v1.EnrichmentStatus
+	2,  // 15: compass.v1.ComplianceRisk.level:type_name -> compass.v1.RiskLevel
+	5,  // 16: compass.v1.EnrichmentService.Enrich:input_type -> compass.v1.EnrichmentRequest
+	5,  // 17: compass.v1.EnrichmentService.EnrichStream:input_type -> compass.v1.EnrichmentRequest
+	7,  // 18: compass.v1.EnrichmentService.Enrich:output_type -> compass.v1.EnrichmentResponse
+	8,  // 19: compass.v1.EnrichmentService.EnrichStream:output_type -> compass.v1.EnrichmentResult
+	18, // [18:20] is the sub-list for method output_type
+	16, // [16:18] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_compass_v1_compass_proto_init() }
+func file_compass_v1_compass_proto_init() {
+	if File_compass_v1_compass_proto != nil {
+		return
+	}
+	file_compass_v1_compass_proto_msgTypes[1].OneofWrappers = []any{}
+	file_compass_v1_compass_proto_msgTypes[3].OneofWrappers = []any{
+		(*EnrichmentResult_Response)(nil),
+		(*EnrichmentResult_Error)(nil),
+	}
+	file_compass_v1_compass_proto_msgTypes[4].OneofWrappers = []any{}
+	file_compass_v1_compass_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_compass_v1_compass_proto_rawDesc), len(file_compass_v1_compass_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_compass_v1_compass_proto_goTypes,
+		DependencyIndexes: file_compass_v1_compass_proto_depIdxs,
+		EnumInfos:         file_compass_v1_compass_proto_enumTypes,
+		MessageInfos:      file_compass_v1_compass_proto_msgTypes,
+	}.Build()
+	File_compass_v1_compass_proto = out.File
+	file_compass_v1_compass_proto_goTypes = nil
+	file_compass_v1_compass_proto_depIdxs = nil
+}
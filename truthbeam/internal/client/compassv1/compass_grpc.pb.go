@@ -0,0 +1,173 @@
+// Compass enrichment API over gRPC. Messages mirror the schemas in api.yaml;
+// see that file for the meaning of each field.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: compass/v1/compass.proto
+
+package compassv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EnrichmentService_Enrich_FullMethodName       = "/compass.v1.EnrichmentService/Enrich"
+	EnrichmentService_EnrichStream_FullMethodName = "/compass.v1.EnrichmentService/EnrichStream"
+)
+
+// EnrichmentServiceClient is the client API for EnrichmentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// EnrichmentService enriches policy evaluation evidence with compliance
+// control data.
+type EnrichmentServiceClient interface {
+	// Enrich enriches a single evidence record. Invalid evidence fails with
+	// INVALID_ARGUMENT.
+	Enrich(ctx context.Context, in *EnrichmentRequest, opts ...grpc.CallOption) (*EnrichmentResponse, error)
+	// EnrichStream enriches a stream of evidence records. One result is sent
+	// for each request, in request order. Invalid evidence produces a result
+	// carrying an error rather than ending the stream.
+	EnrichStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[EnrichmentRequest, EnrichmentResult], error)
+}
+
+type enrichmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEnrichmentServiceClient(cc grpc.ClientConnInterface) EnrichmentServiceClient {
+	return &enrichmentServiceClient{cc}
+}
+
+func (c *enrichmentServiceClient) Enrich(ctx context.Context, in *EnrichmentRequest, opts ...grpc.CallOption) (*EnrichmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrichmentResponse)
+	err := c.cc.Invoke(ctx, EnrichmentService_Enrich_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enrichmentServiceClient) EnrichStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[EnrichmentRequest, EnrichmentResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EnrichmentService_ServiceDesc.Streams[0], EnrichmentService_EnrichStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EnrichmentRequest, EnrichmentResult]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EnrichmentService_EnrichStreamClient = grpc.BidiStreamingClient[EnrichmentRequest, EnrichmentResult]
+
+// EnrichmentServiceServer is the server API for EnrichmentService service.
+// All implementations must embed UnimplementedEnrichmentServiceServer
+// for forward compatibility.
+//
+// EnrichmentService enriches policy evaluation evidence with compliance
+// control data.
+type EnrichmentServiceServer interface {
+	// Enrich enriches a single evidence record. Invalid evidence fails with
+	// INVALID_ARGUMENT.
+	Enrich(context.Context, *EnrichmentRequest) (*EnrichmentResponse, error)
+	// EnrichStream enriches a stream of evidence records. One result is sent
+	// for each request, in request order. Invalid evidence produces a result
+	// carrying an error rather than ending the stream.
+	EnrichStream(grpc.BidiStreamingServer[EnrichmentRequest, EnrichmentResult]) error
+	mustEmbedUnimplementedEnrichmentServiceServer()
+}
+
+// UnimplementedEnrichmentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEnrichmentServiceServer struct{}
+
+func (UnimplementedEnrichmentServiceServer) Enrich(context.Context, *EnrichmentRequest) (*EnrichmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Enrich not implemented")
+}
+func (UnimplementedEnrichmentServiceServer) EnrichStream(grpc.BidiStreamingServer[EnrichmentRequest, EnrichmentResult]) error {
+	return status.Errorf(codes.Unimplemented, "method EnrichStream not implemented")
+}
+func (UnimplementedEnrichmentServiceServer) mustEmbedUnimplementedEnrichmentServiceServer() {}
+func (UnimplementedEnrichmentServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeEnrichmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EnrichmentServiceServer will
+// result in compilation errors.
+type UnsafeEnrichmentServiceServer interface {
+	mustEmbedUnimplementedEnrichmentServiceServer()
+}
+
+func RegisterEnrichmentServiceServer(s grpc.ServiceRegistrar, srv EnrichmentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedEnrichmentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EnrichmentService_ServiceDesc, srv)
+}
+
+func _EnrichmentService_Enrich_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrichmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnrichmentServiceServer).Enrich(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnrichmentService_Enrich_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnrichmentServiceServer).Enrich(ctx, req.(*EnrichmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnrichmentService_EnrichStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EnrichmentServiceServer).EnrichStream(&grpc.GenericServerStream[EnrichmentRequest, EnrichmentResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EnrichmentService_EnrichStreamServer = grpc.BidiStreamingServer[EnrichmentRequest, EnrichmentResult]
+
+// EnrichmentService_ServiceDesc is the grpc.ServiceDesc for EnrichmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EnrichmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "compass.v1.EnrichmentService",
+	HandlerType: (*EnrichmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Enrich",
+			Handler:    _EnrichmentService_Enrich_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "EnrichStream",
+			Handler:       _EnrichmentService_EnrichStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "compass/v1/compass.proto",
+}
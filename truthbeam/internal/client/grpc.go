@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/complytime/complybeacon/truthbeam/internal/client/compassv1"
+)
+
+// GRPCEnricher calls the Compass gRPC API.
+type GRPCEnricher struct {
+	client compassv1.EnrichmentServiceClient
+}
+
+var _ Enricher = (*GRPCEnricher)(nil)
+
+// NewGRPCEnricher returns an Enricher that sends requests over conn.
+func NewGRPCEnricher(conn grpc.ClientConnInterface) *GRPCEnricher {
+	return &GRPCEnricher{client: compassv1.NewEnrichmentServiceClient(conn)}
+}
+
+// Enrich sends req to the Enrich RPC.
+func (e *GRPCEnricher) Enrich(ctx context.Context, req EnrichmentRequest) (*EnrichmentResponse, error) {
+	protoReq, err := toProtoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	protoRes, err := e.client.Enrich(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoResponse(protoRes), nil
+}
+
+var policyEvaluationStatuses = map[EvidencePolicyEvaluationStatus]compassv1.PolicyEvaluationStatus{
+	NotRun:        compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_NOT_RUN,
+	Passed:        compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_PASSED,
+	Failed:        compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_FAILED,
+	NeedsReview:   compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_NEEDS_REVIEW,
+	NotApplicable: compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_NOT_APPLICABLE,
+	Unknown:       compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_UNKNOWN,
+}
+
+var complianceStatuses = map[compassv1.ComplianceStatus]ComplianceStatus{
+	compassv1.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT:      ComplianceStatusCompliant,
+	compassv1.ComplianceStatus_COMPLIANCE_STATUS_NON_COMPLIANT:  ComplianceStatusNonCompliant,
+	compassv1.ComplianceStatus_COMPLIANCE_STATUS_NEEDS_REVIEW:   ComplianceStatusNeedsReview,
+	compassv1.ComplianceStatus_COMPLIANCE_STATUS_EXEMPT:         ComplianceStatusExempt,
+	compassv1.ComplianceStatus_COMPLIANCE_STATUS_NOT_APPLICABLE: ComplianceStatusNotApplicable,
+	compassv1.ComplianceStatus_COMPLIANCE_STATUS_UNKNOWN:        ComplianceStatusUnknown,
+}
+
+var enrichmentStatuses = map[compassv1.EnrichmentStatus]ComplianceEnrichmentStatus{
+	compassv1.EnrichmentStatus_ENRICHMENT_STATUS_SUCCESS:  ComplianceEnrichmentStatusSuccess,
+	compassv1.EnrichmentStatus_ENRICHMENT_STATUS_UNMAPPED: ComplianceEnrichmentStatusUnmapped,
+	compassv1.EnrichmentStatus_ENRICHMENT_STATUS_PARTIAL:  ComplianceEnrichmentStatusPartial,
+	compassv1.EnrichmentStatus_ENRICHMENT_STATUS_UNKNOWN:  ComplianceEnrichmentStatusUnknown,
+	compassv1.EnrichmentStatus_ENRICHMENT_STATUS_SKIPPED:  ComplianceEnrichmentStatusSkipped,
+}
+
+var riskLevels = map[compassv1.RiskLevel]ComplianceRiskLevel{
+	compassv1.RiskLevel_RISK_LEVEL_INFORMATIONAL: Informational,
+	compassv1.RiskLevel_RISK_LEVEL_LOW:           Low,
+	compassv1.RiskLevel_RISK_LEVEL_MEDIUM:        Medium,
+	compassv1.RiskLevel_RISK_LEVEL_HIGH:          High,
+	compassv1.RiskLevel_RISK_LEVEL_CRITICAL:      Critical,
+}
+
+func toProtoRequest(req EnrichmentRequest) (*compassv1.EnrichmentRequest, error) {
+	evidence := &compassv1.Evidence{
+		Timestamp:               timestamppb.New(req.Evidence.Timestamp),
+		PolicyEngineName:        req.Evidence.PolicyEngineName,
+		PolicyRuleId:            req.Evidence.PolicyRuleId,
+		PolicyEvaluationStatus:  policyEvaluationStatuses[req.Evidence.PolicyEvaluationStatus],
+		PolicyTargetEnvironment: req.Evidence.PolicyTargetEnvironment,
+		PolicyTargetId:          req.Evidence.PolicyTargetId,
+	}
+	if req.Evidence.RawData != nil {
+		rawData, err := structpb.NewStruct(*req.Evidence.RawData)
+		if err != nil {
+			return nil, err
+		}
+		evidence.RawData = rawData
+	}
+
+	protoReq := &compassv1.EnrichmentRequest{Evidence: evidence}
+	if req.Mode != nil && *req.Mode == All {
+		protoReq.Mode = compassv1.EnrichmentMode_ENRICHMENT_MODE_ALL
+	}
+	return protoReq, nil
+}
+
+func fromProtoResponse(res *compassv1.EnrichmentResponse) *EnrichmentResponse {
+	enrichRes := &EnrichmentResponse{
+		Compliance: fromProtoCompliance(res.GetCompliance()),
+	}
+	if len(res.GetMatches()) > 0 {
+		matches := make([]Compliance, 0, len(res.GetMatches()))
+		for _, match := range res.GetMatches() {
+			matches = append(matches, fromProtoCompliance(match))
+		}
+		enrichRes.Matches = &matches
+	}
+	return enrichRes
+}
+
+func fromProtoCompliance(compliance *compassv1.Compliance) Compliance {
+	out := Compliance{
+		Control: ComplianceControl{
+			Id:                     compliance.GetControl().GetId(),
+			Category:               compliance.GetControl().GetCategory(),
+			CatalogId:              compliance.GetControl().GetCatalogId(),
+			RemediationDescription: compliance.GetControl().RemediationDescription,
+		},
+		Frameworks: ComplianceFrameworks{
+			Frameworks:   compliance.GetFrameworks().GetFrameworks(),
+			Requirements: compliance.GetFrameworks().GetRequirements(),
+		},
+		Status:           complianceStatuses[compliance.GetStatus()],
+		EnrichmentStatus: enrichmentStatuses[compliance.GetEnrichmentStatus()],
+		TargetId:         compliance.TargetId,
+	}
+	if applicability := compliance.GetControl().GetApplicability(); len(applicability) > 0 {
+		out.Control.Applicability = &applicability
+	}
+	if level, ok := riskLevels[compliance.GetRisk().GetLevel()]; ok {
+		out.Risk = &ComplianceRisk{Level: &level}
+	}
+	if compliance.GetException() != nil {
+		out.Exception = &ComplianceException{
+			Id:     compliance.GetException().GetId(),
+			Active: compliance.GetException().GetActive(),
+		}
+	}
+	return out
+}
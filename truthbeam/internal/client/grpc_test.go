@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/complytime/complybeacon/truthbeam/internal/client/compassv1"
+)
+
+// fakeEnrichmentServer records the last request and replies with response.
+type fakeEnrichmentServer struct {
+	compassv1.UnimplementedEnrichmentServiceServer
+	request  *compassv1.EnrichmentRequest
+	response *compassv1.EnrichmentResponse
+}
+
+func (f *fakeEnrichmentServer) Enrich(_ context.Context, req *compassv1.EnrichmentRequest) (*compassv1.EnrichmentResponse, error) {
+	f.request = req
+	return f.response, nil
+}
+
+func newTestGRPCEnricher(t *testing.T, server compassv1.EnrichmentServiceServer) *GRPCEnricher {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	compassv1.RegisterEnrichmentServiceServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return NewGRPCEnricher(conn)
+}
+
+func TestGRPCEnricher_ApplyAllAttributes(t *testing.T) {
+	remediation := "Enable branch protection"
+	compliance := func(catalogID, controlID string) *compassv1.Compliance {
+		return &compassv1.Compliance{
+			Control: &compassv1.ComplianceControl{
+				Id:                     controlID,
+				Category:               "Access Control",
+				CatalogId:              catalogID,
+				Applicability:          []string{"Production"},
+				RemediationDescription: &remediation,
+			},
+			Frameworks: &compassv1.ComplianceFrameworks{
+				Frameworks:   []string{"NIST-800-53"},
+				Requirements: []string{"AC-1"},
+			},
+			Risk:             &compassv1.ComplianceRisk{Level: compassv1.RiskLevel_RISK_LEVEL_HIGH},
+			Exception:        &compassv1.ComplianceException{Id: "EX-1", Active: true},
+			Status:           compassv1.ComplianceStatus_COMPLIANCE_STATUS_EXEMPT,
+			EnrichmentStatus: compassv1.EnrichmentStatus_ENRICHMENT_STATUS_SUCCESS,
+		}
+	}
+	server := &fakeEnrichmentServer{
+		response: &compassv1.EnrichmentResponse{
+			Compliance: compliance("CIS", "CIS-1.1"),
+			Matches:    []*compassv1.Compliance{compliance("CIS", "CIS-1.1"), compliance("OSPS-B", "OSPS-AC-03.01")},
+		},
+	}
+	enricher := newTestGRPCEnricher(t, server)
+
+	logRecord, resource := createTestLogRecord()
+	logRecord.Attributes().PutStr(POLICY_EVALUATION_RESULT, string(Failed))
+	logRecord.Attributes().PutStr(POLICY_TARGET_ID, "repo-a")
+
+	err := ApplyAllAttributes(context.Background(), enricher, resource, logRecord, nil)
+	require.NoError(t, err)
+
+	require.NotNil(t, server.request)
+	assert.Equal(t, compassv1.EnrichmentMode_ENRICHMENT_MODE_ALL, server.request.GetMode())
+	assert.Equal(t, "test-policy-123", server.request.GetEvidence().GetPolicyRuleId())
+	assert.Equal(t, "test-source", server.request.GetEvidence().GetPolicyEngineName())
+	assert.Equal(t, compassv1.PolicyEvaluationStatus_POLICY_EVALUATION_STATUS_FAILED, server.request.GetEvidence().GetPolicyEvaluationStatus())
+	assert.Equal(t, "repo-a", server.request.GetEvidence().GetPolicyTargetId())
+
+	attrs := logRecord.Attributes().AsRaw()
+	assert.Equal(t, string(ComplianceEnrichmentStatusSuccess), attrs[COMPLIANCE_ENRICHMENT_STATUS])
+	assert.Equal(t, string(ComplianceStatusExempt), attrs[COMPLIANCE_STATUS])
+	assert.Equal(t, "CIS-1.1", attrs[COMPLIANCE_CONTROL_ID])
+	assert.Equal(t, "Access Control", attrs[COMPLIANCE_CONTROL_CATEGORY])
+	assert.Equal(t, "Enable branch protection", attrs[COMPLIANCE_REMEDIATION_DESCRIPTION])
+	assert.Equal(t, []interface{}{"Production"}, attrs[COMPLIANCE_CONTROL_APPLICABILITY])
+	assert.Equal(t, []interface{}{"NIST-800-53"}, attrs[COMPLIANCE_FRAMEWORKS])
+	assert.Equal(t, []interface{}{"AC-1"}, attrs[COMPLIANCE_REQUIREMENTS])
+	assert.Equal(t, string(High), attrs[COMPLIANCE_RISK_LEVEL])
+	assert.Equal(t, "EX-1", attrs[COMPLIANCE_REMEDIATION_EXCEPTION_ID])
+	assert.Equal(t, true, attrs[COMPLIANCE_REMEDIATION_EXCEPTION_ACTIVE])
+	assert.Equal(t, []interface{}{"CIS-1.1", "OSPS-AC-03.01"}, attrs[COMPLIANCE_CONTROL_IDS])
+}
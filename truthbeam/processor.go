@@ -5,9 +5,11 @@ import (
 	"errors"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"github.com/complytime/complybeacon/truthbeam/internal/client"
 )
@@ -19,6 +21,10 @@ type truthBeamProcessor struct {
 	logger *zap.Logger
 
 	client *client.Client
+	// enricher calls Compass over the configured transport.
+	enricher client.Enricher
+	// conn is the gRPC connection when the gRPC transport is used.
+	conn *grpc.ClientConn
 
 	// TODO: Cache results by policy id
 }
@@ -46,9 +52,11 @@ func (t *truthBeamProcessor) processLogs(ctx context.Context, ld plog.Logs) (plo
 			ils := ilss.At(j)
 			logs := ils.LogRecords()
 			resource := rs.Resource()
-			for k := 0; k < logs.Len(); k++ {
+			// Records appended by fan-out are already enriched.
+			count := logs.Len()
+			for k := 0; k < count; k++ {
 				logRecord := logs.At(k)
-				err := client.ApplyAttributes(ctx, t.client, t.config.ClientConfig.Endpoint, resource, logRecord)
+				err := t.applyAttributes(ctx, resource, logRecord, logs)
 				if err != nil {
 					// We don't want to return an error here to ensure the evidence
 					// is not dropped. It will just be uncategorized.
@@ -60,8 +68,31 @@ func (t *truthBeamProcessor) processLogs(ctx context.Context, ld plog.Logs) (plo
 	return ld, nil
 }
 
-// start will add HTTP client and pre-fetch any policy data
+// applyAttributes enriches the log record according to the configured match
+// mode. In fan-out mode, records for further controls are appended to logs.
+func (t *truthBeamProcessor) applyAttributes(ctx context.Context, resource pcommon.Resource, logRecord plog.LogRecord, logs plog.LogRecordSlice) error {
+	switch t.config.MatchMode {
+	case MatchModeAttributes:
+		return client.ApplyAllAttributes(ctx, t.enricher, resource, logRecord, nil)
+	case MatchModeFanout:
+		return client.ApplyAllAttributes(ctx, t.enricher, resource, logRecord, logs.AppendEmpty)
+	default:
+		return client.ApplyEnrichment(ctx, t.enricher, resource, logRecord)
+	}
+}
+
+// start will add the HTTP or gRPC client and pre-fetch any policy data
 func (t *truthBeamProcessor) start(ctx context.Context, host component.Host) error {
+	if t.config.Transport == TransportGRPC {
+		conn, err := t.config.GRPC.ToClientConn(ctx, host, t.telemetry)
+		if err != nil {
+			return err
+		}
+		t.conn = conn
+		t.enricher = client.NewGRPCEnricher(conn)
+		return nil
+	}
+
 	httpClient, err := t.config.ClientConfig.ToClient(ctx, host, t.telemetry)
 	if err != nil {
 		return err
@@ -70,6 +101,15 @@ func (t *truthBeamProcessor) start(ctx context.Context, host component.Host) err
 	if err != nil {
 		return err
 	}
+	t.enricher = client.NewHTTPEnricher(t.client, t.config.ClientConfig.Endpoint)
 
 	return nil
 }
+
+// shutdown closes the gRPC connection, if any.
+func (t *truthBeamProcessor) shutdown(context.Context) error {
+	if t.conn != nil {
+		return t.conn.Close()
+	}
+	return nil
+}
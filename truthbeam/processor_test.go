@@ -3,6 +3,7 @@ package truthbeam
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -12,13 +13,18 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/extension/extensionauth"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
 
 	"github.com/complytime/complybeacon/truthbeam/internal/client"
+	"github.com/complytime/complybeacon/truthbeam/internal/client/compassv1"
 )
 
 // The processor tests validate the core processor functionality including log processing,
@@ -246,10 +252,229 @@ func TestProcessLogsWithMixedValidAndInvalidRecords(t *testing.T) {
 	assert.Equal(t, "NIST-800-53", attrs3.AsRaw()[client.COMPLIANCE_CONTROL_CATALOG_ID])
 }
 
+func TestProcessLogsMatchModes(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req client.EnrichmentRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		require.NoError(t, err)
+
+		match := func(catalogID, controlID string) client.Compliance {
+			return client.Compliance{
+				Control: client.ComplianceControl{
+					CatalogId: catalogID,
+					Category:  "Access Control",
+					Id:        controlID,
+				},
+				Frameworks: client.ComplianceFrameworks{
+					Requirements: []string{},
+					Frameworks:   []string{},
+				},
+				Status:           client.ComplianceStatusNonCompliant,
+				EnrichmentStatus: client.ComplianceEnrichmentStatusSuccess,
+			}
+		}
+		response := client.EnrichmentResponse{Compliance: match("CIS", "CIS-1.1")}
+		if req.Mode != nil && *req.Mode == client.All {
+			matches := []client.Compliance{match("CIS", "CIS-1.1"), match("OSPS-B", "OSPS-AC-03.01")}
+			response.Matches = &matches
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer mockServer.Close()
+
+	t.Run("first", func(t *testing.T) {
+		processor := createTestProcessorWithMode(t, mockServer.URL, MatchModeFirst)
+		logs := createTestLogs()
+		setRequiredAttributes(logs)
+
+		result, err := processor.processLogs(context.Background(), logs)
+		require.NoError(t, err)
+
+		records := result.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		require.Equal(t, 1, records.Len())
+		attrs := records.At(0).Attributes().AsRaw()
+		assert.Equal(t, "CIS-1.1", attrs[client.COMPLIANCE_CONTROL_ID])
+		assert.Nil(t, attrs[client.COMPLIANCE_CONTROL_IDS])
+	})
+
+	t.Run("attributes", func(t *testing.T) {
+		processor := createTestProcessorWithMode(t, mockServer.URL, MatchModeAttributes)
+		logs := createTestLogs()
+		setRequiredAttributes(logs)
+
+		result, err := processor.processLogs(context.Background(), logs)
+		require.NoError(t, err)
+
+		records := result.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		require.Equal(t, 1, records.Len())
+		attrs := records.At(0).Attributes().AsRaw()
+		assert.Equal(t, "CIS-1.1", attrs[client.COMPLIANCE_CONTROL_ID])
+		assert.Equal(t, []interface{}{"CIS-1.1", "OSPS-AC-03.01"}, attrs[client.COMPLIANCE_CONTROL_IDS])
+		assert.Equal(t, []interface{}{"CIS", "OSPS-B"}, attrs[client.COMPLIANCE_CONTROL_CATALOG_IDS])
+	})
+
+	t.Run("fanout", func(t *testing.T) {
+		processor := createTestProcessorWithMode(t, mockServer.URL, MatchModeFanout)
+		logs := createTestLogs()
+		setRequiredAttributes(logs)
+		logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().SetStr("evidence")
+
+		result, err := processor.processLogs(context.Background(), logs)
+		require.NoError(t, err)
+
+		records := result.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		require.Equal(t, 2, records.Len())
+		for i, expected := range []struct{ catalogID, controlID string }{
+			{"CIS", "CIS-1.1"},
+			{"OSPS-B", "OSPS-AC-03.01"},
+		} {
+			record := records.At(i)
+			attrs := record.Attributes().AsRaw()
+			assert.Equal(t, expected.catalogID, attrs[client.COMPLIANCE_CONTROL_CATALOG_ID])
+			assert.Equal(t, expected.controlID, attrs[client.COMPLIANCE_CONTROL_ID])
+			assert.Equal(t, "test-policy-123", attrs[client.POLICY_RULE_ID])
+			assert.Nil(t, attrs[client.COMPLIANCE_CONTROL_IDS])
+			assert.Equal(t, "evidence", record.Body().Str())
+		}
+	})
+}
+
+func TestProcessLogsGRPCTransport(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	compassv1.RegisterEnrichmentServiceServer(server, &fakeEnrichmentServer{})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Transport = TransportGRPC
+	cfg.GRPC.Endpoint = listener.Addr().String()
+	cfg.GRPC.TLS.Insecure = true
+	require.NoError(t, cfg.Validate())
+
+	settings := processortest.NewNopSettings(component.MustNewType("test"))
+	settings.Logger = zaptest.NewLogger(t)
+	processor, err := newTruthBeamProcessor(cfg, settings)
+	require.NoError(t, err)
+	require.NoError(t, processor.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, processor.shutdown(context.Background())) })
+
+	logs := createTestLogs()
+	setRequiredAttributes(logs)
+
+	result, err := processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	attrs := result.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	assert.Equal(t, string(client.ComplianceEnrichmentStatusSuccess), attrs[client.COMPLIANCE_ENRICHMENT_STATUS])
+	assert.Equal(t, string(client.ComplianceStatusCompliant), attrs[client.COMPLIANCE_STATUS])
+	assert.Equal(t, "test-policy-123", attrs[client.COMPLIANCE_CONTROL_ID])
+}
+
+func TestProcessLogsWithAuthExtension(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer enrich-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.EnrichmentResponse{
+			Compliance: client.Compliance{
+				Control:          client.ComplianceControl{CatalogId: "NIST-800-53", Category: "Access Control", Id: "AC-1"},
+				Status:           client.ComplianceStatusCompliant,
+				EnrichmentStatus: client.ComplianceEnrichmentStatusSuccess,
+			},
+		})
+	}))
+	defer mockServer.Close()
+
+	authID := component.MustNewID("bearertokenauth")
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = mockServer.URL
+	cfg.ClientConfig.Auth = configoptional.Some(configauth.Config{AuthenticatorID: authID})
+
+	settings := processortest.NewNopSettings(component.MustNewType("test"))
+	settings.Logger = zaptest.NewLogger(t)
+	processor, err := newTruthBeamProcessor(cfg, settings)
+	require.NoError(t, err)
+	host := &extensionHost{extensions: map[component.ID]component.Component{
+		authID: &bearerTokenAuth{token: "enrich-key"},
+	}}
+	require.NoError(t, processor.start(context.Background(), host))
+
+	logs := createTestLogs()
+	setRequiredAttributes(logs)
+	result, err := processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	attrs := result.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	assert.Equal(t, string(client.ComplianceStatusCompliant), attrs[client.COMPLIANCE_STATUS])
+}
+
+// extensionHost is a component.Host with the given extensions.
+type extensionHost struct {
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+// bearerTokenAuth is a client auth extension that sends a bearer token.
+type bearerTokenAuth struct {
+	component.StartFunc
+	component.ShutdownFunc
+	token string
+}
+
+var _ extensionauth.HTTPClient = (*bearerTokenAuth)(nil)
+
+func (a *bearerTokenAuth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+a.token)
+		return base.RoundTrip(req)
+	}), nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// fakeEnrichmentServer maps every policy rule to a compliant control with the
+// same ID.
+type fakeEnrichmentServer struct {
+	compassv1.UnimplementedEnrichmentServiceServer
+}
+
+func (f *fakeEnrichmentServer) Enrich(_ context.Context, req *compassv1.EnrichmentRequest) (*compassv1.EnrichmentResponse, error) {
+	return &compassv1.EnrichmentResponse{
+		Compliance: &compassv1.Compliance{
+			Control: &compassv1.ComplianceControl{
+				Id:        req.GetEvidence().GetPolicyRuleId(),
+				Category:  "Access Control",
+				CatalogId: "NIST-800-53",
+			},
+			Frameworks:       &compassv1.ComplianceFrameworks{},
+			Status:           compassv1.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT,
+			EnrichmentStatus: compassv1.EnrichmentStatus_ENRICHMENT_STATUS_SUCCESS,
+		},
+	}, nil
+}
+
 // Helper functions
 func createTestProcessor(t *testing.T, endpoint string) *truthBeamProcessor {
+	return createTestProcessorWithMode(t, endpoint, "")
+}
+
+func createTestProcessorWithMode(t *testing.T, endpoint string, mode MatchMode) *truthBeamProcessor {
 	cfg := &Config{
 		ClientConfig: confighttp.NewDefaultClientConfig(),
+		MatchMode:    mode,
 	}
 	cfg.ClientConfig.Endpoint = endpoint
 